@@ -0,0 +1,42 @@
+package jumplist
+
+// Aggregate folds the values with keys within [min, max] in key order, starting from init and
+// replacing the accumulator with fn(acc, value) for each one, in a single pass under the read lock
+// so nothing is copied out. fn must not write to the list. Sum, Count, MinValue and MaxValue are
+// ready made folds, such as list.Aggregate(from, to, Sum, 0.0).
+func (list *SkipList) Aggregate(min, max float64, fn func(acc, value interface{}) interface{}, init interface{}) interface{} {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	acc := init
+	for column := list.seek(min); column != nil && !list.keyLess(max, column.key); column = column.next[0] {
+		acc = fn(acc, column.Value)
+	}
+	return acc
+}
+
+// Sum adds up float64 values, to Aggregate from 0.0.
+func Sum(acc, value interface{}) interface{} {
+	return acc.(float64) + value.(float64)
+}
+
+// Count counts values of any type, to Aggregate from 0. Counting by key is cheaper with SkipList.Count.
+func Count(acc, _ interface{}) interface{} {
+	return acc.(int) + 1
+}
+
+// MinValue keeps the smallest float64 value, to Aggregate from nil, which an empty range returns.
+func MinValue(acc, value interface{}) interface{} {
+	if acc == nil || value.(float64) < acc.(float64) {
+		return value
+	}
+	return acc
+}
+
+// MaxValue keeps the largest float64 value, to Aggregate from nil, which an empty range returns.
+func MaxValue(acc, value interface{}) interface{} {
+	if acc == nil || value.(float64) > acc.(float64) {
+		return value
+	}
+	return acc
+}
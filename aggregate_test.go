@@ -0,0 +1,34 @@
+package jumplist
+
+import "testing"
+
+func TestAggregate(t *testing.T) {
+	list := New()
+	for i := 1; i <= 10; i++ {
+		list.Set(float64(i), float64(i*i%7))
+	}
+
+	//values of keys 3 to 6: 2, 2, 4, 1
+	if sum := list.Aggregate(3, 6, Sum, 0.0); sum != 9.0 {
+		t.Error("Sum is", sum, "expected 9")
+	}
+	if n := list.Aggregate(3, 6, Count, 0); n != 4 {
+		t.Error("Count is", n, "expected 4")
+	}
+	if lo := list.Aggregate(3, 6, MinValue, nil); lo != 1.0 {
+		t.Error("MinValue is", lo, "expected 1")
+	}
+	if hi := list.Aggregate(3, 6, MaxValue, nil); hi != 4.0 {
+		t.Error("MaxValue is", hi, "expected 4")
+	}
+	if lo := list.Aggregate(20, 30, MinValue, nil); lo != nil {
+		t.Error("MinValue of an empty range must be nil, got", lo)
+	}
+
+	keys := list.Aggregate(2, 4, func(acc, value interface{}) interface{} {
+		return append(acc.([]float64), value.(float64))
+	}, []float64{})
+	if got := keys.([]float64); len(got) != 3 || got[0] != 4 || got[1] != 2 || got[2] != 2 {
+		t.Error("values must be folded in key order, got", got)
+	}
+}
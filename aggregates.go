@@ -0,0 +1,164 @@
+package jumplist
+
+import (
+	"cmp"
+	"math"
+)
+
+// Aggregates are running statistics of the values of a list, see WithAggregates. They are all zero
+// while no value is counted.
+type Aggregates struct {
+	Count    int //values counted
+	Sum      float64
+	Min, Max float64
+	Mean     float64
+}
+
+// aggregateState keeps the figures behind Aggregates. The numbers are counted in a List of their own,
+// so the minimum and maximum stay known when the current one is removed.
+type aggregateState struct {
+	number func(value interface{}) (float64, bool)
+	sum    float64
+	carry  float64 //of the compensated sum
+	inf    [2]int  //+Inf and -Inf values, kept out of the sum so that removing one leaves no NaN behind
+	counts *List[float64, int]
+	n      int
+}
+
+// WithAggregates keeps the count, sum, minimum, maximum and mean of the values up to date on every
+// insert, update and removal, so Aggregates returns them without a scan. number turns a value into
+// the figure counted and reports false for values left out; nil counts the Go integer and float types
+// and leaves out everything else. NaN is left out too. Each change costs O(log n) more under the write
+// lock. The sum is compensated, yet after many changes it may differ from a fresh sum by rounding.
+// Like WithMetadata it is not carried over to the lists made by Split, Clone and the like.
+func WithAggregates(number func(value interface{}) (float64, bool)) Option {
+	return func(list *SkipList) {
+		if number == nil {
+			number = numeric
+		}
+		list.aggregates = &aggregateState{number: number, counts: NewListFunc[float64, int](18, cmp.Less[float64])}
+	}
+}
+
+// numeric converts the Go integer and float types.
+func numeric(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// Aggregates returns the statistics kept by WithAggregates, all zero without it.
+func (list *SkipList) Aggregates() Aggregates {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	a := list.aggregates
+	if a == nil || a.n == 0 {
+		return Aggregates{}
+	}
+	sum := a.total()
+	return Aggregates{
+		Count: a.n,
+		Sum:   sum,
+		Min:   a.counts.startPointers[0].key,
+		Max:   a.counts.last(func(*Node[float64, int]) bool { return true }).key,
+		Mean:  sum / float64(a.n),
+	}
+}
+
+// aggregate counts a change in the statistics.
+func (list *SkipList) aggregate(kind EventKind, column *Column, old interface{}) {
+	a := list.aggregates
+	if a == nil {
+		return
+	}
+	switch kind {
+	case EventInsert:
+		a.count(column.Value, 1)
+	case EventUpdate:
+		a.count(old, -1)
+		a.count(column.Value, 1)
+	case EventDelete:
+		a.count(column.Value, -1)
+	}
+}
+
+// count adds value to the statistics, or takes it out for a delta of -1.
+func (a *aggregateState) count(value interface{}, delta int) {
+	x, ok := a.number(value)
+	if !ok || math.IsNaN(x) {
+		return
+	}
+	switch {
+	case math.IsInf(x, 1):
+		a.inf[0] += delta
+	case math.IsInf(x, -1):
+		a.inf[1] += delta
+	default:
+		a.add(float64(delta) * x)
+	}
+	a.n += delta
+
+	counts := a.counts
+	counts.moveCursors(x)
+	if node := counts.levelCursors[0][0]; counts.found(node, x) {
+		if node.Value += delta; node.Value == 0 {
+			counts.del(x)
+		}
+		return
+	}
+	counts.set(x, delta)
+}
+
+// add adds x to the sum with Neumaier's compensation.
+func (a *aggregateState) add(x float64) {
+	sum := a.sum + x
+	if math.Abs(a.sum) >= math.Abs(x) {
+		a.carry += (a.sum - sum) + x
+	} else {
+		a.carry += (x - sum) + a.sum
+	}
+	a.sum = sum
+}
+
+// total returns the sum, infinite while an infinite value is counted and NaN with both signs of them.
+func (a *aggregateState) total() float64 {
+	switch {
+	case a.inf[0] > 0 && a.inf[1] > 0:
+		return math.NaN()
+	case a.inf[0] > 0:
+		return math.Inf(1)
+	case a.inf[1] > 0:
+		return math.Inf(-1)
+	}
+	return a.sum + a.carry
+}
+
+// reset forgets every value, for clear.
+func (a *aggregateState) reset() {
+	*a = aggregateState{number: a.number, counts: NewListFunc[float64, int](18, cmp.Less[float64])}
+}
@@ -0,0 +1,100 @@
+package jumplist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestAggregates(t *testing.T) {
+	list := New(WithAggregates(nil))
+	if got := list.Aggregates(); got != (Aggregates{}) {
+		t.Fatal("an empty list aggregates to zero, got", got)
+	}
+
+	model := map[float64]float64{}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 5000; i++ {
+		key := float64(r.Intn(300))
+		switch r.Intn(5) {
+		case 0:
+			list.Del(key)
+			delete(model, key)
+		case 1:
+			list.Accumulate(key, 1.5, func(current, delta interface{}) interface{} { return current.(float64) + delta.(float64) })
+			model[key] += 1.5
+		case 2:
+			list.ReplaceOrInsert(key, float64(i))
+			model[key] = float64(i)
+		default:
+			list.Set(key, float64(r.Intn(1000)-500))
+			model[key] = list.Get(key).Value.(float64)
+		}
+	}
+	list.RemoveRange(100, 120)
+	for key := range model {
+		if key >= 100 && key <= 120 {
+			delete(model, key)
+		}
+	}
+
+	expected := Aggregates{Count: len(model), Min: math.Inf(1), Max: math.Inf(-1)}
+	for _, v := range model {
+		expected.Sum += v
+		expected.Min = math.Min(expected.Min, v)
+		expected.Max = math.Max(expected.Max, v)
+	}
+	expected.Mean = expected.Sum / float64(expected.Count)
+	got := list.Aggregates()
+	if got.Count != expected.Count || got.Min != expected.Min || got.Max != expected.Max ||
+		math.Abs(got.Sum-expected.Sum) > 1e-6 || math.Abs(got.Mean-expected.Mean) > 1e-9 {
+		t.Fatalf("Aggregates is %+v, expected %+v", got, expected)
+	}
+
+	list.Clear()
+	if got := list.Aggregates(); got != (Aggregates{}) {
+		t.Fatal("Clear must reset the aggregates, got", got)
+	}
+}
+
+func TestAggregatesNumber(t *testing.T) {
+	list := New(WithAggregates(nil))
+	list.Set(1, 3)
+	list.Set(2, uint8(4))
+	list.Set(3, "five")        //left out
+	list.Set(4, math.NaN())    //left out
+	list.Set(5, float32(-1.5)) //the smallest
+	if got := list.Aggregates(); got != (Aggregates{Count: 3, Sum: 5.5, Min: -1.5, Max: 4, Mean: 5.5 / 3}) {
+		t.Fatal("the built-in types must be counted, got", got)
+	}
+	list.Del(5)
+	if got := list.Aggregates(); got.Min != 3 || got.Count != 2 {
+		t.Fatal("removing the minimum must bring up the next one, got", got)
+	}
+
+	type order struct{ qty float64 }
+	book := New(WithAggregates(func(value interface{}) (float64, bool) { return value.(order).qty, true }))
+	book.LoadParallel([]KV{{1, order{2}}, {2, order{5}}, {3, order{1}}}, 2)
+	if got := book.Aggregates(); got.Count != 3 || got.Sum != 8 || got.Max != 5 {
+		t.Fatal("a number function and bulk loads must be counted, got", got)
+	}
+}
+
+func TestAggregatesInfinity(t *testing.T) {
+	list := New(WithAggregates(nil))
+	list.Set(1, 2.5)
+	list.Set(2, math.Inf(1))
+	if got := list.Aggregates(); !math.IsInf(got.Sum, 1) || !math.IsInf(got.Max, 1) {
+		t.Fatal("an infinite value makes the sum infinite, got", got)
+	}
+	list.Set(3, math.Inf(-1))
+	if got := list.Aggregates(); !math.IsNaN(got.Sum) {
+		t.Fatal("infinities of both signs sum to NaN, got", got)
+	}
+
+	list.Del(2)
+	list.Set(3, 4.0) //an update taking -Inf out
+	if got := list.Aggregates(); got.Sum != 6.5 || got.Count != 2 || got.Max != 4 || got.Mean != 3.25 {
+		t.Fatal("removing the infinities must leave the finite sum, got", got)
+	}
+}
@@ -0,0 +1,109 @@
+package jumplist
+
+import (
+	"testing"
+)
+
+// readOps are the read paths that must not allocate once warmed up, checked by TestReadAllocations and
+// measured by BenchmarkReads. It covers lookups, the iterators and the reusable finger and cursor.
+func readOps(list *SkipList) map[string]func() {
+	finger := list.NewFinger()
+	cursor := list.Cursor(0)
+	var dst []KV
+	key := 0.0
+	next := func() float64 { //walks the keys so fingers and cursors move
+		key++
+		if key >= float64(list.Len()) {
+			key = 0
+		}
+		return key
+	}
+	return map[string]func(){
+		"Get":      func() { list.Get(next()) },
+		"GetValue": func() { list.GetValue(next()) },
+		"Contains": func() { list.Contains(next()) },
+		"Rank":     func() { list.Rank(next()) },
+		"Floor":    func() { list.Floor(next() + 0.5) },
+		"Count":    func() { list.Count(next(), key+100) },
+		"All": func() {
+			for range list.All() {
+			}
+		},
+		"From": func() {
+			for range list.From(next()) {
+				break
+			}
+		},
+		"Backward": func() {
+			for range list.Backward() {
+				break
+			}
+		},
+		"ForEach":     func() { list.ForEach(func(float64, interface{}) bool { return true }) },
+		"RangeAppend": func() { dst = list.RangeAppend(dst[:0], next(), key+100) },
+		"Finger":      func() { finger.Seek(next()) },
+		"Cursor": func() {
+			cursor.Reset(next())
+			cursor.Next()
+		},
+	}
+}
+
+func TestReadAllocations(t *testing.T) {
+	list := New()
+	for i := 0; i < 1000; i++ {
+		list.Set(float64(i), i)
+	}
+	for name, op := range readOps(list) {
+		if allocs := testing.AllocsPerRun(100, op); allocs != 0 {
+			t.Errorf("%v allocates %v times per call", name, allocs)
+		}
+	}
+
+	//a finger whose list changed starts over in its own memory
+	finger := list.NewFinger()
+	if allocs := testing.AllocsPerRun(100, func() {
+		list.mutex.Lock()
+		list.version++
+		list.mutex.Unlock()
+		finger.Seek(500)
+	}); allocs != 0 {
+		t.Error("Seek after a change allocates", allocs, "times per call")
+	}
+
+	m, frozen := &Map{}, list.Freeze()
+	generic, sorted := NewList[int, int](), NewSortedMap[int, int]()
+	for i := 0; i < 1000; i++ {
+		m.Store(float64(i), i)
+		generic.Set(i, i)
+		sorted.Set(i, i)
+	}
+	for name, op := range map[string]func(){
+		"Map.Load":         func() { m.Load(500) },
+		"Map.Range":        func() { m.Range(func(float64, interface{}) bool { return true }) },
+		"FrozenList.Get":   func() { frozen.Get(500) },
+		"FrozenList.All":   func() { frozen.All()(func(float64, interface{}) bool { return true }) },
+		"List.Get":         func() { generic.Get(500) },
+		"SortedMap.Get":    func() { sorted.Get(500) },
+		"SortedMap.Ascend": func() { sorted.Ascend()(func(int, int) bool { return true }) },
+	} {
+		if allocs := testing.AllocsPerRun(100, op); allocs != 0 {
+			t.Errorf("%v allocates %v times per call", name, allocs)
+		}
+	}
+}
+
+func BenchmarkReads(b *testing.B) {
+	list := New()
+	for i := 0; i < 100000; i++ {
+		list.Set(float64(i), i)
+	}
+	for name, op := range readOps(list) {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				op()
+			}
+		})
+	}
+}
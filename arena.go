@@ -0,0 +1,100 @@
+package jumplist
+
+import "sync"
+
+// Arena hands out columns and their pointer slices carved from large slabs, so a list built
+// through it costs a few big allocations instead of several per column. Memory is given back all
+// at once by Reset. An Arena may be shared by several lists.
+type Arena struct {
+	mutex    sync.Mutex
+	slabSize int
+
+	columns  slabs[Column]
+	pointers slabs[*Column]
+	spans    slabs[int]
+}
+
+// slabs carves slices of T out of a growing set of slabs.
+type slabs[T any] struct {
+	slabs [][]T
+	slab  int //index of the slab being carved
+	used  int //items taken from that slab
+}
+
+// take returns n items, skipping the tail of a slab that is too short for them.
+func (s *slabs[T]) take(n, slabSize int) []T {
+	if len(s.slabs) == 0 || s.used+n > len(s.slabs[s.slab]) {
+		if len(s.slabs) > 0 {
+			s.slab++
+		}
+		for s.slab < len(s.slabs) && len(s.slabs[s.slab]) < n {
+			s.slab++
+		}
+		if s.slab == len(s.slabs) {
+			if slabSize < n {
+				slabSize = n
+			}
+			s.slabs = append(s.slabs, make([]T, slabSize))
+		}
+		s.used = 0
+	}
+
+	items := s.slabs[s.slab][s.used : s.used+n : s.used+n] //capped, so appends cannot reach the neighbour
+	s.used += n
+	return items
+}
+
+// reset zeroes everything handed out so it can be collected and starts carving from the first slab.
+func (s *slabs[T]) reset() {
+	var zero T
+	for _, slab := range s.slabs {
+		for i := range slab {
+			slab[i] = zero
+		}
+	}
+	s.slab, s.used = 0, 0
+}
+
+// NewArena returns an arena that allocates slabs of slabSize columns (and pointers) at a time.
+func NewArena(slabSize int) *Arena {
+	if slabSize < 1 {
+		panic("slabSize must be positive")
+	}
+	return &Arena{slabSize: slabSize}
+}
+
+// WithArena makes the list allocate its columns from arena.
+func WithArena(arena *Arena) Option {
+	return func(list *SkipList) {
+		list.arena = arena
+	}
+}
+
+// WithOwnArena gives the list an arena of its own with slabs of slabSize columns. Clear and Reset
+// hand the whole arena back at once, so columns taken from the list must not be used after them.
+func WithOwnArena(slabSize int) Option {
+	return func(list *SkipList) {
+		list.arena, list.ownsArena = NewArena(slabSize), true //one per list, even when the option is reused
+	}
+}
+
+func (arena *Arena) column(level int) *Column {
+	arena.mutex.Lock()
+	defer arena.mutex.Unlock()
+
+	column := &arena.columns.take(1, arena.slabSize)[0]
+	column.next = arena.pointers.take(level, arena.slabSize)
+	column.span = arena.spans.take(level, arena.slabSize)
+	return column
+}
+
+// Reset releases everything handed out so far and keeps the slabs for reuse.
+// Every list built through the arena must be discarded first, they are invalid afterwards.
+func (arena *Arena) Reset() {
+	arena.mutex.Lock()
+	defer arena.mutex.Unlock()
+
+	arena.columns.reset()
+	arena.pointers.reset()
+	arena.spans.reset()
+}
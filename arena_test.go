@@ -0,0 +1,96 @@
+package jumplist
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestArena(t *testing.T) {
+	arena := NewArena(100) //smaller than the lists, so several slabs are needed
+
+	for round := 0; round < 5; round++ {
+		list := NewWithLevel(64, WithArena(arena)) //tall list for a wide spread of slice lengths
+		for i := 0; i < 1000; i++ {
+			list.Set(float64((i*7919)%1000), i)
+		}
+		list.Del(500)
+		checkSanity(list, t)
+
+		keys, _ := list.ExportColumns()
+		if len(keys) != 999 {
+			t.Fatalf("round %v: list holds %v keys, expected 999", round, len(keys))
+		}
+		for c := list.startPointers.next[0]; c != nil; c = c.next[0] {
+			if cap(c.next) != len(c.next) {
+				t.Fatalf("round %v: next slice of %v may overwrite its neighbour", round, c.key)
+			}
+		}
+		arena.Reset()
+	}
+
+	if len(arena.columns.slabs) != 10 {
+		t.Fatalf("arena grew to %v column slabs, expected them reused at 10", len(arena.columns.slabs))
+	}
+}
+
+func benchmarkShortLived(b *testing.B, arena *Arena) {
+	b.ReportAllocs()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	for i := 0; i < b.N; i++ {
+		list := New()
+		if arena != nil {
+			list = New(WithArena(arena))
+		}
+		for j := 0; j < 10000; j++ {
+			list.Set(float64(j), j)
+		}
+		if arena != nil {
+			arena.Reset()
+		}
+	}
+
+	runtime.ReadMemStats(&after)
+	b.ReportMetric(float64(after.PauseTotalNs-before.PauseTotalNs)/float64(b.N), "gc-pause-ns/op")
+}
+
+func BenchmarkShortLivedDefault(b *testing.B) {
+	benchmarkShortLived(b, nil)
+}
+
+func BenchmarkShortLivedArena(b *testing.B) {
+	benchmarkShortLived(b, NewArena(4096))
+}
+
+func TestWithOwnArena(t *testing.T) {
+	list := New(WithOwnArena(64))
+	for round := 0; round < 3; round++ {
+		for i := 0; i < 1000; i++ {
+			list.Set(float64(i), i)
+		}
+		checkSanity(list, t)
+		list.Clear()
+	}
+	if slabs := len(list.arena.columns.slabs); slabs != 16 {
+		t.Fatalf("arena grew to %v column slabs, expected Clear to reuse 16", slabs)
+	}
+
+	for i := 0; i < 1000; i++ {
+		list.Set(float64(i), i)
+	}
+	clone := list.Clone()
+	left, right := list.Split(500)
+	list.Clear() //the arena was given up, so the halves and the clone stay intact
+	list.Set(1, nil)
+	for _, l := range []*SkipList{clone, left, right} {
+		checkSanity(l, t)
+	}
+	if clone.Len() != 1000 || left.Len() != 500 || right.Get(999).Value.(int) != 999 {
+		t.Fatal("split halves and clones must not be reset with the arena")
+	}
+
+	if sharded := NewSharded(2, WithOwnArena(64)); sharded.shards[0].arena == sharded.shards[1].arena {
+		t.Fatal("every list must get its own arena")
+	}
+}
@@ -0,0 +1,112 @@
+package jumplist
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	// ErrDuplicateKey is returned by SetManyAtomic for a key given twice to a list without duplicates.
+	ErrDuplicateKey = errors.New("jumplist: duplicate key in batch")
+	// ErrOverCapacity is returned by SetManyAtomic when a bounded list would have to evict to take the batch.
+	ErrOverCapacity = errors.New("jumplist: batch exceeds capacity")
+)
+
+// GetMulti looks up every key in one ordered pass under one lock and returns the values of those present.
+// The keys are sorted first, so each lookup continues from the previous one like a Cursor instead of
+// descending from the top, which makes n lookups over nearby keys much cheaper than n calls to Get.
+func (list *SkipList) GetMulti(keys []float64) map[float64]interface{} {
+	sorted := append([]float64(nil), keys...)
+	sort.Slice(sorted, func(i, j int) bool { return list.keyLess(sorted[i], sorted[j]) })
+
+	if list.lru != nil && !list.lru.insertionOrder {
+		list.mutex.Lock()
+		defer list.mutex.Unlock()
+	} else {
+		list.mutex.RLock()
+		defer list.mutex.RUnlock()
+	}
+
+	values := make(map[float64]interface{}, len(keys))
+	cursor := &Cursor{list: list, fingers: make([]*Column, list.maxLevel)}
+	for _, key := range sorted {
+		stored := list.snap(key) //never before an earlier key, snapping keeps the order
+		cursor.seekTo(stored)
+		if next := list.unexpired(cursor.current, stored); next != nil {
+			values[key] = next.Value
+			list.touch(next)
+			list.trace("Get", key, next, true)
+		} else {
+			list.trace("Get", key, nil, false)
+		}
+	}
+	return values
+}
+
+// RemoveMulti removes every key in one ordered pass under one lock and returns how many were removed.
+// Like SetBatch, each removal continues from the cursors of the previous one. A key repeated in keys
+// removes one more of its columns in a multiset and nothing more otherwise.
+func (list *SkipList) RemoveMulti(keys []float64) int {
+	sorted := append([]float64(nil), keys...)
+	sort.Slice(sorted, func(i, j int) bool { return list.keyLess(sorted[i], sorted[j]) })
+
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	for i := range list.levelCursors {
+		list.levelCursors[i], list.cursorRanks[i] = &list.startPointers, 0
+	}
+	list.cursorColumn = nil
+
+	removed := 0
+	for _, key := range sorted {
+		key = list.snap(key)
+		list.advanceCursorsTo(key, false)
+		column := list.levelCursors[0].next[0]
+		if column == nil || column.key != key {
+			list.trace("Del", key, nil, false)
+			continue
+		}
+		list.unlinkAtCursors()
+		list.trace("Del", key, column, true)
+		removed++
+	}
+	return removed
+}
+
+// SetManyAtomic sets every item under one lock, or none of them. The whole batch is checked before the
+// first item is applied: a NaN key, a key given twice to a list without duplicates or a batch that would
+// make a bounded list evict fails it, and the list is left as it was. Readers never see part of a batch.
+func (list *SkipList) SetManyAtomic(items []KV) error {
+	order := list.keyOrder(items)
+	for n, i := range order {
+		key := items[i].Key
+		if key != key {
+			return fmt.Errorf("%w at item %v", errNaNKey, i)
+		}
+		if n > 0 && key == items[order[n-1]].Key && !list.duplicates {
+			return fmt.Errorf("%w %v", ErrDuplicateKey, key)
+		}
+	}
+
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	if list.capacity > 0 {
+		inserts := len(items)
+		if !list.duplicates {
+			for _, item := range items {
+				if next := list.seek(item.Key); next != nil && next.key == item.Key {
+					inserts-- //an update, the count stays
+				}
+			}
+		}
+		if list.length+inserts > list.capacity {
+			return ErrOverCapacity
+		}
+	}
+
+	list.setBatch(items, order)
+	return nil
+}
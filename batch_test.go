@@ -0,0 +1,135 @@
+package jumplist
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestGetMulti(t *testing.T) {
+	list := New()
+	for i := 0; i < 1000; i++ {
+		list.Set(float64(i*2), i)
+	}
+
+	values := list.GetMulti([]float64{1998, 4, 3, 4, -1, 0, 2000})
+	if len(values) != 3 || values[1998] != 999 || values[4] != 2 || values[0] != 0 {
+		t.Fatal("wrong values", values)
+	}
+	if len(list.GetMulti(nil)) != 0 {
+		t.Fatal("no keys must find nothing")
+	}
+
+	lru := NewLRU(18, 3)
+	lru.Set(1, nil)
+	lru.Set(2, nil)
+	lru.Set(3, nil)
+	lru.GetMulti([]float64{1})
+	lru.Set(4, nil)
+	if lru.Get(1) == nil || lru.Get(2) != nil {
+		t.Fatal("GetMulti must count as a use")
+	}
+}
+
+func TestRemoveMulti(t *testing.T) {
+	list := New()
+	expected := map[float64]bool{}
+	for i := 0; i < 1000; i++ {
+		list.Set(float64(i), nil)
+		expected[float64(i)] = true
+	}
+
+	r := rand.New(rand.NewSource(1))
+	keys := []float64{-5, 1000, 500, 500}
+	for i := 0; i < 300; i++ {
+		keys = append(keys, float64(r.Intn(1000)))
+	}
+	removed := 0
+	for _, key := range keys {
+		if expected[key] {
+			delete(expected, key)
+			removed++
+		}
+	}
+
+	if n := list.RemoveMulti(keys); n != removed {
+		t.Fatalf("removed %v, expected %v", n, removed)
+	}
+	checkSanity(list, t)
+	if list.Len() != len(expected) {
+		t.Fatal("wrong length", list.Len())
+	}
+	for key := range expected {
+		if list.Get(key) == nil {
+			t.Fatalf("key %v must be kept", key)
+		}
+	}
+
+	multi := New(AllowDuplicates())
+	multi.Set(1, "a")
+	multi.Set(1, "b")
+	multi.Set(1, "c")
+	if n := multi.RemoveMulti([]float64{1, 1}); n != 2 || multi.Len() != 1 {
+		t.Fatal("a repeated key must remove one column each", n)
+	}
+	checkSanity(multi, t)
+}
+
+func BenchmarkGetMulti(b *testing.B) {
+	keys := make([]float64, 1000)
+	for i := range keys {
+		keys[i] = float64(i * 100)
+	}
+	b.Run("Get", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, key := range keys {
+				benchList.Get(key)
+			}
+		}
+	})
+	b.Run("GetMulti", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchList.GetMulti(keys)
+		}
+	})
+}
+
+func TestSetManyAtomic(t *testing.T) {
+	list := New()
+	list.Set(1, "old")
+	if err := list.SetManyAtomic([]KV{{3, "c"}, {1, "a"}, {2, "b"}}); err != nil {
+		t.Fatal(err)
+	}
+	checkSanity(list, t)
+	if list.Len() != 3 || list.Get(1).Value != "a" || list.Get(3).Value != "c" {
+		t.Fatal("every item must be applied", list.Len())
+	}
+
+	for _, batch := range [][]KV{{{4, nil}, {math.NaN(), nil}}, {{4, nil}, {5, nil}, {4, nil}}} {
+		if err := list.SetManyAtomic(batch); err == nil {
+			t.Fatal("an invalid batch must fail", batch)
+		}
+		if list.Len() != 3 || list.Contains(4) {
+			t.Fatal("a failed batch must leave the list untouched")
+		}
+	}
+	if err := list.SetManyAtomic([]KV{{4, nil}, {4, nil}}); !errors.Is(err, ErrDuplicateKey) {
+		t.Fatal("wrong error", err)
+	}
+
+	multi := New(AllowDuplicates())
+	if err := multi.SetManyAtomic([]KV{{1, "a"}, {1, "b"}}); err != nil || multi.Len() != 2 {
+		t.Fatal("multisets take equal keys", err)
+	}
+
+	bounded := NewWithCapacity(3, EvictSmallest)
+	bounded.Set(1, nil)
+	bounded.Set(2, nil)
+	if err := bounded.SetManyAtomic([]KV{{2, "updated"}, {3, nil}}); err != nil {
+		t.Fatal("updates do not count against the capacity", err)
+	}
+	if err := bounded.SetManyAtomic([]KV{{4, nil}}); !errors.Is(err, ErrOverCapacity) || bounded.Len() != 3 || !bounded.Contains(1) {
+		t.Fatal("a batch that would evict must fail", err)
+	}
+}
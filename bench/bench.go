@@ -0,0 +1,156 @@
+// Package bench runs configurable workloads against a list and reports throughput and latency, for
+// comparing locking modes, sharding, the probability and the level count on the hardware at hand:
+//
+//	w := bench.Workload{Ops: 1000000, Goroutines: 8, ReadRatio: 0.9, Keys: 100000, Distribution: bench.Zipf}
+//	fmt.Println(bench.Run(jumplist.New(), w))
+//	fmt.Println(bench.Run(jumplist.NewSharded(8), w))
+package bench
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/abbychau/jumplist"
+)
+
+// Target is what a workload runs against, a *jumplist.SkipList or a *jumplist.ShardedSkipList.
+type Target interface {
+	Get(key float64) *jumplist.Column
+	Set(key float64, value interface{}) *jumplist.Column
+}
+
+// Distribution chooses the keys a workload touches.
+type Distribution int
+
+const (
+	Uniform    Distribution = iota //every key equally likely
+	Zipf                           //a few hot keys take most of the operations, see Workload.ZipfS
+	Sequential                     //each goroutine walks the keys in order from its own start
+)
+
+// latencyEvery is how often an operation is timed, timing every one would cost more than a lookup.
+const latencyEvery = 16
+
+// Workload describes a benchmark run. Zero fields take the defaults noted on them.
+type Workload struct {
+	Ops          int     //operations in total, split between the goroutines, 1000000 by default
+	Goroutines   int     //1 by default
+	ReadRatio    float64 //share of the operations that are Gets, the rest are Sets
+	Keys         int     //size of the key space, keys are 0 to Keys-1, 100000 by default
+	Distribution Distribution
+	ZipfS        float64 //skew of Zipf, above 1, 1.1 by default
+	Seed         int64   //of the key and operation choices, 1 by default
+	Preload      bool    //set every key before timing, so reads hit
+}
+
+// Report is the outcome of a run. Latencies are measured on every 16th operation.
+type Report struct {
+	Ops       int
+	Elapsed   time.Duration
+	OpsPerSec float64
+	P50, P99  time.Duration
+	Max       time.Duration
+}
+
+func (r Report) String() string {
+	return fmt.Sprintf("%v ops in %v: %.0f ops/s, p50 %v, p99 %v, max %v", r.Ops, r.Elapsed.Round(time.Millisecond), r.OpsPerSec, r.P50, r.P99, r.Max)
+}
+
+func (w *Workload) defaults() {
+	if w.Ops <= 0 {
+		w.Ops = 1000000
+	}
+	if w.Goroutines <= 0 {
+		w.Goroutines = 1
+	}
+	if w.Keys <= 0 {
+		w.Keys = 100000
+	}
+	if w.ZipfS <= 1 {
+		w.ZipfS = 1.1
+	}
+	if w.Seed == 0 {
+		w.Seed = 1
+	}
+}
+
+// keys returns the key generator of goroutine g.
+func (w *Workload) keys(g int, r *rand.Rand) func() float64 {
+	switch w.Distribution {
+	case Zipf:
+		zipf := rand.NewZipf(r, w.ZipfS, 1, uint64(w.Keys-1))
+		return func() float64 { return float64(zipf.Uint64()) }
+	case Sequential:
+		next := g * w.Keys / w.Goroutines
+		return func() float64 {
+			key := next % w.Keys
+			next++
+			return float64(key)
+		}
+	default:
+		return func() float64 { return float64(r.Intn(w.Keys)) }
+	}
+}
+
+// Run runs the workload against target and reports how it went.
+func Run(target Target, w Workload) Report {
+	w.defaults()
+	if w.Preload {
+		for key := 0; key < w.Keys; key++ {
+			target.Set(float64(key), key)
+		}
+	}
+
+	latencies := make([][]time.Duration, w.Goroutines)
+	start := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	for g := 0; g < w.Goroutines; g++ {
+		ops := w.Ops / w.Goroutines
+		if g < w.Ops%w.Goroutines {
+			ops++
+		}
+		r := rand.New(rand.NewSource(w.Seed + int64(g)))
+		key := w.keys(g, r)
+
+		wg.Add(1)
+		go func(g, ops int) {
+			defer wg.Done()
+			timed := make([]time.Duration, 0, ops/latencyEvery+1)
+			<-start
+			for i := 0; i < ops; i++ {
+				k, read := key(), r.Float64() < w.ReadRatio
+				var began time.Time
+				if i%latencyEvery == 0 {
+					began = time.Now()
+				}
+				if read {
+					target.Get(k)
+				} else {
+					target.Set(k, i)
+				}
+				if i%latencyEvery == 0 {
+					timed = append(timed, time.Since(began))
+				}
+			}
+			latencies[g] = timed
+		}(g, ops)
+	}
+
+	began := time.Now()
+	close(start)
+	wg.Wait()
+	elapsed := time.Since(began)
+
+	all := slices.Concat(latencies...)
+	slices.Sort(all)
+	report := Report{Ops: w.Ops, Elapsed: elapsed, OpsPerSec: float64(w.Ops) / elapsed.Seconds()}
+	if len(all) > 0 {
+		report.P50 = all[len(all)/2]
+		report.P99 = all[len(all)*99/100]
+		report.Max = all[len(all)-1]
+	}
+	return report
+}
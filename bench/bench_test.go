@@ -0,0 +1,41 @@
+package bench
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/abbychau/jumplist"
+)
+
+func TestRun(t *testing.T) {
+	for _, d := range []Distribution{Uniform, Zipf, Sequential} {
+		list := jumplist.New()
+		report := Run(list, Workload{Ops: 10000, Goroutines: 4, ReadRatio: 0.5, Keys: 1000, Distribution: d, Preload: true})
+		if report.Ops != 10000 || report.OpsPerSec <= 0 || report.P50 > report.P99 || report.P99 > report.Max {
+			t.Fatal("wrong report", report)
+		}
+		if list.Len() != 1000 {
+			t.Fatalf("the keys must stay within the key space, %v columns", list.Len())
+		}
+	}
+
+	list := jumplist.New()
+	Run(list, Workload{Ops: 100, Keys: 100, Distribution: Sequential})
+	if list.Len() != 100 {
+		t.Fatal("a sequential write only run must touch every key", list.Len())
+	}
+}
+
+func Example() {
+	w := Workload{Ops: 100000, Goroutines: 4, ReadRatio: 0.9, Keys: 10000, Distribution: Zipf, Preload: true}
+	for name, target := range map[string]Target{
+		"locked":  jumplist.New(),
+		"sharded": jumplist.NewSharded(4),
+	} {
+		report := Run(target, w)
+		fmt.Println(name, report.Ops)
+	}
+	// Unordered output:
+	// locked 100000
+	// sharded 100000
+}
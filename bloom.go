@@ -0,0 +1,93 @@
+package jumplist
+
+import "math"
+
+const bloomMinCapacity = 1024 //keys a filter is sized for at least, so small lists do not rebuild all the time
+
+// bloomFilter answers whether a key may be in the list. Bits cannot be cleared, so removed keys stay
+// in the filter as false positives until it is rebuilt from the columns.
+type bloomFilter struct {
+	bits     []uint64
+	k        int     //bits set per key
+	rate     float64 //false positive rate at capacity
+	capacity int
+	added    int //keys added since the last rebuild
+	removed  int //and removed, still answering true
+}
+
+// WithBloomFilter puts a Bloom filter in front of Get and Contains, so most lookups of absent keys
+// return after a few bit tests instead of a descent. It is sized for twice the length at the given
+// false positive rate and rebuilt from level 0 under the write lock when the inserts outgrow it, or when
+// removals leave too many stale bits behind, so upkeep is amortized O(1) per change.
+func WithBloomFilter(falsePositiveRate float64) Option {
+	if !(falsePositiveRate > 0 && falsePositiveRate < 1) {
+		panic("falsePositiveRate must be between 0 and 1")
+	}
+	return func(list *SkipList) {
+		list.bloom = newBloomFilter(bloomMinCapacity, falsePositiveRate)
+	}
+}
+
+// newBloomFilter sizes the filter for n keys, m = -n ln(rate) / ln(2)^2 bits and k = m/n ln(2) of them per key.
+func newBloomFilter(n int, rate float64) *bloomFilter {
+	m := int(math.Ceil(-float64(n) * math.Log(rate) / (math.Ln2 * math.Ln2)))
+	words := (m + 63) / 64
+	k := int(math.Round(float64(words*64) / float64(n) * math.Ln2))
+	return &bloomFilter{bits: make([]uint64, words), k: max(k, 1), rate: rate, capacity: n}
+}
+
+// probe returns the hash pair of key, bit i of it being h1 + i*h2 modulo the filter size.
+func (f *bloomFilter) probe(key float64) (h1, h2, m uint64) {
+	h := hashKey(key)
+	return h, h>>32 | h<<32 | 1, uint64(len(f.bits) * 64) //h2 is odd, so the steps do not repeat early
+}
+
+func (f *bloomFilter) add(key float64) {
+	h1, h2, m := f.probe(key)
+	for i := 0; i < f.k; i++ {
+		at := (h1 + uint64(i)*h2) % m
+		f.bits[at/64] |= 1 << (at % 64)
+	}
+	f.added++
+}
+
+func (f *bloomFilter) mayContain(key float64) bool {
+	h1, h2, m := f.probe(key)
+	for i := 0; i < f.k; i++ {
+		at := (h1 + uint64(i)*h2) % m
+		if f.bits[at/64]&(1<<(at%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// filterKey updates the Bloom filter for a change, rebuilding it once it is full or mostly stale.
+func (list *SkipList) filterKey(kind EventKind, column *Column) {
+	if list.bloom == nil {
+		return
+	}
+	switch kind {
+	case EventInsert:
+		list.bloom.add(column.key)
+		if list.bloom.added > list.bloom.capacity {
+			list.refilter()
+		}
+	case EventDelete:
+		list.bloom.removed++
+		if list.bloom.removed > list.bloom.capacity/2 {
+			list.refilter()
+		}
+	}
+}
+
+// refilter rebuilds the Bloom filter from level 0, sized for twice the length.
+func (list *SkipList) refilter() {
+	if list.bloom == nil {
+		return
+	}
+	list.bloom = newBloomFilter(max(2*list.length, bloomMinCapacity), list.bloom.rate)
+	for column := list.startPointers.next[0]; column != nil; column = column.next[0] {
+		list.bloom.add(column.key)
+	}
+}
@@ -0,0 +1,89 @@
+package jumplist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestBloomFilter(t *testing.T) {
+	list := New(WithBloomFilter(0.01))
+	for i := 0; i < 10000; i++ {
+		list.Set(float64(i*2), i)
+	}
+	checkSanity(list, t)
+	for i := 0; i < 10000; i++ {
+		if list.Get(float64(i*2)) == nil || !list.Contains(float64(i*2)) {
+			t.Fatalf("key %v must be found", i*2)
+		}
+	}
+	if list.bloom.capacity < list.Len() {
+		t.Fatal("the filter must grow with the list", list.bloom.capacity)
+	}
+
+	positives := 0
+	for i := 0; i < 10000; i++ {
+		if list.bloom.mayContain(float64(i*2 + 1)) {
+			positives++
+		}
+		if list.Get(float64(i*2+1)) != nil {
+			t.Fatalf("key %v must be absent", i*2+1)
+		}
+	}
+	if positives > 300 {
+		t.Fatalf("%v false positives out of 10000 at a rate of 0.01", positives)
+	}
+
+	//removals leave stale bits behind until they outnumber half the filter
+	for i := 0; i < 9900; i++ {
+		list.Del(float64(i * 2))
+	}
+	if list.bloom.removed > list.bloom.capacity/2 || list.bloom.capacity >= 10000 {
+		t.Fatal("heavy deletion must rebuild the filter", list.bloom.removed, list.bloom.capacity)
+	}
+	if list.Get(19800) == nil || list.Contains(0) {
+		t.Fatal("the rebuilt filter must keep the remaining keys only")
+	}
+	if !list.bloom.mayContain(math.Copysign(0, -1)) && list.bloom.mayContain(0) {
+		t.Fatal("-0 must hash like 0")
+	}
+
+	list.Clear()
+	if list.bloom.added != 0 || list.Contains(19800) {
+		t.Fatal("Clear must empty the filter")
+	}
+
+	keys := make([]float64, 5000)
+	values := make([]interface{}, len(keys))
+	r := rand.New(rand.NewSource(1))
+	for i := range keys {
+		keys[i] = r.Float64()
+	}
+	for name, built := range map[string]*SkipList{
+		"NewParallel": NewParallel(18, 4, keys, values, WithBloomFilter(0.01)),
+		"Load":        New(WithBloomFilter(0.01)),
+	} {
+		if name == "Load" {
+			built.Load(NewParallel(18, 4, keys, values).Dump())
+		}
+		for _, key := range keys {
+			if !built.Contains(key) {
+				t.Fatalf("%v must fill the filter with the keys it builds", name)
+			}
+		}
+	}
+}
+
+func BenchmarkBloomFilter(b *testing.B) {
+	for name, opts := range map[string][]Option{"plain": nil, "filtered": {WithBloomFilter(0.01)}} {
+		list := New(opts...)
+		for i := 0; i < 100000; i++ {
+			list.Set(float64(i*2), nil)
+		}
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				list.Contains(float64(i%100000*2 + 1)) //absent
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+// Package btree mirrors the Item based API of github.com/google/btree on top of a jumplist.List, so
+// code written against that package can switch to a skip list by changing the import path, and
+// both can be benchmarked behind the same calls:
+//
+//	tree := btree.New(32)
+//	tree.ReplaceOrInsert(btree.Int(5))
+//	tree.AscendRange(btree.Int(1), btree.Int(10), func(i btree.Item) bool {
+//		fmt.Println(i)
+//		return true
+//	})
+//
+// Items equal under Less are the same item, as in google/btree. Iterating in descending order costs
+// O(log n) per item, the list having no back links. Like the original, a BTree must not be modified
+// while it is iterated.
+package btree
+
+import "github.com/abbychau/jumplist"
+
+// Item is an element of the tree. a.Less(b) and b.Less(a) both false means a and b are equal.
+type Item interface {
+	Less(than Item) bool
+}
+
+// ItemIterator is called on each item of an iteration, which stops when it returns false.
+type ItemIterator func(i Item) bool
+
+// Int implements Item for int.
+type Int int
+
+// Less compares Int items, than must be an Int.
+func (a Int) Less(than Item) bool {
+	return a < than.(Int)
+}
+
+// BTree is an ordered set of Items. Keys of the list are the items used to find a node, values the
+// items last stored, which ReplaceOrInsert swaps in place.
+type BTree struct {
+	list *jumplist.List[Item, Item]
+}
+
+// New returns an empty tree. degree has no meaning for a skip list and is only checked the way
+// google/btree checks it.
+func New(degree int) *BTree {
+	if degree <= 1 {
+		panic("bad degree")
+	}
+	return &BTree{list: newList()}
+}
+
+func newList() *jumplist.List[Item, Item] {
+	return jumplist.NewListFunc[Item, Item](18, Item.Less)
+}
+
+// ReplaceOrInsert adds item, replacing and returning an equal item already in the tree, or nil.
+func (t *BTree) ReplaceOrInsert(item Item) Item {
+	if item == nil {
+		panic("nil item being added to BTree")
+	}
+	old := t.Get(item)
+	t.list.Set(item, item)
+	return old
+}
+
+// Delete removes and returns the item equal to item, or nil if there is none.
+func (t *BTree) Delete(item Item) Item {
+	return value(t.list.Del(item))
+}
+
+// DeleteMin removes and returns the smallest item, nil if the tree is empty.
+func (t *BTree) DeleteMin() Item {
+	if node := t.list.Front(); node != nil {
+		return value(t.list.Del(node.Key()))
+	}
+	return nil
+}
+
+// DeleteMax removes and returns the largest item, nil if the tree is empty.
+func (t *BTree) DeleteMax() Item {
+	if node := t.list.Back(); node != nil {
+		return value(t.list.Del(node.Key()))
+	}
+	return nil
+}
+
+// Get returns the item equal to key, or nil.
+func (t *BTree) Get(key Item) Item {
+	return value(t.list.Get(key))
+}
+
+// Has reports whether an item equal to key is in the tree.
+func (t *BTree) Has(key Item) bool {
+	return t.list.Get(key) != nil
+}
+
+// Min returns the smallest item, nil if the tree is empty.
+func (t *BTree) Min() Item {
+	return value(t.list.Front())
+}
+
+// Max returns the largest item, nil if the tree is empty.
+func (t *BTree) Max() Item {
+	return value(t.list.Back())
+}
+
+// Len returns the number of items.
+func (t *BTree) Len() int {
+	return t.list.Len()
+}
+
+// Clear removes every item. The free list of google/btree does not exist here, so
+// addNodesToFreelist is ignored.
+func (t *BTree) Clear(addNodesToFreelist bool) {
+	t.list = newList()
+}
+
+// Clone returns a copy of the tree. google/btree copies lazily, this copies the items up front in
+// O(n log n), after which the two trees are independent.
+func (t *BTree) Clone() *BTree {
+	clone := &BTree{list: newList()}
+	t.Ascend(func(i Item) bool {
+		clone.list.Set(i, i)
+		return true
+	})
+	return clone
+}
+
+// Ascend calls iterator on every item in ascending order.
+func (t *BTree) Ascend(iterator ItemIterator) {
+	t.ascend(t.list.Front(), nil, iterator)
+}
+
+// AscendRange calls iterator on the items within [greaterOrEqual, lessThan) in ascending order.
+func (t *BTree) AscendRange(greaterOrEqual, lessThan Item, iterator ItemIterator) {
+	t.ascend(t.list.Ceiling(greaterOrEqual), lessThan, iterator)
+}
+
+// AscendLessThan calls iterator on the items less than pivot in ascending order.
+func (t *BTree) AscendLessThan(pivot Item, iterator ItemIterator) {
+	t.ascend(t.list.Front(), pivot, iterator)
+}
+
+// AscendGreaterOrEqual calls iterator on the items not less than pivot in ascending order.
+func (t *BTree) AscendGreaterOrEqual(pivot Item, iterator ItemIterator) {
+	t.ascend(t.list.Ceiling(pivot), nil, iterator)
+}
+
+// ascend walks from node up to stop excluded, or to the end if stop is nil.
+func (t *BTree) ascend(node *jumplist.Node[Item, Item], stop Item, iterator ItemIterator) {
+	for ; node != nil && (stop == nil || node.Key().Less(stop)); node = node.Next() {
+		if !iterator(node.Value) {
+			return
+		}
+	}
+}
+
+// Descend calls iterator on every item in descending order.
+func (t *BTree) Descend(iterator ItemIterator) {
+	t.descend(t.list.Back(), nil, iterator)
+}
+
+// DescendRange calls iterator on the items within (greaterThan, lessOrEqual] in descending order.
+func (t *BTree) DescendRange(lessOrEqual, greaterThan Item, iterator ItemIterator) {
+	t.descend(t.list.Floor(lessOrEqual), greaterThan, iterator)
+}
+
+// DescendLessOrEqual calls iterator on the items not greater than pivot in descending order.
+func (t *BTree) DescendLessOrEqual(pivot Item, iterator ItemIterator) {
+	t.descend(t.list.Floor(pivot), nil, iterator)
+}
+
+// DescendGreaterThan calls iterator on the items greater than pivot in descending order.
+func (t *BTree) DescendGreaterThan(pivot Item, iterator ItemIterator) {
+	t.descend(t.list.Back(), pivot, iterator)
+}
+
+// descend walks from node down to stop excluded, or to the front if stop is nil.
+func (t *BTree) descend(node *jumplist.Node[Item, Item], stop Item, iterator ItemIterator) {
+	for ; node != nil && (stop == nil || stop.Less(node.Key())); node = t.list.Lower(node.Key()) {
+		if !iterator(node.Value) {
+			return
+		}
+	}
+}
+
+func value(node *jumplist.Node[Item, Item]) Item {
+	if node == nil {
+		return nil
+	}
+	return node.Value
+}
@@ -0,0 +1,147 @@
+package btree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// tree is the API shared with google/btree, written against the interface the way a caller
+// switching between the two would.
+type tree interface {
+	ReplaceOrInsert(item Item) Item
+	Delete(item Item) Item
+	Get(key Item) Item
+	Len() int
+	AscendRange(greaterOrEqual, lessThan Item, iterator ItemIterator)
+	DescendRange(lessOrEqual, greaterThan Item, iterator ItemIterator)
+}
+
+var _ tree = New(2)
+
+func collect(walk func(ItemIterator)) []int {
+	items := []int{}
+	walk(func(i Item) bool {
+		items = append(items, int(i.(Int)))
+		return true
+	})
+	return items
+}
+
+func TestBTree(t *testing.T) {
+	tr := New(32)
+	r := rand.New(rand.NewSource(1))
+	model := map[int]bool{}
+	for i := 0; i < 3000; i++ {
+		k := r.Intn(200)
+		if r.Intn(3) == 0 {
+			if removed := tr.Delete(Int(k)); (removed != nil) != model[k] {
+				t.Fatalf("Delete(%v) returned %v, present: %v", k, removed, model[k])
+			}
+			delete(model, k)
+		} else {
+			if old := tr.ReplaceOrInsert(Int(k)); (old != nil) != model[k] {
+				t.Fatalf("ReplaceOrInsert(%v) returned %v, present: %v", k, old, model[k])
+			}
+			model[k] = true
+		}
+	}
+
+	keys := []int{}
+	for k := range model {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	if tr.Len() != len(keys) {
+		t.Fatalf("tree holds %v items, expected %v", tr.Len(), len(keys))
+	}
+	if got := collect(tr.Ascend); !slices.Equal(got, keys) {
+		t.Fatal("Ascend is", got, "expected", keys)
+	}
+	reversed := slices.Clone(keys)
+	slices.Reverse(reversed)
+	if got := collect(tr.Descend); !slices.Equal(got, reversed) {
+		t.Fatal("Descend is", got, "expected", reversed)
+	}
+
+	within := func(lo, hi int) []int { //[lo, hi)
+		items := []int{}
+		for _, k := range keys {
+			if k >= lo && k < hi {
+				items = append(items, k)
+			}
+		}
+		return items
+	}
+	for _, bounds := range [][2]int{{50, 100}, {-5, 7}, {190, 300}, {60, 60}, {100, 50}} {
+		lo, hi := bounds[0], bounds[1]
+		if got := collect(func(it ItemIterator) { tr.AscendRange(Int(lo), Int(hi), it) }); !slices.Equal(got, within(lo, hi)) {
+			t.Errorf("AscendRange(%v, %v) is %v, expected %v", lo, hi, got, within(lo, hi))
+		}
+		expected := within(lo+1, hi+1) //(lo, hi] on ints
+		slices.Reverse(expected)
+		if got := collect(func(it ItemIterator) { tr.DescendRange(Int(hi), Int(lo), it) }); !slices.Equal(got, expected) {
+			t.Errorf("DescendRange(%v, %v) is %v, expected %v", hi, lo, got, expected)
+		}
+	}
+	if got := collect(func(it ItemIterator) { tr.AscendLessThan(Int(keys[3]), it) }); !slices.Equal(got, keys[:3]) {
+		t.Error("AscendLessThan is", got, "expected", keys[:3])
+	}
+	if got := collect(func(it ItemIterator) { tr.AscendGreaterOrEqual(Int(keys[len(keys)-3]), it) }); !slices.Equal(got, keys[len(keys)-3:]) {
+		t.Error("AscendGreaterOrEqual is", got, "expected", keys[len(keys)-3:])
+	}
+	if got := collect(func(it ItemIterator) { tr.DescendLessOrEqual(Int(keys[2]), it) }); !slices.Equal(got, []int{keys[2], keys[1], keys[0]}) {
+		t.Error("DescendLessOrEqual is", got)
+	}
+	if got := collect(func(it ItemIterator) { tr.DescendGreaterThan(Int(keys[len(keys)-3]), it) }); !slices.Equal(got, []int{keys[len(keys)-1], keys[len(keys)-2]}) {
+		t.Error("DescendGreaterThan is", got)
+	}
+
+	stopped := 0
+	tr.Descend(func(Item) bool {
+		stopped++
+		return stopped < 3
+	})
+	if stopped != 3 {
+		t.Fatal("Descend must stop when the iterator returns false, it ran", stopped, "times")
+	}
+
+	clone := tr.Clone()
+	if tr.Min() != Int(keys[0]) || tr.DeleteMin() != Int(keys[0]) || tr.Has(Int(keys[0])) {
+		t.Fatal("Min and DeleteMin must return the smallest item", keys[0])
+	}
+	if tr.Max() != Int(keys[len(keys)-1]) || tr.DeleteMax() != Int(keys[len(keys)-1]) {
+		t.Fatal("Max and DeleteMax must return the largest item", keys[len(keys)-1])
+	}
+	if clone.Len() != len(keys) || !clone.Has(Int(keys[0])) {
+		t.Fatal("a clone must not see the changes of the original")
+	}
+	tr.Clear(false)
+	if tr.Len() != 0 || tr.Min() != nil || tr.DeleteMax() != nil {
+		t.Fatal("Clear must empty the tree")
+	}
+}
+
+// user is ordered by id alone, so a new name replaces the old one.
+type user struct {
+	id   int
+	name string
+}
+
+func (u user) Less(than Item) bool {
+	return u.id < than.(user).id
+}
+
+func TestReplaceOrInsert(t *testing.T) {
+	tr := New(2)
+	tr.ReplaceOrInsert(user{1, "ann"})
+	if old := tr.ReplaceOrInsert(user{1, "bob"}); old != (user{1, "ann"}) {
+		t.Fatal("ReplaceOrInsert must return the replaced item, got", old)
+	}
+	if got := tr.Get(user{id: 1}); got != (user{1, "bob"}) {
+		t.Fatal("Get must return the item stored last, got", got)
+	}
+	if tr.Len() != 1 {
+		t.Fatal("equal items must not be stored twice")
+	}
+}
@@ -0,0 +1,450 @@
+package jumplist
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+// KV is a key and value pair, used by the batch and bulk APIs.
+type KV struct {
+	Key   float64
+	Value interface{}
+}
+
+// tails tracks the end of a list while it is built in key order.
+type tails struct {
+	level []*pointerColumn //last pointerColumn on each level
+	rank  []int            //rank of each of them, the start pointers being 0
+	last  *Column
+}
+
+func (list *SkipList) newTails() *tails {
+	t := &tails{level: make([]*pointerColumn, list.maxLevel), rank: make([]int, list.maxLevel)}
+	for i := range t.level {
+		t.level[i] = &list.startPointers
+	}
+	return t
+}
+
+// appendSorted links a new column after the tails, so key must be greater than every key already in the list.
+func (list *SkipList) appendSorted(t *tails, key float64, value interface{}) *Column {
+	return list.appendColumn(t, list.randLevel(), key, value)
+}
+
+func (list *SkipList) appendColumn(t *tails, level int, key float64, value interface{}) *Column {
+	column := list.newColumn(level, key, value)
+	list.linkAtTails(t, column)
+	list.track(EventInsert, column, nil)
+	return column
+}
+
+// linkAtTails links column after the tails, its tower not pointing anywhere yet.
+func (list *SkipList) linkAtTails(t *tails, column *Column) {
+	rank := list.length + 1
+
+	for i := range column.next {
+		t.level[i].next[i] = column
+		t.level[i].span[i] = rank - t.rank[i]
+		t.level[i] = &column.pointerColumn
+		t.rank[i] = rank
+	}
+	for i := len(column.next); i < list.maxLevel; i++ {
+		t.level[i].span[i]++ //tails are the end of their level, so their span reaches the end of the list
+	}
+	column.prev = t.last
+	t.last = column
+
+	list.length++ //builders publish the count once they are done
+}
+
+// findTails walks down the right edge of the list in O(log n).
+func (list *SkipList) findTails() *tails {
+	t := list.newTails()
+	pointers := &list.startPointers
+	rank := 0
+
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		for pointers.next[i] != nil {
+			rank += pointers.span[i]
+			t.last = pointers.next[i]
+			pointers = &t.last.pointerColumn
+		}
+		t.level[i], t.rank[i] = pointers, rank
+	}
+	return t
+}
+
+// Append sets key like Set, optimized for keys larger than any in the list such as timestamps.
+// The end of every level is kept between calls, so appending in order takes amortized O(1) instead of
+// a descent from the top. Any other change drops them, and a key that is not past the end falls back to Set.
+func (list *SkipList) Append(key float64, value interface{}) *Column {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	if list.weigher != nil || list.epsilon > 0 { //the sums are kept and near keys snapped by the descent of Set
+		return list.set(key, value)
+	}
+	t := list.tail
+	if t == nil {
+		t = list.findTails()
+	}
+	if t.last != nil && (list.keyLess(key, t.last.key) || key == t.last.key && !list.tiesLast()) {
+		return list.set(key, value)
+	}
+
+	column := list.appendColumn(t, list.randLevel(), key, value)
+	list.resize(0)
+	if len(t.level) == list.maxLevel {
+		list.tail = t //unless the list just grew a level
+	}
+	list.touch(column)
+	list.notify(EventInsert, column, nil)
+	list.evict()
+	list.trace("Set", key, column, false)
+	return column
+}
+
+// NewFromSorted builds a list from keys in ascending order in O(n), linking every column at the tail.
+// Levels are not drawn at random: a column is promoted past a level whenever the count of columns on
+// that level crosses a multiple of e, so every level holds evenly spaced columns. Repeated keys keep the last value.
+func NewFromSorted(keys []float64, values []interface{}, opts ...Option) *SkipList {
+	if len(keys) != len(values) {
+		panic("keys and values must have the same length")
+	}
+
+	list := New(opts...)
+	list.reserve(len(keys))
+	levels := make(evenLevels, list.maxLevel)
+
+	t := list.newTails()
+	for i, key := range keys {
+		if t.last != nil && (key == t.last.key || list.keyLess(key, t.last.key)) {
+			if key != t.last.key {
+				panic("keys must be sorted in ascending order")
+			}
+			old := t.last.Value
+			t.last.Value = values[i]
+			list.track(EventUpdate, t.last, old)
+			continue
+		}
+
+		list.appendColumn(t, levels.next(), key, values[i])
+	}
+	list.reweighAll()
+	list.resize(0)
+
+	return list
+}
+
+// evenLevels hands out levels for columns appended in order, counting the columns that reached each level so far.
+type evenLevels []int
+
+func (counts evenLevels) next() int {
+	level := 1
+	for ; level <= len(counts); level++ {
+		counts[level-1]++
+		n := float64(counts[level-1])
+		if level == len(counts) || math.Floor(n/math.E) == math.Floor((n-1)/math.E) {
+			break //the count on this level did not cross a multiple of e
+		}
+	}
+	return level
+}
+
+// NewParallel builds a list from unsorted keys and values using up to workers goroutines.
+// Input is sorted in parallel, split into disjoint key ranges, each range is built with the append path
+// and the partitions are concatenated. For duplicated keys the last value wins, same as calling Set in order.
+// Options apply to the returned list, the partitions draw their levels from its source.
+func NewParallel(maxLevel, workers int, keys []float64, values []interface{}, opts ...Option) *SkipList {
+	if len(keys) != len(values) {
+		panic("keys and values must have the same length")
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	for _, key := range keys {
+		checkKey(key) //here rather than in a worker, where the panic could not be recovered
+	}
+
+	list := NewWithLevel(maxLevel, opts...)
+	list.appendParallel(parallelSort(keys, values, workers, list.keyLess), workers)
+	return list
+}
+
+// LoadParallel sets the items, sorted by key, using up to workers goroutines for bulk loads into a list
+// that is empty or whose keys all come before the items. The items are split into key ranges, each range
+// is built as a part of its own and the parts are stitched after the end of the list like NewParallel
+// does, without any descent. For repeated keys the last value wins, same as calling Set in order.
+// Otherwise, or when inserts need work of their own such as hooks, watchers, access tracking, a
+// capacity bound, WithEpsilon or ties not in FIFO order, it falls back to SetBatch under the lock. Unsorted items or a NaN key panic.
+func (list *SkipList) LoadParallel(items []KV, workers int) {
+	for i, item := range items {
+		checkKey(item.Key)
+		if i > 0 && list.keyLess(item.Key, items[i-1].Key) {
+			panic("keys must be sorted in ascending order")
+		}
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	if len(items) == 0 {
+		return
+	}
+	last := list.back()
+	past := last == nil || list.keyLess(last.key, items[0].Key) || list.tiesLast() && items[0].Key == last.key
+	if !past || list.duplicates && !list.tiesLast() || list.hooked() || len(list.watchers) > 0 || list.lru != nil || list.capacity > 0 || list.epsilon > 0 {
+		order := make([]int, len(items))
+		for i := range order {
+			order[i] = i
+		}
+		list.setBatch(items, order)
+		return
+	}
+	list.appendParallel(items, workers)
+	if list.metrics != nil {
+		list.metrics.sets.Add(int64(len(items))) //the parts count nothing
+	}
+}
+
+// appendParallel appends sorted entries past the last key of the list, building the parts in up to
+// workers goroutines. Parts never split a run of equal keys, which collapse to the last value unless
+// the list is a multiset.
+func (list *SkipList) appendParallel(entries []KV, workers int) {
+	if len(entries) == 0 {
+		return
+	}
+	list.reserve(list.length + len(entries))
+	maxLevel := list.maxLevel //the parts are built as tall as the list
+
+	if workers > len(entries) {
+		workers = len(entries)
+	}
+	partSize := (len(entries) + workers - 1) / workers
+
+	type part struct {
+		list  *SkipList
+		tails *tails
+		items []KV
+	}
+	parts := make([]part, 0, workers)
+	for lo := 0; lo < len(entries); {
+		hi := min(lo+partSize, len(entries))
+		for hi < len(entries) && entries[hi].Key == entries[hi-1].Key {
+			hi++ //equal keys go to the same part
+		}
+		p := NewWithLevel(list.levelCap, WithProbability(list.probability))
+		p.raise(maxLevel)
+		p.randomSeed = rand.NewSource(list.randomSeed.Int63()) //sources are not safe for concurrent use
+		p.duplicates, p.less = list.duplicates, list.less
+		p.seq = list.seq + uint64(lo) //numbers stay unique across the parts
+		parts = append(parts, part{p, p.newTails(), entries[lo:hi]})
+		lo = hi
+	}
+
+	wg := &sync.WaitGroup{}
+	for _, p := range parts {
+		wg.Add(1)
+		go func() {
+			for _, e := range p.items {
+				if t := p.tails; t.last != nil && t.last.key == e.Key && !p.list.duplicates {
+					old := t.last.Value
+					t.last.Value = e.Value
+					p.list.track(EventUpdate, t.last, old)
+					continue
+				}
+				p.list.appendSorted(p.tails, e.Key, e.Value)
+			}
+			wg.Done()
+		}()
+	}
+	wg.Wait()
+
+	//partitions are ordered and disjoint, so stitch every level of each part after the previous tails
+	t := list.findTails()
+	for _, p := range parts {
+		offset := list.length //ranks inside the part are shifted by everything before it
+		for i := 0; i < maxLevel; i++ {
+			if p.list.startPointers.next[i] == nil {
+				t.level[i].span[i] += p.list.length
+				continue
+			}
+			t.level[i].next[i] = p.list.startPointers.next[i]
+			t.level[i].span[i] = offset + p.list.startPointers.span[i] - t.rank[i]
+			t.level[i] = p.tails.level[i]
+			t.rank[i] = offset + p.tails.rank[i]
+		}
+		p.list.startPointers.next[0].prev = t.last
+		t.last = p.tails.last
+		list.length += p.list.length
+	}
+	if list.metadata != nil || list.aggregates != nil {
+		for column := parts[0].list.startPointers.next[0]; column != nil; column = column.next[0] {
+			list.stamp(EventInsert, column) //the parts recorded nothing
+			list.aggregate(EventInsert, column, nil)
+		}
+	}
+	list.seq += uint64(len(entries))
+	list.reweighAll()
+	list.resize(0)
+	list.reindex()
+	list.refilter()
+}
+
+// parallelSort returns the pairs sorted by less with duplicates collapsed to the last occurrence.
+func parallelSort(keys []float64, values []interface{}, workers int, less func(a, b float64) bool) []KV {
+	entries := make([]sortEntry, len(keys))
+	for i := range keys {
+		entries[i] = sortEntry{KV{keys[i], values[i]}, i}
+	}
+
+	if workers > len(entries) {
+		workers = len(entries)
+	}
+	if workers < 1 {
+		return nil
+	}
+
+	//sort the chunks concurrently
+	chunkSize := (len(entries) + workers - 1) / workers
+	chunks := [][]sortEntry{}
+	for lo := 0; lo < len(entries); lo += chunkSize {
+		hi := lo + chunkSize
+		if hi > len(entries) {
+			hi = len(entries)
+		}
+		chunks = append(chunks, entries[lo:hi])
+	}
+
+	wg := &sync.WaitGroup{}
+	for _, chunk := range chunks {
+		wg.Add(1)
+		go func(chunk []sortEntry) {
+			sort.Slice(chunk, func(i, j int) bool { return chunk[i].less(chunk[j], less) })
+			wg.Done()
+		}(chunk)
+	}
+	wg.Wait()
+
+	//merge neighbouring chunks pairwise until one is left
+	for len(chunks) > 1 {
+		merged := make([][]sortEntry, (len(chunks)+1)/2)
+		for i := 0; i < len(chunks); i += 2 {
+			if i+1 == len(chunks) {
+				merged[i/2] = chunks[i]
+				continue
+			}
+			wg.Add(1)
+			go func(dst int, a, b []sortEntry) {
+				merged[dst] = mergeEntries(a, b, less)
+				wg.Done()
+			}(i/2, chunks[i], chunks[i+1])
+		}
+		wg.Wait()
+		chunks = merged
+	}
+
+	//collapse duplicated keys, the later one overwrites like Set does
+	out := make([]KV, 0, len(entries))
+	for _, e := range chunks[0] {
+		if len(out) > 0 && out[len(out)-1].Key == e.Key {
+			out[len(out)-1].Value = e.Value
+			continue
+		}
+		out = append(out, e.KV)
+	}
+
+	return out
+}
+
+// sortEntry remembers the input position so equal keys keep their order without a stable sort.
+type sortEntry struct {
+	KV
+	pos int
+}
+
+func (e sortEntry) less(other sortEntry, less func(a, b float64) bool) bool {
+	if e.Key == other.Key {
+		return e.pos < other.pos
+	}
+	return less(e.Key, other.Key)
+}
+
+func mergeEntries(a, b []sortEntry, less func(a, b float64) bool) []sortEntry {
+	out := make([]sortEntry, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		if b[0].less(a[0], less) {
+			out = append(out, b[0])
+			b = b[1:]
+		} else {
+			out = append(out, a[0])
+			a = a[1:]
+		}
+	}
+	out = append(out, a...)
+	return append(out, b...)
+}
+
+// SetBatch sets every item while holding the lock once. Items are applied in key order, so each
+// insert continues from the cursors of the previous one instead of descending from the top.
+// For repeated keys the later item wins and both get the same column. The returned columns line up with items.
+func (list *SkipList) SetBatch(items []KV) []*Column {
+	order := list.keyOrder(items)
+
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	return list.setBatch(items, order)
+}
+
+// keyOrder returns the positions of items sorted by key, equal keys keeping their order.
+func (list *SkipList) keyOrder(items []KV) []int {
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return list.keyLess(items[order[a]].Key, items[order[b]].Key) })
+	return order
+}
+
+// setBatch sets the items in the given key order, see SetBatch.
+func (list *SkipList) setBatch(items []KV, order []int) []*Column {
+	columns := make([]*Column, len(items))
+	for i := range list.levelCursors {
+		list.levelCursors[i], list.cursorRanks[i] = &list.startPointers, 0
+	}
+	list.cursorColumn = nil
+
+	for _, i := range order {
+		key, value := list.snap(items[i].Key), items[i].Value //snapping keeps the order
+		if list.capacity > 0 {
+			list.moveCursorsTo(key, list.tiesLast()) //evictions move the cursors anywhere
+		} else {
+			list.advanceCursorsTo(key, list.tiesLast())
+		}
+		if list.duplicates {
+			list.stepTies(key, value)
+		}
+
+		column := list.levelCursors[0].next[0]
+		if !list.duplicates && column != nil && column.key == key {
+			old := column.Value
+			column.Value = value
+			list.dropExpiry(column)
+			list.touch(column)
+			list.notify(EventUpdate, column, old)
+			list.trace("Set", key, column, true)
+		} else {
+			column = list.insertAtCursors(key, value)
+			list.trace("Set", key, column, false)
+		}
+		columns[i] = column
+	}
+
+	return columns
+}
@@ -0,0 +1,293 @@
+package jumplist
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestNewParallel(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	keys := make([]float64, 10000)
+	values := make([]interface{}, len(keys))
+	for i := range keys {
+		keys[i] = float64(r.Intn(5000)) //plenty of duplicates
+		values[i] = i
+	}
+
+	sequential := New()
+	for i := range keys {
+		sequential.Set(keys[i], values[i])
+	}
+
+	for _, workers := range []int{1, 2, 3, 8, 64} {
+		list := NewParallel(18, workers, keys, values)
+		checkSanity(list, t)
+
+		a, b := list.startPointers.next[0], sequential.startPointers.next[0]
+		for a != nil && b != nil {
+			if a.key != b.key || a.Value != b.Value {
+				t.Fatalf("workers %v: got %v=%v, expected %v=%v", workers, a.key, a.Value, b.key, b.Value)
+			}
+			a, b = a.next[0], b.next[0]
+		}
+		if a != nil || b != nil || list.Len() != sequential.Len() {
+			t.Fatalf("workers %v: lists have different lengths", workers)
+		}
+
+		for i := range keys {
+			if list.Get(keys[i]) == nil {
+				t.Fatalf("workers %v: key %v not found", workers, keys[i])
+			}
+		}
+	}
+
+	if list := NewParallel(18, 4, nil, nil); list.startPointers.next[0] != nil {
+		t.Fatal("list built from no keys must be empty")
+	}
+}
+
+func TestLoadParallel(t *testing.T) {
+	items := make([]KV, 0, 5000)
+	for i := 0; i < 5000; i++ {
+		items = append(items, KV{float64(i / 3), i}) //runs of three equal keys
+	}
+	check := func(list *SkipList, name string, length int) {
+		t.Helper()
+		checkSanity(list, t)
+		if err := list.Validate(); err != nil {
+			t.Fatalf("%v: %v", name, err)
+		}
+		if list.Len() != length {
+			t.Fatalf("%v: list holds %v columns, expected %v", name, list.Len(), length)
+		}
+	}
+
+	for _, workers := range []int{1, 3, 8, 5000} {
+		list := New()
+		list.Set(-1, "before")
+		seq := list.Seq()
+		list.LoadParallel(items, workers)
+		check(list, fmt.Sprint("workers ", workers), 1+len(items)/3+1)
+		for _, item := range items {
+			if v, _ := list.GetValue(item.Key); v != min(3*int(item.Key)+2, len(items)-1) {
+				t.Fatalf("workers %v: %v holds %v, the last of its run is expected", workers, item.Key, v)
+			}
+		}
+		for column := list.Front().Next(); column != nil; column = column.Next() {
+			if column.Seq() <= seq {
+				t.Fatalf("workers %v: %v has sequence number %v, not past %v", workers, column.key, column.Seq(), seq)
+			}
+		}
+		if list.Seq() < seq+uint64(list.Len()-1) {
+			t.Fatalf("workers %v: the sequence of the list is behind its columns", workers)
+		}
+	}
+
+	multiset := New(AllowDuplicates())
+	multiset.LoadParallel(items, 4)
+	check(multiset, "multiset", len(items))
+
+	overlapping := New()
+	overlapping.Set(100, "kept apart") //not past the end, so it falls back to SetBatch
+	overlapping.LoadParallel(items, 4)
+	check(overlapping, "overlapping", len(items)/3+1)
+
+	inserted := 0
+	hooked := New(OnInsert(func(*Column) { inserted++ }))
+	hooked.LoadParallel(items, 4)
+	check(hooked, "hooked", len(items)/3+1)
+	if inserted != hooked.Len() {
+		t.Fatal("hooks must run for every insert, ran", inserted)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("unsorted items must panic")
+		}
+	}()
+	New().LoadParallel([]KV{{2, nil}, {1, nil}}, 2)
+}
+
+func BenchmarkNewParallel(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	keys := make([]float64, 1000000)
+	values := make([]interface{}, len(keys))
+	for i := range keys {
+		keys[i] = r.Float64()
+	}
+
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%v", workers), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				NewParallel(18, workers, keys, values)
+			}
+		})
+	}
+}
+
+func TestSetBatch(t *testing.T) {
+	list := New()
+	list.Set(5, "old")
+	list.Set(100, "kept")
+
+	items := []KV{{30, "a"}, {5, "b"}, {10, "c"}, {30, "d"}, {-1, "e"}, {200, "f"}}
+	columns := list.SetBatch(items)
+	checkSanity(list, t)
+
+	if len(columns) != len(items) {
+		t.Fatalf("got %v columns for %v items", len(columns), len(items))
+	}
+	for i, c := range columns {
+		if c == nil || c.key != items[i].Key {
+			t.Fatalf("column %v is %v, expected key %v", i, c, items[i].Key)
+		}
+	}
+	if columns[0] != columns[3] || columns[0].Value != "d" {
+		t.Fatal("the later of two equal keys must win", columns[0].Value)
+	}
+
+	expected := map[float64]interface{}{-1: "e", 5: "b", 10: "c", 30: "d", 100: "kept", 200: "f"}
+	if list.Len() != len(expected) {
+		t.Fatalf("wrong length %v (expected %v)", list.Len(), len(expected))
+	}
+	for k, v := range expected {
+		if c := list.Get(k); c == nil || c.Value != v {
+			t.Fatalf("key %v holds %v, expected %v", k, c, v)
+		}
+	}
+
+	lru := NewLRU(18, 3)
+	lru.SetBatch([]KV{{1, nil}, {2, nil}, {3, nil}, {4, nil}, {5, nil}})
+	checkSanity(lru, t)
+	if keys, _ := lru.ExportColumns(); len(keys) != 3 || keys[0] != 3 {
+		t.Fatal("batch into an LRU list must keep the last items", keys)
+	}
+}
+
+func BenchmarkSetBatch(b *testing.B) {
+	items := make([]KV, 100000)
+	r := rand.New(rand.NewSource(1))
+	for i := range items {
+		items[i] = KV{r.Float64(), nil}
+	}
+
+	b.Run("Set", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			list := New()
+			for _, item := range items {
+				list.Set(item.Key, item.Value)
+			}
+		}
+	})
+	b.Run("SetBatch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			New().SetBatch(items)
+		}
+	})
+}
+
+func TestNewFromSorted(t *testing.T) {
+	keys := make([]float64, 100000)
+	values := make([]interface{}, len(keys))
+	for i := range keys {
+		keys[i] = float64(i * 3)
+		values[i] = i
+	}
+	keys = append(keys, keys[len(keys)-1]) //repeated key at the end
+	values = append(values, "last")
+
+	list := NewFromSorted(keys, values)
+	checkSanity(list, t)
+	if list.Len() != 100000 {
+		t.Fatalf("wrong length %v (expected 100000)", list.Len())
+	}
+	if c := list.Get(299997); c == nil || c.Value != "last" {
+		t.Fatal("the last of repeated keys must win", c)
+	}
+	if c := list.Get(3000); c == nil || c.Value.(int) != 1000 {
+		t.Fatal(`wrong "3000" value`, c)
+	}
+
+	//levels are evenly spaced, so each one holds about 1/e of the one below
+	perLevel := make([]int, list.maxLevel)
+	for c := list.Front(); c != nil; c = c.Next() {
+		for i := 0; i < c.Level(); i++ {
+			perLevel[i]++
+		}
+	}
+	for i := 1; i < 6; i++ {
+		expected := float64(perLevel[0]) / math.Pow(math.E, float64(i))
+		if math.Abs(float64(perLevel[i])-expected) > 1 {
+			t.Fatalf("level %v holds %v columns, expected about %v", i, perLevel[i], expected)
+		}
+	}
+
+	//keeps working as a normal list afterwards
+	list.Set(1, "inserted")
+	list.Del(0)
+	checkSanity(list, t)
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("unsorted keys must panic")
+		}
+	}()
+	NewFromSorted([]float64{2, 1}, []interface{}{nil, nil})
+}
+
+func TestAppend(t *testing.T) {
+	list := New()
+	for i := 0; i < 1000; i++ {
+		list.Append(float64(i), i)
+	}
+	checkSanity(list, t)
+
+	list.Append(500, "not at the end") //falls back to Set
+	list.Del(999)
+	list.Append(2000, nil) //tails are found again after the Del
+	list.ReplaceOrInsert(2000, "replaced")
+	list.Append(2001, nil)
+	list.Append(2001, "again")
+	checkSanity(list, t)
+
+	if list.Len() != 1001 || list.Get(500).Value != "not at the end" || list.Get(2001).Value != "again" {
+		t.Fatal("Append must behave like Set", list.Len())
+	}
+	if c := list.Back(); c.Key() != 2001 || c.Prev().Key() != 2000 || c.Prev().Value != "replaced" {
+		t.Fatal("appended columns must be linked at the end")
+	}
+
+	multi := New(AllowDuplicates())
+	multi.Append(1, "a")
+	multi.Append(1, "b")
+	if all := multi.GetAll(1); len(all) != 2 || all[1].Value != "b" {
+		t.Fatal("multisets must append equal keys", all)
+	}
+
+	lru := NewLRU(18, 10)
+	for i := 0; i < 100; i++ {
+		lru.Append(float64(i), nil)
+	}
+	checkSanity(lru, t)
+	if lru.Len() != 10 || lru.Front().Key() != 90 {
+		t.Fatal("Append must evict from a bounded list", lru.Len())
+	}
+}
+
+func BenchmarkAppend(b *testing.B) {
+	b.Run("Set", func(b *testing.B) {
+		list := New()
+		for i := 0; i < b.N; i++ {
+			list.Set(float64(i), nil)
+		}
+	})
+	b.Run("Append", func(b *testing.B) {
+		list := New()
+		for i := 0; i < b.N; i++ {
+			list.Append(float64(i), nil)
+		}
+	})
+}
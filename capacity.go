@@ -0,0 +1,38 @@
+package jumplist
+
+// EvictPolicy chooses the column a bounded list drops when an insert takes it past its capacity.
+type EvictPolicy int
+
+const (
+	EvictSmallest    EvictPolicy = iota //drop the smallest key, keeping the largest n like a leaderboard
+	EvictLargest                        //drop the largest key, keeping the smallest n
+	EvictOldest                         //drop the column inserted first, updates do not count
+	EvictLeastRecent                    //drop the column used least recently by Get or Set, like NewLRU
+)
+
+// NewWithCapacity returns a list holding at most n columns. An insert past n evicts one column
+// chosen by policy, which may be the one just inserted when it is the smallest or largest key.
+func NewWithCapacity(n int, policy EvictPolicy, opts ...Option) *SkipList {
+	list := New(opts...)
+	list.bound(n, policy)
+	return list
+}
+
+func (list *SkipList) bound(capacity int, policy EvictPolicy) {
+	if capacity < 1 {
+		panic("capacity must be positive")
+	}
+	list.capacity, list.policy = capacity, policy
+	if policy == EvictOldest || policy == EvictLeastRecent {
+		list.lru = &lruState{insertionOrder: policy == EvictOldest}
+		list.lru.reset()
+	}
+}
+
+// OnEvict calls fn with the key and value of every column a bounded list evicts.
+// fn runs while the list is locked, so it must not call back into the list.
+func OnEvict(fn func(key float64, value interface{})) Option {
+	return func(list *SkipList) {
+		list.onEvict = fn
+	}
+}
@@ -0,0 +1,102 @@
+package jumplist
+
+import "testing"
+
+func TestNewWithCapacity(t *testing.T) {
+	keysOf := func(list *SkipList) []float64 {
+		keys, _ := list.ExportColumns()
+		return keys
+	}
+	same := func(got []float64, expected ...float64) bool {
+		if len(got) != len(expected) {
+			return false
+		}
+		for i := range got {
+			if got[i] != expected[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	evicted := []float64{}
+	onEvict := OnEvict(func(key float64, value interface{}) { evicted = append(evicted, key) })
+
+	top := NewWithCapacity(3, EvictSmallest, onEvict)
+	for _, k := range []float64{5, 1, 9, 7, 3, 8} {
+		top.Set(k, nil)
+	}
+	checkSanity(top, t)
+	if keys := keysOf(top); !same(keys, 7, 8, 9) {
+		t.Fatal("EvictSmallest must keep the largest keys", keys)
+	}
+	if !same(evicted, 1, 3, 5) { //3 is dropped as soon as it is inserted
+		t.Fatal("OnEvict must see every evicted key in order", evicted)
+	}
+
+	bottom := NewWithCapacity(3, EvictLargest)
+	for _, k := range []float64{5, 1, 9, 7, 3, 8} {
+		bottom.Set(k, nil)
+	}
+	if keys := keysOf(bottom); !same(keys, 1, 3, 5) {
+		t.Fatal("EvictLargest must keep the smallest keys", keys)
+	}
+
+	fifo := NewWithCapacity(3, EvictOldest)
+	fifo.Set(1, nil)
+	fifo.Set(2, nil)
+	fifo.Set(3, nil)
+	fifo.Get(1)
+	fifo.Set(1, "updated") //neither reads nor updates count
+	fifo.Set(4, nil)
+	if keys := keysOf(fifo); !same(keys, 2, 3, 4) {
+		t.Fatal("EvictOldest must drop the first insertion", keys)
+	}
+	fifo.Del(3)
+	fifo.Set(5, nil)
+	fifo.Set(6, nil)
+	if keys := keysOf(fifo); !same(keys, 4, 5, 6) {
+		t.Fatal("removed columns must leave the insertion order", keys)
+	}
+	checkSanity(fifo, t)
+
+	lru := NewWithCapacity(2, EvictLeastRecent)
+	lru.Set(1, nil)
+	lru.Set(2, nil)
+	lru.Get(1)
+	lru.Set(3, nil)
+	if keys := keysOf(lru); !same(keys, 1, 3) {
+		t.Fatal("EvictLeastRecent must behave like NewLRU", keys)
+	}
+}
+
+func TestEvictDuplicates(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		policy  EvictPolicy
+		opts    []Option
+		evicted string
+	}{
+		{"EvictLeastRecent", EvictLeastRecent, []Option{AllowDuplicates()}, "b"},
+		{"EvictOldest", EvictOldest, []Option{WithTieOrder(TiesLIFO)}, "a"},
+		{"EvictLargest", EvictLargest, []Option{AllowDuplicates()}, "b"},
+	} {
+		evicted := []interface{}{}
+		list := NewWithCapacity(2, tc.policy, append(tc.opts, OnEvict(func(key float64, value interface{}) {
+			evicted = append(evicted, value)
+		}))...)
+		list.Set(1, "a")
+		list.Set(1, "b")
+		list.Get(1) //makes the first column with key 1 recently used
+		list.Set(0, "c")
+		checkSanity(list, t)
+
+		kept := []interface{}{}
+		for _, column := range list.GetAll(1) {
+			kept = append(kept, column.Value)
+		}
+		if len(evicted) != 1 || evicted[0] != tc.evicted || len(kept) != 1 || kept[0] == tc.evicted {
+			t.Errorf("%v reported %v as evicted while keeping %v", tc.name, evicted, kept)
+		}
+	}
+}
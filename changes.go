@@ -0,0 +1,54 @@
+package jumplist
+
+import (
+	"context"
+	"math"
+)
+
+// ChangeRecord is an Event numbered in the order of every change to the list. Unlike the sequence
+// numbers of ChangedSince, deletes are counted too, so the records of a Changes stream have
+// consecutive numbers and a follower can tell that it missed none.
+type ChangeRecord struct {
+	Seq uint64
+	Event
+}
+
+// Changes returns a channel receiving every change to the list in the order it was committed, numbered
+// without gaps from the first change after the call, until ctx is done. It is a Watch of every key for
+// keeping a replica: a follower that subscribes, then copies the list with Clone, Dump or WriteTo
+// and applies each record on the copy ends up with the contents of the leader, since a record the copy
+// already holds is followed by every later change to its key. Clear, Split, Freeze, Reset and the
+// loads that replace the whole list are not reported as records: they close the channel once the
+// records before them are delivered, and the follower must copy the list again. Records are queued
+// without bound, so a follower must keep up or cancel ctx, which drops the undelivered ones.
+func (list *SkipList) Changes(ctx context.Context) <-chan ChangeRecord {
+	w := newWatcher(math.Inf(-1), math.Inf(1))
+	w.every, w.records = true, make(chan ChangeRecord)
+
+	list.mutex.Lock()
+	list.watchers = append(list.watchers, w)
+	list.mutex.Unlock()
+
+	go w.run()
+	context.AfterFunc(ctx, func() { list.cancel(w) })
+
+	return w.records
+}
+
+// endChanges unregisters the Changes streams, which close once they delivered their records. It is
+// called holding the write lock by whatever changes the list without notifying.
+func (list *SkipList) endChanges() {
+	watchers := list.watchers[:0]
+	for _, w := range list.watchers {
+		if w.records == nil {
+			watchers = append(watchers, w)
+			continue
+		}
+		w.mutex.Lock()
+		w.ending = true
+		w.mutex.Unlock()
+		w.signal()
+	}
+	clear(list.watchers[len(watchers):])
+	list.watchers = watchers
+}
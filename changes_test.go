@@ -0,0 +1,76 @@
+package jumplist
+
+import (
+	"context"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestChanges(t *testing.T) {
+	leader := New()
+	leader.Set(-1, "before")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	records := leader.Changes(ctx)
+	follower := leader.Clone()
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		key := float64(r.Intn(100))
+		switch r.Intn(4) {
+		case 0:
+			leader.Del(key)
+		case 1:
+			leader.RemoveRange(key, key+3)
+		default:
+			leader.Set(key, i)
+		}
+	}
+	leader.Set(1000, "end")
+
+	var last uint64
+	for record := range records {
+		if last != 0 && record.Seq != last+1 {
+			t.Fatalf("record %v follows %v", record.Seq, last)
+		}
+		last = record.Seq
+		if record.Kind == EventDelete {
+			follower.Del(record.Key)
+		} else {
+			follower.Set(record.Key, record.Value)
+		}
+		if record.Key == 1000 {
+			break
+		}
+	}
+	if !slices.Equal(follower.Items(), leader.Items()) {
+		t.Fatal("the follower must end up with the contents of the leader")
+	}
+
+	cancel()
+	for record := range records {
+		t.Fatal("no record may follow cancel", record)
+	}
+}
+
+func TestChangesEnd(t *testing.T) {
+	list := New()
+	records := list.Changes(context.Background())
+	list.Set(1, "a")
+	list.Del(1)
+	list.Clear()
+	list.Set(2, "b")
+
+	got := []ChangeRecord{}
+	for record := range records {
+		got = append(got, record)
+	}
+	expected := []ChangeRecord{{1, Event{EventInsert, 1, "a"}}, {2, Event{EventDelete, 1, "a"}}}
+	if !slices.Equal(got, expected) {
+		t.Fatal("Clear must close the stream after the records before it, got", got)
+	}
+	if len(list.watchers) != 0 {
+		t.Fatal("Clear must unregister the stream")
+	}
+}
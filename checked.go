@@ -0,0 +1,83 @@
+package jumplist
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+)
+
+// The constructors below return the misuse their plain forms panic on as an error, so lists can be
+// built from configuration without a recover. Programming errors such as a nil option still panic.
+
+// NewE is New returning an error for a bad option.
+func NewE(opts ...Option) (*SkipList, error) {
+	return checked(func() *SkipList { return New(opts...) })
+}
+
+// NewWithLevelE is NewWithLevel returning an error for a level outside 1~64 or a bad option.
+func NewWithLevelE(level int, opts ...Option) (*SkipList, error) {
+	return checked(func() *SkipList { return NewWithLevel(level, opts...) })
+}
+
+// NewWithProbabilityE is NewWithProbability returning an error for p outside (0, 1) or a bad option.
+func NewWithProbabilityE(p float64, expectedN int, opts ...Option) (*SkipList, error) {
+	return checked(func() *SkipList { return NewWithProbability(p, expectedN, opts...) })
+}
+
+// NewWithCapacityE is NewWithCapacity returning an error for a capacity below 1 or a bad option.
+func NewWithCapacityE(n int, policy EvictPolicy, opts ...Option) (*SkipList, error) {
+	return checked(func() *SkipList { return NewWithCapacity(n, policy, opts...) })
+}
+
+// NewLRUE is NewLRU returning an error for a bad level or capacity.
+func NewLRUE(maxLevel, capacity int) (*SkipList, error) {
+	return checked(func() *SkipList { return NewLRU(maxLevel, capacity) })
+}
+
+// NewSampledE is NewSampled returning an error for a bad level or capacity.
+func NewSampledE(maxLevel, capacity int) (*SkipList, error) {
+	return checked(func() *SkipList { return NewSampled(maxLevel, capacity) })
+}
+
+// NewFromSortedE is NewFromSorted returning an error for unsorted keys, NaN keys, mismatched lengths or a bad option.
+func NewFromSortedE(keys []float64, values []interface{}, opts ...Option) (*SkipList, error) {
+	return checked(func() *SkipList { return NewFromSorted(keys, values, opts...) })
+}
+
+// NewParallelE is NewParallel returning an error for NaN keys, mismatched lengths, a bad level or option.
+func NewParallelE(maxLevel, workers int, keys []float64, values []interface{}, opts ...Option) (*SkipList, error) {
+	return checked(func() *SkipList { return NewParallel(maxLevel, workers, keys, values, opts...) })
+}
+
+// NewShardedE is NewSharded returning an error for a shard count below 1 or a bad option.
+func NewShardedE(n int, opts ...Option) (*ShardedSkipList, error) {
+	return checked(func() *ShardedSkipList { return NewSharded(n, opts...) })
+}
+
+// NewUnrolledE is NewUnrolled returning an error for fewer than 2 entries per node.
+func NewUnrolledE(entriesPerNode int) (*Unrolled, error) {
+	return checked(func() *Unrolled { return NewUnrolled(entriesPerNode) })
+}
+
+// NewArenaE is NewArena returning an error for a slab size below 1.
+func NewArenaE(slabSize int) (*Arena, error) {
+	return checked(func() *Arena { return NewArena(slabSize) })
+}
+
+// checked runs build and turns the panics for bad arguments into errors, runtime errors are bugs and keep panicking.
+func checked[T any](build func() T) (built T, err error) {
+	defer func() {
+		switch r := recover().(type) {
+		case nil:
+		case runtime.Error:
+			panic(r)
+		case error:
+			err = r
+		case string:
+			err = errors.New("jumplist: " + r)
+		default:
+			err = fmt.Errorf("jumplist: %v", r)
+		}
+	}()
+	return build(), nil
+}
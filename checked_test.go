@@ -0,0 +1,43 @@
+package jumplist
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestCheckedConstructors(t *testing.T) {
+	if list, err := NewWithLevelE(10); err != nil || list.levelCap != 10 {
+		t.Fatal("valid arguments must build the list", err)
+	}
+	if list, err := NewE(WithLocking(false)); err != nil || list == nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		message string
+		build   func() error
+	}{
+		{"level must be 1~64", func() error { _, err := NewWithLevelE(0); return err }},
+		{"probability must be between 0 and 1", func() error { _, err := NewE(WithProbability(2)); return err }},
+		{"capacity must be positive", func() error { _, err := NewWithCapacityE(0, EvictSmallest); return err }},
+		{"capacity must be positive", func() error { _, err := NewLRUE(18, -1); return err }},
+		{"keys must be sorted in ascending order", func() error { _, err := NewFromSortedE([]float64{2, 1}, []interface{}{nil, nil}); return err }},
+		{"keys and values must have the same length", func() error { _, err := NewParallelE(18, 1, []float64{1}, nil); return err }},
+		{"NaN key", func() error { _, err := NewFromSortedE([]float64{math.NaN()}, []interface{}{nil}); return err }},
+		{"n must be positive", func() error { _, err := NewShardedE(0); return err }},
+		{"entriesPerNode must be at least 2", func() error { _, err := NewUnrolledE(1); return err }},
+		{"slabSize must be positive", func() error { _, err := NewArenaE(0); return err }},
+	} {
+		if err := c.build(); err == nil || !strings.HasPrefix(err.Error(), "jumplist: ") || !strings.Contains(err.Error(), c.message) {
+			t.Fatalf("expected %q, got %v", c.message, err)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("runtime errors must keep panicking")
+		}
+	}()
+	NewE(nil)
+}
@@ -0,0 +1,236 @@
+package jumplist
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"os"
+)
+
+// ErrCorruptCheckpoint is returned by ReadFrom and Verify for a checkpoint that is truncated or fails
+// its checksums.
+var ErrCorruptCheckpoint = errors.New("jumplist: corrupt checkpoint")
+
+// checkpointMagic starts a checkpoint with checksums. 0xff cannot start the gob stream of a checkpoint
+// written before them, which ReadFrom still reads.
+const checkpointMagic = "\xffJLC"
+
+// After the magic, each gob message written for the count and for every pair is a frame: its length,
+// its CRC-32 and the bytes. A frame of length 0 ends the checkpoint, carrying the CRC-32 of all the
+// bytes before it instead.
+const (
+	frameHeader = 8
+	frameMax    = 1 << 30 //longest frame, a longer length field is corrupt
+)
+
+var errLargeFrame = errors.New("jumplist: checkpoint frame too large")
+
+// frameWriter collects what the encoder writes for one message and sends it as a frame on flush.
+type frameWriter struct {
+	w      io.Writer
+	buf    bytes.Buffer
+	sum    hash.Hash32 //of the whole checkpoint
+	header [frameHeader]byte
+}
+
+func newFrameWriter(w io.Writer) (*frameWriter, error) {
+	fw := &frameWriter{w: w, sum: crc32.NewIEEE()}
+	_, err := io.WriteString(fw.w, checkpointMagic)
+	fw.sum.Write([]byte(checkpointMagic))
+	return fw, err
+}
+
+func (fw *frameWriter) Write(p []byte) (int, error) {
+	return fw.buf.Write(p)
+}
+
+func (fw *frameWriter) flush() error {
+	payload := fw.buf.Bytes()
+	if len(payload) > frameMax {
+		return errLargeFrame //reading it back would take it for a corrupt length
+	}
+	binary.BigEndian.PutUint32(fw.header[:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(fw.header[4:], crc32.ChecksumIEEE(payload))
+	fw.sum.Write(fw.header[:])
+	fw.sum.Write(payload)
+	if _, err := fw.w.Write(fw.header[:]); err != nil {
+		return err
+	}
+	_, err := fw.w.Write(payload)
+	fw.buf.Reset()
+	return err
+}
+
+// close writes the end frame.
+func (fw *frameWriter) close() error {
+	binary.BigEndian.PutUint32(fw.header[:4], 0)
+	binary.BigEndian.PutUint32(fw.header[4:], fw.sum.Sum32())
+	_, err := fw.w.Write(fw.header[:])
+	return err
+}
+
+// frameReader hands the decoder the bytes of the frames, checking each frame as it is read in full.
+// It is an io.ByteReader, so the decoder reads no further than the messages it decodes.
+type frameReader struct {
+	r       io.Reader
+	frame   []byte //unread rest of the current frame
+	sum     hash.Hash32
+	frames  int
+	done    bool //the end frame was read and matched
+	header  [frameHeader]byte
+	scratch []byte
+}
+
+func newFrameReader(r io.Reader) *frameReader {
+	fr := &frameReader{r: r, sum: crc32.NewIEEE()}
+	fr.sum.Write([]byte(checkpointMagic))
+	return fr
+}
+
+// next reads the following frame, returning io.EOF after the end frame.
+func (fr *frameReader) next() error {
+	if fr.done {
+		return io.EOF
+	}
+	if _, err := io.ReadFull(fr.r, fr.header[:]); err != nil {
+		return fmt.Errorf("%w: truncated after %v frames", ErrCorruptCheckpoint, fr.frames)
+	}
+	length, crc := binary.BigEndian.Uint32(fr.header[:4]), binary.BigEndian.Uint32(fr.header[4:])
+	if length == 0 {
+		if crc != fr.sum.Sum32() {
+			return fmt.Errorf("%w: checksum of the whole checkpoint does not match", ErrCorruptCheckpoint)
+		}
+		fr.done = true
+		return io.EOF
+	}
+
+	if length > frameMax {
+		return fmt.Errorf("%w: frame %v is %v bytes long", ErrCorruptCheckpoint, fr.frames, length)
+	}
+	payload, err := fr.read(int(length))
+	if err != nil {
+		return fmt.Errorf("%w: frame %v truncated", ErrCorruptCheckpoint, fr.frames)
+	}
+	if crc32.ChecksumIEEE(payload) != crc {
+		return fmt.Errorf("%w: checksum of frame %v does not match", ErrCorruptCheckpoint, fr.frames)
+	}
+	fr.sum.Write(fr.header[:])
+	fr.sum.Write(payload)
+	fr.frame = payload
+	fr.frames++
+	return nil
+}
+
+// read reads n bytes into the scratch buffer, growing it as they arrive rather than by n at once, so a
+// length torn or corrupted below frameMax costs no more memory than the bytes there are.
+func (fr *frameReader) read(n int) ([]byte, error) {
+	payload := fr.scratch[:0]
+	for len(payload) < n {
+		if len(payload) == cap(payload) {
+			payload = append(payload, 0)[:len(payload)] //grows the array by doubling
+		}
+		m, err := io.ReadFull(fr.r, payload[len(payload):min(n, cap(payload))])
+		payload = payload[:len(payload)+m]
+		if err != nil {
+			fr.scratch = payload
+			return nil, err
+		}
+	}
+	fr.scratch = payload
+	return payload, nil
+}
+
+func (fr *frameReader) Read(p []byte) (int, error) {
+	for len(fr.frame) == 0 {
+		if err := fr.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, fr.frame)
+	fr.frame = fr.frame[n:]
+	return n, nil
+}
+
+func (fr *frameReader) ReadByte() (byte, error) {
+	for len(fr.frame) == 0 {
+		if err := fr.next(); err != nil {
+			return 0, err
+		}
+	}
+	b := fr.frame[0]
+	fr.frame = fr.frame[1:]
+	return b, nil
+}
+
+// finish checks that the decoder used up the frames and that the end frame follows.
+func (fr *frameReader) finish() error {
+	if len(fr.frame) > 0 {
+		return fmt.Errorf("%w: frame %v holds more than a message", ErrCorruptCheckpoint, fr.frames-1)
+	}
+	if err := fr.next(); err != io.EOF {
+		if err == nil {
+			err = fmt.Errorf("%w: frames after the last pair", ErrCorruptCheckpoint)
+		}
+		return err
+	}
+	return nil
+}
+
+// openCheckpoint reads the start of a checkpoint and returns the decoder for the rest, with the frame
+// reader to finish it with, nil for a checkpoint written before checksums.
+func openCheckpoint(r io.Reader) (*gob.Decoder, *frameReader, error) {
+	head := make([]byte, len(checkpointMagic))
+	n, err := io.ReadFull(r, head)
+	if err == nil && string(head) == checkpointMagic {
+		fr := newFrameReader(r)
+		return gob.NewDecoder(fr), fr, nil
+	}
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, nil, err
+	}
+	return gob.NewDecoder(io.MultiReader(bytes.NewReader(head[:n]), r)), nil, nil
+}
+
+// Verify checks the checkpoint at path, as written by WriteTo or kept by Persistent, against its
+// checksums and returns an error wrapping ErrCorruptCheckpoint if it is truncated, damaged or was
+// written before checkpoints carried checksums. It checks the frames and the pair count without
+// decoding the values, so their types need not be registered.
+func Verify(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	decoder, fr, err := openCheckpoint(bufio.NewReader(f))
+	if err != nil {
+		return err
+	}
+	if fr == nil {
+		return fmt.Errorf("%w: no checksums", ErrCorruptCheckpoint)
+	}
+	var n int
+	if err := decoder.Decode(&n); err != nil {
+		if !errors.Is(err, ErrCorruptCheckpoint) {
+			err = fmt.Errorf("%w: %v", ErrCorruptCheckpoint, err)
+		}
+		return err
+	}
+	for {
+		if err := fr.next(); err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+	if fr.frames != n+1 {
+		return fmt.Errorf("%w: %v pairs counted, %v stored", ErrCorruptCheckpoint, n, fr.frames-1)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package jumplist
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckpointChecksums(t *testing.T) {
+	list := New()
+	for i := 0; i < 200; i++ {
+		list.Set(float64(i), i)
+	}
+	buf := &bytes.Buffer{}
+	if _, err := list.WriteTo(buf); err != nil {
+		t.Fatal(err)
+	}
+	checkpoint := buf.Bytes()
+
+	dir := t.TempDir()
+	write := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	if err := Verify(write("good", checkpoint)); err != nil {
+		t.Fatal("a good checkpoint must verify:", err)
+	}
+
+	for _, at := range []int{len(checkpointMagic) + 2, len(checkpointMagic) + frameHeader + 1, len(checkpoint) / 2, len(checkpoint) - 1} {
+		damaged := bytes.Clone(checkpoint)
+		damaged[at] ^= 0x10
+		if err := Verify(write("damaged", damaged)); !errors.Is(err, ErrCorruptCheckpoint) {
+			t.Errorf("a bit flipped at %v must fail Verify, got %v", at, err)
+		}
+		loaded := New()
+		if _, err := loaded.ReadFrom(bytes.NewReader(damaged)); err == nil || loaded.Len() != 0 {
+			t.Errorf("a bit flipped at %v must fail ReadFrom, got %v", at, err)
+		}
+	}
+
+	for _, n := range []int{len(checkpoint) - frameHeader, len(checkpoint) - frameHeader - 3, len(checkpoint) / 3} {
+		if err := Verify(write("truncated", checkpoint[:n])); !errors.Is(err, ErrCorruptCheckpoint) {
+			t.Errorf("a checkpoint cut at %v of %v bytes must fail Verify, got %v", n, len(checkpoint), err)
+		}
+		if _, err := New().ReadFrom(bytes.NewReader(checkpoint[:n])); !errors.Is(err, ErrCorruptCheckpoint) {
+			t.Errorf("a checkpoint cut at %v of %v bytes must fail ReadFrom, got %v", n, len(checkpoint), err)
+		}
+	}
+
+	//written before checksums: a bare gob stream, which loads but does not verify
+	legacy := &bytes.Buffer{}
+	encoder := gob.NewEncoder(legacy)
+	encoder.Encode(2)
+	encoder.Encode(KV{1, "a"})
+	encoder.Encode(KV{2, "b"})
+	if err := Verify(write("legacy", legacy.Bytes())); !errors.Is(err, ErrCorruptCheckpoint) {
+		t.Error("a checkpoint without checksums cannot verify, got", err)
+	}
+	loaded := New()
+	if _, err := loaded.ReadFrom(bytes.NewReader(legacy.Bytes())); err != nil || loaded.Get(2).Value != "b" {
+		t.Error("a checkpoint without checksums must still load, got", err)
+	}
+}
+
+func TestCheckpointFrameLength(t *testing.T) {
+	list := New()
+	list.Set(1, "a")
+	buf := &bytes.Buffer{}
+	list.WriteTo(buf)
+	checkpoint := buf.Bytes()
+
+	for _, length := range []uint32{math.MaxUint32, frameMax, 1 << 20} { //oversized, and torn below the bound
+		damaged := bytes.Clone(checkpoint)
+		binary.BigEndian.PutUint32(damaged[len(checkpointMagic):], length)
+		fr := newFrameReader(bytes.NewReader(damaged[len(checkpointMagic):]))
+		if err := fr.next(); !errors.Is(err, ErrCorruptCheckpoint) {
+			t.Errorf("a frame length of %v must fail, got %v", length, err)
+		}
+		if cap(fr.scratch) > 2*len(damaged) { //grown by doubling as the bytes arrived
+			t.Errorf("a frame length of %v allocated %v bytes for a %v byte checkpoint", length, cap(fr.scratch), len(damaged))
+		}
+	}
+}
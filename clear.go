@@ -0,0 +1,59 @@
+package jumplist
+
+import "time"
+
+// Clear drops every column under the lock and keeps the configuration, so the list can be refilled
+// without building a new one. With WithPooling the columns go back to the pool and WithOwnArena resets
+// the arena, while columns taken from a shared arena stay allocated until the arena is reset.
+func (list *SkipList) Clear() {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	list.clear()
+}
+
+func (list *SkipList) clear() {
+	if list.pool != nil {
+		for column := list.startPointers.next[0]; column != nil; {
+			next := column.next[0]
+			list.release(column)
+			column = next
+		}
+	}
+	list.endChanges()
+	list.setLevels(list.levelCap, list.probability) //back to the initial height
+	list.cursorColumn = nil
+	list.resize(-list.length)
+
+	if list.lru != nil {
+		list.lru.reset()
+	}
+	if list.ttl != nil {
+		list.ttl.expires, list.ttl.queue = map[*Column]time.Time{}, nil
+	}
+	list.sampleLen, list.sampleSeen = 0, 0
+	if list.valueIndex != nil {
+		clear(list.valueIndex)
+	}
+	if list.metadata != nil {
+		clear(list.metadata)
+	}
+	if list.aggregates != nil {
+		list.aggregates.reset()
+	}
+	list.refilter()
+	if list.ownsArena {
+		list.arena.Reset()
+	}
+}
+
+// Reset clears the list and changes its number of levels, keeping every other setting.
+func (list *SkipList) Reset(maxLevel int) {
+	newLevelDist(maxLevel, list.probability) //panics on a bad level before touching the list
+
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	list.clear()
+	list.setLevels(maxLevel, list.probability)
+}
@@ -0,0 +1,58 @@
+package jumplist
+
+import "testing"
+
+func TestClear(t *testing.T) {
+	var traced int
+	list := New(WithTracer(func(string, float64, int, bool) { traced++ }))
+	for i := 0; i < 100; i++ {
+		list.Set(float64(i), i)
+	}
+
+	list.Clear()
+	checkSanity(list, t)
+	if list.Len() != 0 || list.ApproxLen() != 0 || list.Front() != nil {
+		t.Fatal("cleared list must be empty")
+	}
+
+	traced = 0
+	list.Set(1, nil)
+	checkSanity(list, t)
+	if list.Len() != 1 || traced != 1 {
+		t.Fatal("cleared list must keep working with its options", list.Len(), traced)
+	}
+
+	lru := NewLRU(18, 2)
+	lru.Set(1, nil)
+	lru.Set(2, nil)
+	lru.Clear()
+	lru.Set(3, nil)
+	lru.Set(4, nil)
+	if lru.Len() != 2 || lru.Get(3) == nil {
+		t.Fatal("cleared LRU list must forget the old columns", lru.Len())
+	}
+}
+
+func TestReset(t *testing.T) {
+	list := New(AllowDuplicates())
+	list.Set(1, nil)
+
+	list.Reset(4)
+	checkSanity(list, t)
+	if list.Len() != 0 || list.maxLevel != 4 {
+		t.Fatal("reset list must be empty with the new level count")
+	}
+
+	for i := 0; i < 1000; i++ {
+		list.Set(float64(i%10), i)
+	}
+	checkSanity(list, t)
+	if list.Len() != 1000 {
+		t.Fatal("reset list must keep the duplicates mode", list.Len())
+	}
+	for c := list.Front(); c != nil; c = c.Next() {
+		if c.Level() > 4 {
+			t.Fatal("column taller than the new level count", c.Level())
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package jumplist
+
+import "time"
+
+// Clone returns an independent copy of the list, taken under the read lock so writers wait only for the copy.
+// Columns are copied with their levels, the values are shared, and deadlines set with SetWithTTL are
+// copied too, so expired columns not swept yet stay expired in the copy. The copy keeps the settings
+// Split keeps, WithEpsilon and WithWeights. It drops the capacity bound, a Locker given by WithLocker,
+// the hooks, watchers, access tracking, pooling, metrics, value index, bloom filter, metadata,
+// aggregates and sampling.
+func (list *SkipList) Clone() *SkipList {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	clone := list.sibling()
+	clone.weigher = list.weigher
+	if list.ttl != nil {
+		clone.ttl = &ttlState{expires: map[*Column]time.Time{}, now: list.ttl.now}
+	}
+	t := clone.newTails()
+	for column := list.startPointers.next[0]; column != nil; column = column.next[0] {
+		copied := clone.appendColumn(t, column.Level(), column.key, column.Value)
+		if at, ok := list.ttlOf(column); ok {
+			clone.setExpiry(copied, at)
+		}
+	}
+	clone.resize(0)
+	clone.reweighAll()
+
+	return clone
+}
@@ -0,0 +1,78 @@
+package jumplist
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClone(t *testing.T) {
+	list := New()
+	for i := 0; i < 1000; i++ {
+		list.Set(float64(i), i)
+	}
+
+	clone := list.Clone()
+	checkSanity(clone, t)
+	if clone.Len() != list.Len() {
+		t.Fatalf("clone holds %v columns instead of %v", clone.Len(), list.Len())
+	}
+	for a, b := list.Front(), clone.Front(); a != nil; a, b = a.Next(), b.Next() {
+		if a == b || a.key != b.key || a.Value != b.Value || a.Level() != b.Level() {
+			t.Fatalf("column %v was not copied as is", a.key)
+		}
+	}
+
+	list.Set(5, "changed")
+	list.Del(6)
+	clone.Set(2000, nil)
+	if clone.Get(5).Value.(int) != 5 || clone.Get(6) == nil || list.Get(2000) != nil {
+		t.Fatal("clone must be independent of the list")
+	}
+	checkSanity(list, t)
+	checkSanity(clone, t)
+}
+
+func TestCloneSettings(t *testing.T) {
+	list := New(WithEpsilon(0.01), WithWeights(nil))
+	now := time.Unix(1000, 0)
+	list.SetWithTTL(1, 10, time.Second)
+	list.ttl.now = func() time.Time { return now }
+	list.SetWithTTL(1, 10, time.Second)
+	list.SetWithTTL(2, 20, time.Minute)
+	list.Set(3, 30)
+	now = now.Add(time.Second) //1 has expired but is not swept
+
+	clone := list.Clone()
+	if err := clone.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if clone.Get(1) != nil || clone.Sweep() != 1 || clone.Len() != 2 {
+		t.Fatal("the clone must keep the deadlines, expired columns included")
+	}
+	if clone.Get(2.005) == nil || clone.SumRange(0, 10) != 50 {
+		t.Fatal("the clone must keep WithEpsilon and WithWeights")
+	}
+	now = now.Add(time.Minute)
+	if clone.Sweep() != 1 || clone.Len() != 1 {
+		t.Fatal("the deadlines in the clone must follow the clock of the list")
+	}
+}
+
+func TestCloneConcurrent(t *testing.T) { //run with -race, Clone draws its seed under the read lock
+	list := New()
+	for i := 0; i < 100; i++ {
+		list.Set(float64(i), i)
+	}
+	wg := &sync.WaitGroup{}
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				list.Clone()
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,139 @@
+package jumplist
+
+import (
+	"encoding"
+	"encoding/gob"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ValueCodec turns the values of one concrete type into bytes and back, for every serialization of a
+// list: WriteTo checkpoints, Persistent logs, MarshalJSON and WriteMapped. The version is stored with each
+// encoded value and handed back to Decode, so a codec whose stored form changed can still read the values
+// its older versions wrote.
+type ValueCodec interface {
+	Version() uint32
+	Encode(value interface{}) ([]byte, error)
+	Decode(data []byte, version uint32) (interface{}, error)
+}
+
+// codec is a registered ValueCodec with its name in the streams.
+type codec struct {
+	name string
+	ValueCodec
+}
+
+// funcCodec is the ValueCodec of RegisterCodec, at version 0.
+type funcCodec struct {
+	encode func(value interface{}) ([]byte, error)
+	decode func(data []byte) (interface{}, error)
+}
+
+func (funcCodec) Version() uint32 { return 0 }
+
+func (c funcCodec) Encode(value interface{}) ([]byte, error) { return c.encode(value) }
+
+func (c funcCodec) Decode(data []byte, _ uint32) (interface{}, error) { return c.decode(data) }
+
+var codecs = struct {
+	sync.RWMutex
+	byType map[reflect.Type]*codec
+	byName map[string]*codec
+}{byType: map[reflect.Type]*codec{}, byName: map[string]*codec{}}
+
+// codedValue stands in for a value with a registered codec in WriteTo checkpoints and Persistent logs.
+// Streams written before codecs had versions decode with Version 0.
+type codedValue struct {
+	Codec   string
+	Version uint32
+	Data    []byte
+}
+
+func init() {
+	gob.Register(codedValue{})
+}
+
+// RegisterCodec registers encode and decode as the ValueCodec for the concrete type of sample, see
+// RegisterValueCodec. It is meant for types gob cannot handle, such as structs with unexported fields,
+// or whose stored form must stay stable.
+func RegisterCodec(name string, sample interface{}, encode func(value interface{}) ([]byte, error), decode func(data []byte) (interface{}, error)) {
+	RegisterValueCodec(name, sample, funcCodec{encode, decode})
+}
+
+// RegisterValueCodec makes every serialization store the values of the concrete type of sample through c,
+// under name in the stream, instead of gob or encoding/json. Like gob.Register it is called from init and
+// panics when name or the type is already registered.
+func RegisterValueCodec(name string, sample interface{}, c ValueCodec) {
+	codecs.Lock()
+	defer codecs.Unlock()
+
+	t := reflect.TypeOf(sample)
+	if _, ok := codecs.byName[name]; ok {
+		panic(fmt.Sprintf("jumplist: codec %q registered twice", name))
+	}
+	if _, ok := codecs.byType[t]; ok {
+		panic(fmt.Sprintf("jumplist: codec for %v registered twice", t))
+	}
+	registered := &codec{name, c}
+	codecs.byType[t], codecs.byName[name] = registered, registered
+}
+
+// RegisterBinary registers a codec for values of type *T through their MarshalBinary and UnmarshalBinary.
+func RegisterBinary[T any, PT interface {
+	*T
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}](name string) {
+	RegisterCodec(name, PT(nil),
+		func(value interface{}) ([]byte, error) { return value.(PT).MarshalBinary() },
+		func(data []byte) (interface{}, error) {
+			value := PT(new(T))
+			return value, value.UnmarshalBinary(data)
+		})
+}
+
+// EncodeValue encodes value through the codec registered for its type, for formats of the caller's own
+// such as a network protocol. name is empty when the type has no codec.
+func EncodeValue(value interface{}) (name string, version uint32, data []byte, err error) {
+	encoded, err := encodeValue(value)
+	coded, _ := encoded.(codedValue)
+	return coded.Codec, coded.Version, coded.Data, err
+}
+
+// DecodeValue decodes data written by version of the codec registered as name.
+func DecodeValue(name string, version uint32, data []byte) (interface{}, error) {
+	return decodeValue(codedValue{name, version, data})
+}
+
+// encodeValue replaces a value having a codec with its codedValue.
+func encodeValue(value interface{}) (interface{}, error) {
+	codecs.RLock()
+	c := codecs.byType[reflect.TypeOf(value)]
+	codecs.RUnlock()
+	if c == nil {
+		return value, nil
+	}
+
+	data, err := c.Encode(value)
+	if err != nil {
+		return nil, err
+	}
+	return codedValue{c.name, c.Version(), data}, nil
+}
+
+// decodeValue reverses encodeValue.
+func decodeValue(value interface{}) (interface{}, error) {
+	coded, ok := value.(codedValue)
+	if !ok {
+		return value, nil
+	}
+
+	codecs.RLock()
+	c := codecs.byName[coded.Codec]
+	codecs.RUnlock()
+	if c == nil {
+		return nil, fmt.Errorf("jumplist: no codec registered as %q", coded.Codec)
+	}
+	return c.Decode(coded.Data, coded.Version)
+}
@@ -0,0 +1,162 @@
+package jumplist
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// point has no exported fields, so gob alone cannot store it
+type point struct {
+	x, y int32
+}
+
+func (p *point) MarshalBinary() ([]byte, error) {
+	return binary.BigEndian.AppendUint32(binary.BigEndian.AppendUint32(nil, uint32(p.x)), uint32(p.y)), nil
+}
+
+func (p *point) UnmarshalBinary(data []byte) error {
+	if len(data) != 8 {
+		return errors.New("bad point")
+	}
+	p.x, p.y = int32(binary.BigEndian.Uint32(data)), int32(binary.BigEndian.Uint32(data[4:]))
+	return nil
+}
+
+type secret struct {
+	text string
+}
+
+// celsius is stored as tenths of a degree since version 1, version 0 stored whole degrees
+type celsius float64
+
+type celsiusCodec struct{}
+
+func (celsiusCodec) Version() uint32 { return 1 }
+
+func (celsiusCodec) Encode(value interface{}) ([]byte, error) {
+	return binary.BigEndian.AppendUint32(nil, uint32(value.(celsius)*10)), nil
+}
+
+func (celsiusCodec) Decode(data []byte, version uint32) (interface{}, error) {
+	if len(data) != 4 {
+		return nil, errors.New("bad temperature")
+	}
+	if version == 0 {
+		return celsius(binary.BigEndian.Uint32(data)), nil
+	}
+	return celsius(binary.BigEndian.Uint32(data)) / 10, nil
+}
+
+func init() {
+	RegisterValueCodec("test.celsius", celsius(0), celsiusCodec{})
+	RegisterBinary[point]("test.point")
+	RegisterCodec("test.secret", secret{},
+		func(value interface{}) ([]byte, error) { return []byte(value.(secret).text), nil },
+		func(data []byte) (interface{}, error) { return secret{string(data)}, nil })
+}
+
+func TestCodecs(t *testing.T) {
+	list := New()
+	list.Set(1, &point{3, -4})
+	list.Set(2, secret{"hidden"})
+	list.Set(3, "plain")
+
+	buf := &bytes.Buffer{}
+	if _, err := list.WriteTo(buf); err != nil {
+		t.Fatal(err)
+	}
+	restored := New()
+	if _, err := restored.ReadFrom(buf); err != nil {
+		t.Fatal(err)
+	}
+	if p, ok := restored.Get(1).Value.(*point); !ok || *p != (point{3, -4}) {
+		t.Fatal("binary marshaled values must round trip", restored.Get(1).Value)
+	}
+	if s, ok := restored.Get(2).Value.(secret); !ok || s.text != "hidden" {
+		t.Fatal("values with a codec must round trip", restored.Get(2).Value)
+	}
+	if restored.Get(3).Value != "plain" {
+		t.Fatal("other values must still use gob")
+	}
+
+	path := filepath.Join(t.TempDir(), "list.wal")
+	p, err := NewPersistent(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Set(1, &point{5, 6})
+	p.Set(2, secret{"logged"})
+	p.Close()
+	if p, err = NewPersistent(path); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := p.Get(1).Value.(*point); !ok || *v != (point{5, 6}) || p.Get(2).Value.(secret).text != "logged" {
+		t.Fatal("the log must replay values through their codecs")
+	}
+	p.Close()
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("registering a name twice must panic")
+		}
+	}()
+	RegisterBinary[point]("test.point")
+}
+
+func TestValueCodec(t *testing.T) {
+	list := New()
+	list.Set(1, celsius(21.5))
+	list.Set(2, "plain")
+
+	data, err := json.Marshal(list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored := New()
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatal(err)
+	}
+	if restored.Get(1).Value != celsius(21.5) || restored.Get(2).Value != "plain" {
+		t.Fatal("JSON must go through the codec", string(data))
+	}
+
+	buf := &bytes.Buffer{}
+	if err := list.WriteMapped(buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "list.map")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := OpenMapped(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	raw, _ := m.Get(1)
+	if v, err := DecodeValue("test.celsius", celsiusCodec{}.Version(), raw); err != nil || v != celsius(21.5) {
+		t.Fatal("mapped values must be stored through the codec", v, err)
+	}
+	if raw, _ := m.Get(2); string(raw) != "plain" {
+		t.Fatal("strings are mapped as they are")
+	}
+
+	name, version, encoded, err := EncodeValue(celsius(3))
+	if err != nil || name != "test.celsius" || version != 1 {
+		t.Fatal("wrong encoding", name, version, err)
+	}
+	if v, _ := DecodeValue(name, 0, binary.BigEndian.AppendUint32(nil, 3)); v != celsius(3) {
+		t.Fatal("older versions must still decode", v)
+	}
+	if v, _ := DecodeValue(name, version, encoded); v != celsius(3) {
+		t.Fatal("wrong round trip", v)
+	}
+	if name, _, _, _ := EncodeValue(1); name != "" {
+		t.Fatal("types without a codec have no name")
+	}
+}
@@ -0,0 +1,69 @@
+package jumplist
+
+// Compact brings a list that lost most of its columns back into shape. Levels only grow with the
+// length, so after heavy removals the list keeps the height it once needed and every search starts
+// with walks along levels that are empty or nearly so. Compact lowers the list to the height its
+// length calls for, cutting the towers that reach above it. With relevel it also rebuilds every tower
+// with evenly spaced levels like NewFromSorted, undoing the clusters of tall and short towers that
+// removals leave behind. Columns stay in place, so the columns callers hold remain valid. It takes
+// O(n) with relevel and O(columns above the new height) without.
+func (list *SkipList) Compact(relevel bool) {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	height := max(min(levelsFor(list.probability, list.length), list.levelCap), min(list.levelCap, initialLevels))
+	if height < list.maxLevel {
+		list.lower(height)
+	}
+	if relevel {
+		list.relevel()
+	}
+	list.resize(0) //cursors and fingers have to find their place again
+}
+
+// lower drops the levels from height up, cutting the towers that reach them.
+func (list *SkipList) lower(height int) {
+	for column := list.startPointers.next[height]; column != nil; {
+		next := column.next[height]
+		column.next, column.span = column.next[:height], column.span[:height]
+		if list.weigher != nil {
+			column.sum = column.sum[:height]
+		}
+		column = next
+	}
+	list.startPointers.next = list.startPointers.next[:height]
+	list.startPointers.span = list.startPointers.span[:height]
+	if list.weigher != nil {
+		list.startPointers.sum = list.startPointers.sum[:height]
+	}
+	list.levelCursors = list.levelCursors[:height]
+	list.cursorRanks = list.cursorRanks[:height]
+	list.cursorColumn = nil
+	list.maxLevel = height
+	list.levels = newLevelDist(height, list.probability)
+	list.growAt = list.growthAt(height)
+}
+
+// relevel relinks the columns in order with towers from evenLevels.
+func (list *SkipList) relevel() {
+	column := list.startPointers.next[0]
+	clear(list.startPointers.next)
+	clear(list.startPointers.span)
+	list.length = 0
+	levels := make(evenLevels, list.maxLevel)
+
+	t := list.newTails()
+	for column != nil {
+		next := column.next[0]
+		if level := levels.next(); level <= cap(column.next) {
+			column.next, column.span = column.next[:level], column.span[:level]
+			clear(column.next)
+			clear(column.span)
+		} else {
+			column.next, column.span = make([]*Column, level), make([]int, level)
+		}
+		list.linkAtTails(t, column)
+		column = next
+	}
+	list.reweighAll()
+}
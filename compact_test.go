@@ -0,0 +1,62 @@
+package jumplist
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCompact(t *testing.T) {
+	for _, relevel := range []bool{false, true} {
+		list := New(WithSeed(1))
+		for i := 0; i < 100000; i++ {
+			list.Set(float64(i), i)
+		}
+		tall := list.maxLevel
+		for i := 0; i < 100000; i++ {
+			if i%1000 != 0 {
+				list.Del(float64(i))
+			}
+		}
+		kept := list.Get(5000)
+		cursor := list.Cursor(0)
+
+		list.Compact(relevel)
+		if list.maxLevel >= tall || list.maxLevel != max(levelsFor(list.probability, 100), initialLevels) {
+			t.Fatalf("relevel %v: %v levels left of %v", relevel, list.maxLevel, tall)
+		}
+		checkSanity(list, t)
+		if err := list.Validate(); err != nil {
+			t.Fatalf("relevel %v: %v", relevel, err)
+		}
+		if list.Len() != 100 || list.Get(5000) != kept || list.Rank(5000) != 5 || list.GetByRank(99).key != 99000 {
+			t.Fatalf("relevel %v: compacting must keep the columns and their ranks", relevel)
+		}
+		if next := cursor.Next(); next == nil || next.key != 1000 {
+			t.Fatalf("relevel %v: a cursor must carry on after Compact, got %v", relevel, next)
+		}
+
+		list.Set(0.5, "new")
+		if list.Get(0.5) == nil || list.Validate() != nil {
+			t.Fatalf("relevel %v: a compacted list must take new columns", relevel)
+		}
+	}
+
+	even := New()
+	for i := 0; i < 1000; i++ {
+		even.Set(float64(i), i)
+	}
+	even.Compact(true)
+	onLevel := 0
+	for column := even.startPointers.next[1]; column != nil; column = column.next[1] {
+		onLevel++
+	}
+	if expected := int(math.Floor(1000 / math.E)); onLevel < expected-1 || onLevel > expected+1 {
+		t.Fatalf("relevel must space level 1 evenly, it holds %v columns, expected about %v", onLevel, expected)
+	}
+
+	empty := New()
+	empty.Compact(true)
+	if empty.maxLevel != initialLevels || empty.Validate() != nil {
+		t.Fatal("compacting an empty list must leave it as new")
+	}
+}
@@ -0,0 +1,26 @@
+package jumplist
+
+// WithComparator orders the keys by less instead of numerically, such as descending with
+// func(a, b float64) bool { return a > b }, or with the keys of one range first. less must be a strict
+// total order: of two different keys exactly one is less, so keys are still equal only when they are
+// ==. Every method follows the order: Front holds the least key, a range [min, max] holds the keys
+// not less than min and not greater than max by less, Floor is the nearest key not greater and
+// Rank counts the keys less. Each comparison then costs a call.
+//
+// Features measuring numeric distance keep their own meaning: Nearest still measures how far keys are
+// apart. New panics when WithEpsilon is also given, and NewHistogram, WriteMapped, Tiered and
+// Cursor.Seek panic on a list with a comparator, as they need the numeric order. Merging, diffing or
+// comparing lists only makes sense for lists of one order.
+func WithComparator(less func(a, b float64) bool) Option {
+	return func(list *SkipList) {
+		list.less = less
+	}
+}
+
+// keyLess reports whether key a comes before key b in the order of the list.
+func (list *SkipList) keyLess(a, b float64) bool {
+	if list.less == nil {
+		return a < b
+	}
+	return list.less(a, b)
+}
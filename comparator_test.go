@@ -0,0 +1,155 @@
+package jumplist
+
+import (
+	"slices"
+	"testing"
+)
+
+func descending(a, b float64) bool { return a > b }
+
+func TestWithComparator(t *testing.T) {
+	list := New(WithComparator(descending))
+	for _, key := range []float64{3, 1, 4, 5, 9, 2, 6} {
+		list.Set(key, key*10)
+	}
+	list.Set(4, "four")
+
+	if err := list.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if keys := list.Keys(); !slices.Equal(keys, []float64{9, 6, 5, 4, 3, 2, 1}) {
+		t.Fatal("keys must be in descending order, got", keys)
+	}
+	if list.Front().Key() != 9 || list.Back().Key() != 1 || list.Get(4).Value != "four" {
+		t.Fatal("Front, Back and Get must follow the comparator")
+	}
+	if list.Floor(7).Key() != 9 || list.Ceiling(7).Key() != 6 || list.Rank(5) != 2 {
+		t.Fatal("Floor, Ceiling and Rank must follow the comparator")
+	}
+	var keys []float64
+	for _, column := range list.Range(6, 3) {
+		keys = append(keys, column.Key())
+	}
+	if !slices.Equal(keys, []float64{6, 5, 4, 3}) || list.Count(6, 3) != 4 || len(list.Range(3, 6)) != 0 {
+		t.Fatal("Range and Count must take min and max by the comparator, got", keys)
+	}
+	keys = keys[:0]
+	for key := range list.Backward() {
+		keys = append(keys, key)
+	}
+	if !slices.Equal(keys, []float64{1, 2, 3, 4, 5, 6, 9}) {
+		t.Fatal("Backward must walk to the least key first, got", keys)
+	}
+
+	if list.Del(5) == nil || list.Contains(5) || list.RemoveRange(3, 1) != 3 {
+		t.Fatal("Del and RemoveRange must follow the comparator")
+	}
+	if keys := list.Keys(); !slices.Equal(keys, []float64{9, 6, 4}) {
+		t.Fatal("removals left", keys)
+	}
+}
+
+func TestWithComparatorBuild(t *testing.T) {
+	list := NewFromSorted([]float64{5, 3, 1}, []interface{}{"a", "b", "c"}, WithComparator(descending))
+	list.SetBatch([]KV{{2, "d"}, {4, "e"}, {6, "f"}})
+	if err := list.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if keys := list.Keys(); !slices.Equal(keys, []float64{6, 5, 4, 3, 2, 1}) {
+		t.Fatal("NewFromSorted and SetBatch must follow the comparator, got", keys)
+	}
+
+	other := New(WithComparator(descending))
+	other.Set(0, "h")
+	other.Set(9, "i")
+	if keys := list.Merge(other, nil).Keys(); !slices.Equal(keys, []float64{9, 6, 5, 4, 3, 2, 1, 0}) {
+		t.Fatal("Merge must follow the comparator, got", keys)
+	}
+	var keys []float64
+	for key := range MergeIter(list, other) {
+		keys = append(keys, key)
+	}
+	if !slices.Equal(keys, []float64{9, 6, 5, 4, 3, 2, 1, 0}) {
+		t.Fatal("MergeIter must follow the comparator, got", keys)
+	}
+	clone := list.Clone()
+	clone.Set(8, "j")
+	if clone.Front().Key() != 8 || clone.Validate() != nil {
+		t.Fatal("Clone must keep the comparator")
+	}
+
+	left, right := list.Split(4)
+	if !slices.Equal(left.Keys(), []float64{6, 5}) || !slices.Equal(right.Keys(), []float64{4, 3, 2, 1}) {
+		t.Fatal("Split must follow the comparator, got", left.Keys(), right.Keys())
+	}
+	right.Set(7, "g")
+	if right.Front().Key() != 7 {
+		t.Fatal("Split must keep the comparator")
+	}
+}
+
+func TestWithComparatorViews(t *testing.T) {
+	list := New(WithComparator(descending))
+	for _, key := range []float64{1, 2, 3, 4, 5} {
+		list.Set(key, key)
+	}
+
+	snapshot := list.Snapshot()
+	if value, ok := snapshot.Get(2); !ok || value != 2.0 {
+		t.Fatal("Snapshot.Get must search by the comparator")
+	}
+	var keys []float64
+	for key := range snapshot.From(3) {
+		keys = append(keys, key)
+	}
+	if !slices.Equal(keys, []float64{3, 2, 1}) {
+		t.Fatal("Snapshot.From must follow the comparator, got", keys)
+	}
+
+	keys = keys[:0]
+	for cursor := (PageCursor{}); !cursor.End(); {
+		var columns []*Column
+		columns, cursor = list.Page(cursor, 2)
+		for _, column := range columns {
+			keys = append(keys, column.Key())
+		}
+	}
+	if !slices.Equal(keys, []float64{5, 4, 3, 2, 1}) {
+		t.Fatal("Page must follow the comparator, got", keys)
+	}
+
+	frozen := list.Freeze()
+	if frozen.Front().Key() != 5 || frozen.Floor(2.5).Key() != 3 || frozen.Count(4, 2) != 3 {
+		t.Fatal("FrozenList must follow the comparator")
+	}
+
+	m := NewMap(WithComparator(descending))
+	m.Store(1, "a")
+	m.Store(-1, "b")
+	keys = keys[:0]
+	m.Range(func(key float64, value interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+	if !slices.Equal(keys, []float64{1, -1}) {
+		t.Fatal("Map.Range must start at the front, got", keys)
+	}
+}
+
+func TestWithComparatorNumericOnly(t *testing.T) {
+	list := New(WithComparator(descending))
+	for name, fn := range map[string]func(){
+		"WithEpsilon": func() { New(WithComparator(descending), WithEpsilon(0.1)) },
+		"Histogram":   func() { list.Histogram(4) },
+		"Seek":        func() { list.Cursor(0).Seek(1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error(name, "must panic on a list with a comparator")
+				}
+			}()
+			fn()
+		}()
+	}
+}
@@ -0,0 +1,34 @@
+package jumplist
+
+import "math"
+
+// Key is a composite key ordered by Score and then by Tie, such as a timestamp or sequence
+// number, so entries with equal scores keep a stable order instead of overwriting each other.
+type Key struct {
+	Score float64
+	Tie   int64
+}
+
+// Less orders keys by Score, then by Tie.
+func (k Key) Less(other Key) bool {
+	if k.Score != other.Score {
+		return k.Score < other.Score
+	}
+	return k.Tie < other.Tie
+}
+
+// CompositeSkipList is a List keyed by Key.
+type CompositeSkipList struct {
+	*List[Key, interface{}]
+}
+
+// NewCompositeSkipList returns an empty list ordered by score and tiebreaker.
+func NewCompositeSkipList() *CompositeSkipList {
+	return &CompositeSkipList{NewListFunc[Key, interface{}](18, Key.Less)}
+}
+
+// RangeByScore returns the nodes with scores within [min, max], ordered by score and then tiebreaker.
+// Range bounds both components.
+func (list *CompositeSkipList) RangeByScore(min, max float64) []*Node[Key, interface{}] {
+	return list.Range(Key{min, math.MinInt64}, Key{max, math.MaxInt64})
+}
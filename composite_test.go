@@ -0,0 +1,49 @@
+package jumplist
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCompositeSkipList(t *testing.T) {
+	list := NewCompositeSkipList()
+	list.Set(Key{10, 300}, "c")
+	list.Set(Key{20, 100}, "d")
+	list.Set(Key{10, 100}, "a")
+	list.Set(Key{10, 200}, "b")
+	list.Set(Key{5, math.MaxInt64}, "first")
+	list.Set(Key{10, 200}, "B")
+
+	values := func(nodes []*Node[Key, interface{}]) []interface{} {
+		out := []interface{}{}
+		for _, n := range nodes {
+			out = append(out, n.Value)
+		}
+		return out
+	}
+	check := func(name string, got []interface{}, expected ...interface{}) {
+		if len(got) != len(expected) {
+			t.Fatalf("%v: got %v, expected %v", name, got, expected)
+		}
+		for i := range got {
+			if got[i] != expected[i] {
+				t.Fatalf("%v: got %v, expected %v", name, got, expected)
+			}
+		}
+	}
+
+	if list.Len() != 5 {
+		t.Fatalf("list holds %v nodes, expected 5", list.Len())
+	}
+	check("equal scores", values(list.RangeByScore(10, 10)), "a", "B", "c")
+	check("scores", values(list.RangeByScore(0, 15)), "first", "a", "B", "c")
+	check("both components", values(list.Range(Key{10, 150}, Key{20, 100})), "B", "c", "d")
+	check("tiebreaker bound", values(list.Range(Key{10, 100}, Key{10, 299})), "a", "B")
+
+	if n := list.Get(Key{10, 300}); n == nil || n.Value != "c" {
+		t.Fatal("composite key lookup failed", n)
+	}
+	if list.Get(Key{10, 301}) != nil {
+		t.Fatal("a different tiebreaker is a different key")
+	}
+}
@@ -0,0 +1,96 @@
+package concurrent
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// collectEvery is how many retirements trigger a Collect from Retire itself.
+const collectEvery = 128
+
+// Epochs is an epoch based reclamation domain. Readers Pin it while they may hold references into a
+// structure, and whatever a writer unlinked is handed to Retire with the function releasing it. That
+// function runs once every reader pinned at the time has unpinned, two epoch advances later.
+//
+// The garbage collector already keeps unlinked nodes alive for readers, so this is about what the
+// collector does not see: values holding pooled buffers, file handles or manually managed memory.
+type Epochs struct {
+	epoch   atomic.Uint64
+	active  [3]atomic.Int64 //readers pinned in each of the last three epochs
+	mutex   sync.Mutex
+	bags    [3][]func() //retired in each epoch, released two advances later
+	retired int         //since the last Collect
+}
+
+// Guard is a pinned epoch, released by Unpin. The zero Guard pins nothing.
+type Guard struct {
+	epochs *Epochs
+	epoch  uint64
+}
+
+// NewEpochs returns an empty reclamation domain.
+func NewEpochs() *Epochs {
+	return &Epochs{}
+}
+
+// Pin enters the current epoch. Nothing retired from now on is released before Unpin.
+func (e *Epochs) Pin() Guard {
+	for {
+		epoch := e.epoch.Load()
+		e.active[epoch%3].Add(1)
+		if e.epoch.Load() == epoch {
+			return Guard{e, epoch}
+		}
+		e.active[epoch%3].Add(-1) //the epoch moved on meanwhile, pin the new one
+	}
+}
+
+// Unpin leaves the epoch entered by Pin.
+func (g Guard) Unpin() {
+	if g.epochs != nil {
+		g.epochs.active[g.epoch%3].Add(-1)
+	}
+}
+
+// Retire queues release for an object already unlinked, so no reader pinning later can reach it.
+// Every collectEvery retirements it collects too.
+func (e *Epochs) Retire(release func()) {
+	e.mutex.Lock()
+	e.bags[e.epoch.Load()%3] = append(e.bags[e.epoch.Load()%3], release)
+	e.retired++
+	collect := e.retired >= collectEvery
+	e.mutex.Unlock()
+
+	if collect {
+		e.Collect()
+	}
+}
+
+// Collect advances the epoch if no reader is pinned in an older one and runs the releases that became
+// safe, returning how many ran. A reader that stays pinned holds back every release retired since.
+func (e *Epochs) Collect() int {
+	e.mutex.Lock()
+	epoch := e.epoch.Load()
+	if e.active[(epoch+2)%3].Load() != 0 || e.active[(epoch+1)%3].Load() != 0 {
+		e.mutex.Unlock()
+		return 0 //readers are still pinned in the previous two epochs
+	}
+	ready := e.bags[(epoch+1)%3] //retired two epochs ago
+	e.bags[(epoch+1)%3] = nil
+	e.epoch.Store(epoch + 1)
+	e.retired = 0
+	e.mutex.Unlock()
+
+	for _, release := range ready {
+		release()
+	}
+	return len(ready)
+}
+
+// Pending returns the number of retired objects not released yet.
+func (e *Epochs) Pending() int {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return len(e.bags[0]) + len(e.bags[1]) + len(e.bags[2])
+}
@@ -0,0 +1,102 @@
+package concurrent
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// resource stands for a value owning something the garbage collector does not manage
+type resource struct {
+	released atomic.Bool
+}
+
+func TestEpochsPinned(t *testing.T) {
+	epochs := NewEpochs()
+	released := 0
+	guard := epochs.Pin()
+	epochs.Retire(func() { released++ })
+
+	for i := 0; i < 5; i++ {
+		epochs.Collect()
+	}
+	if released != 0 || epochs.Pending() != 1 {
+		t.Fatal("nothing may be released while a reader that could hold it is pinned")
+	}
+
+	guard.Unpin()
+	for i := 0; i < 3; i++ {
+		epochs.Collect()
+	}
+	if released != 1 || epochs.Pending() != 0 {
+		t.Fatalf("released %v, %v pending after unpinning (expected 1 and 0)", released, epochs.Pending())
+	}
+
+	Guard{}.Unpin() //the zero Guard is a no-op
+}
+
+// every value removed or overwritten must be released exactly once, and none that is still reachable
+func TestReclaimerLeaks(t *testing.T) {
+	epochs := NewEpochs()
+	var released atomic.Int64
+	list := NewWithReclaimer(18, epochs, func(key float64, value interface{}) {
+		if value.(*resource).released.Swap(true) {
+			t.Errorf("value of %v released twice", key)
+		}
+		released.Add(1)
+	})
+
+	var created atomic.Int64
+	wg := &sync.WaitGroup{}
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(w)))
+			for i := 0; i < 20000; i++ {
+				key := float64(r.Intn(64))
+				switch r.Intn(4) {
+				case 0:
+					list.Set(key, &resource{})
+					created.Add(1)
+				case 1:
+					list.Del(key)
+				case 2:
+					list.View(key, func(value interface{}) {
+						if value.(*resource).released.Load() {
+							t.Errorf("value of %v released while a reader holds it", key)
+						}
+					})
+				default:
+					list.Range(func(key float64, value interface{}) bool {
+						if value.(*resource).released.Load() {
+							t.Errorf("value of %v released while a reader holds it", key)
+						}
+						return r.Intn(8) != 0
+					})
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	checkSanity(list, t)
+
+	for i := 0; i < 3; i++ {
+		epochs.Collect()
+	}
+	if epochs.Pending() != 0 {
+		t.Fatalf("%v retired values never released", epochs.Pending())
+	}
+	list.Range(func(key float64, value interface{}) bool {
+		if value.(*resource).released.Load() {
+			t.Fatalf("value of %v is still in the list but was released", key)
+		}
+		return true
+	})
+
+	//whatever is not in the list anymore was released, removed or overwritten
+	if released.Load() != created.Load()-int64(list.Len()) {
+		t.Fatalf("released %v of %v values, %v are still in the list", released.Load(), created.Load(), list.Len())
+	}
+}
@@ -0,0 +1,207 @@
+package concurrent
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+type lazyNode struct {
+	key         float64
+	value       atomic.Pointer[box]
+	next        []atomic.Pointer[lazyNode]
+	mutex       sync.Mutex
+	marked      atomic.Bool //logically removed, unlinked by the Del holding the mutex
+	fullyLinked atomic.Bool //linked on every level, before that it is not in the list yet
+}
+
+// Lazy is the lazy concurrent skip list of Herlihy, Lev, Luchangco and Shavit. Writers lock only the
+// predecessors of their key and check that nothing changed around them before linking or unlinking,
+// so writers in disjoint key regions proceed in parallel. Get and Range take no locks and never wait.
+type Lazy struct {
+	head          *lazyNode //sentinel below every key, the end of a level is nil
+	probabilities []float64
+	length        atomic.Int64
+}
+
+func NewLazyWithLevel(level int) *Lazy {
+	if level < 1 || level > 64 {
+		panic("level must be 1~64")
+	}
+	return &Lazy{head: &lazyNode{next: make([]atomic.Pointer[lazyNode], level)}, probabilities: levelProbabilities(level)}
+}
+
+func NewLazy() *Lazy {
+	return NewLazyWithLevel(18)
+}
+
+// find fills preds and succs around key on every level and returns the highest level holding key, -1 if none.
+func (list *Lazy) find(key float64, preds, succs []*lazyNode) int {
+	found := -1
+	pred := list.head
+	for level := len(list.head.next) - 1; level >= 0; level-- {
+		curr := pred.next[level].Load()
+		for curr != nil && key > curr.key {
+			pred = curr
+			curr = pred.next[level].Load()
+		}
+		if found == -1 && curr != nil && curr.key == key {
+			found = level
+		}
+		preds[level], succs[level] = pred, curr
+	}
+	return found
+}
+
+// lockPreds locks the distinct predecessors on the lowest levels, from the bottom up, and returns the
+// function unlocking them. Lower predecessors are further right, so every writer locks from right to
+// left and two of them cannot wait on each other.
+func lockPreds(preds []*lazyNode, levels int) (unlock func()) {
+	var last *lazyNode
+	locked := preds[:0:0]
+	for _, pred := range preds[:levels] {
+		if pred != last {
+			pred.mutex.Lock()
+			locked = append(locked, pred)
+			last = pred
+		}
+	}
+	return func() {
+		for _, pred := range locked {
+			pred.mutex.Unlock()
+		}
+	}
+}
+
+// Set inserts or overwrites key. An overwrite stores the value under the lock of the node, so it
+// either lands before a concurrent Del marks the node, and that Del returns it, or waits for the
+// removal and inserts key again.
+func (list *Lazy) Set(key float64, value interface{}) {
+	levels := len(list.head.next)
+	preds, succs := make([]*lazyNode, levels), make([]*lazyNode, levels)
+	height := randLevel(list.probabilities)
+	b := &box{value}
+
+	for {
+		if found := list.find(key, preds, succs); found != -1 {
+			n := succs[found]
+			if !n.marked.Load() {
+				for !n.fullyLinked.Load() {
+					runtime.Gosched() //the insert of key is still linking it
+				}
+				n.mutex.Lock() //Del marks under it, so once held the node cannot be unlinked under the store
+				if !n.marked.Load() {
+					n.value.Store(b)
+					n.mutex.Unlock()
+					return
+				}
+				n.mutex.Unlock()
+			}
+			continue //being removed, retry once it is gone
+		}
+
+		unlock := lockPreds(preds, height)
+		valid := true
+		for level := 0; valid && level < height; level++ {
+			pred, succ := preds[level], succs[level]
+			valid = !pred.marked.Load() && (succ == nil || !succ.marked.Load()) && pred.next[level].Load() == succ
+		}
+		if !valid {
+			unlock()
+			continue
+		}
+
+		n := &lazyNode{key: key, next: make([]atomic.Pointer[lazyNode], height)}
+		n.value.Store(b)
+		for level := 0; level < height; level++ {
+			n.next[level].Store(succs[level])
+		}
+		for level := 0; level < height; level++ {
+			preds[level].next[level].Store(n)
+		}
+		n.fullyLinked.Store(true)
+		unlock()
+		list.length.Add(1)
+		return
+	}
+}
+
+// Get returns the value of key and whether it is present.
+func (list *Lazy) Get(key float64) (value interface{}, ok bool) {
+	pred := list.head
+	for level := len(list.head.next) - 1; level >= 0; level-- {
+		curr := pred.next[level].Load()
+		for curr != nil && key > curr.key {
+			pred = curr
+			curr = pred.next[level].Load()
+		}
+		if curr != nil && curr.key == key {
+			if !curr.fullyLinked.Load() || curr.marked.Load() {
+				return nil, false
+			}
+			return curr.value.Load().value, true
+		}
+	}
+	return nil, false
+}
+
+// Del removes key and returns its value and whether it was present.
+func (list *Lazy) Del(key float64) (value interface{}, ok bool) {
+	levels := len(list.head.next)
+	preds, succs := make([]*lazyNode, levels), make([]*lazyNode, levels)
+	var victim *lazyNode
+
+	for {
+		found := list.find(key, preds, succs)
+		if victim == nil {
+			if found == -1 {
+				return nil, false
+			}
+			n := succs[found]
+			if !n.fullyLinked.Load() || n.marked.Load() || len(n.next)-1 != found {
+				return nil, false //not inserted yet, already removed, or found below its top level by a racing change
+			}
+			n.mutex.Lock()
+			if n.marked.Load() {
+				n.mutex.Unlock()
+				return nil, false
+			}
+			n.marked.Store(true) //from here on it is removed, only the unlinking is left
+			victim = n
+		}
+
+		height := len(victim.next)
+		unlock := lockPreds(preds, height)
+		valid := true
+		for level := 0; valid && level < height; level++ {
+			valid = !preds[level].marked.Load() && preds[level].next[level].Load() == victim
+		}
+		if !valid {
+			unlock()
+			continue
+		}
+
+		for level := height - 1; level >= 0; level-- {
+			preds[level].next[level].Store(victim.next[level].Load())
+		}
+		victim.mutex.Unlock()
+		unlock()
+		list.length.Add(-1)
+		return victim.value.Load().value, true
+	}
+}
+
+// Len returns the number of keys.
+func (list *Lazy) Len() int {
+	return int(list.length.Load())
+}
+
+// Range calls fn for every key in ascending order until it returns false. It is weakly consistent:
+// keys set or removed while it runs may or may not be seen.
+func (list *Lazy) Range(fn func(key float64, value interface{}) bool) {
+	for curr := list.head.next[0].Load(); curr != nil; curr = curr.next[0].Load() {
+		if curr.fullyLinked.Load() && !curr.marked.Load() && !fn(curr.key, curr.value.Load().value) {
+			return
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package concurrent
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func checkLazy(list *Lazy, t *testing.T) {
+	for level := range list.head.next {
+		var prev *lazyNode
+		for curr := list.head.next[level].Load(); curr != nil; curr = curr.next[level].Load() {
+			if prev != nil && !(curr.key > prev.key) {
+				t.Fatalf("level %v is out of order: %v after %v", level, curr.key, prev.key)
+			}
+			if curr.marked.Load() || !curr.fullyLinked.Load() {
+				t.Fatalf("node %v is linked on level %v while removed or half inserted", curr.key, level)
+			}
+			prev = curr
+		}
+	}
+
+	cnt := 0
+	list.Range(func(key float64, value interface{}) bool {
+		cnt++
+		return true
+	})
+	if cnt != list.Len() {
+		t.Fatalf("ranged over %v keys, Len is %v", cnt, list.Len())
+	}
+}
+
+func TestLazyCRUD(t *testing.T) {
+	list := NewLazy()
+	list.Set(10, 1)
+	list.Set(60, 2)
+	list.Set(30, 3)
+	list.Set(30, 9)
+
+	if v, ok := list.Get(30); !ok || v.(int) != 9 {
+		t.Fatal(`wrong "30" value (expected "9")`, v)
+	}
+	if v, ok := list.Del(10); !ok || v.(int) != 1 {
+		t.Fatal(`Del of "10" must return "1"`, v)
+	}
+	if _, ok := list.Del(10); ok {
+		t.Fatal(`second Del of "10" must fail`)
+	}
+	if _, ok := list.Get(10); ok {
+		t.Fatal(`found "10", which should have been deleted`)
+	}
+	if list.Len() != 2 {
+		t.Fatalf("wrong length %v (expected 2)", list.Len())
+	}
+	checkLazy(list, t)
+}
+
+// every goroutine owns its own keys, so the result must match a model of its own operations
+func TestLazyConcurrent(t *testing.T) {
+	list := NewLazy()
+	const workers = 8
+
+	models := make([]map[float64]int, workers)
+	wg := &sync.WaitGroup{}
+	for w := 0; w < workers; w++ {
+		models[w] = map[float64]int{}
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(w)))
+			model := models[w]
+			for i := 0; i < 20000; i++ {
+				key := float64(r.Intn(500)*workers + w)
+				switch r.Intn(3) {
+				case 0:
+					list.Set(key, i)
+					model[key] = i
+				case 1:
+					_, ok := list.Del(key)
+					if _, held := model[key]; ok != held {
+						t.Errorf("Del(%v) returned %v, model says %v", key, ok, held)
+						return
+					}
+					delete(model, key)
+				default:
+					v, ok := list.Get(key)
+					if expected, held := model[key]; ok != held || (ok && v.(int) != expected) {
+						t.Errorf("Get(%v) returned %v %v, model says %v %v", key, v, ok, expected, held)
+						return
+					}
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	total := 0
+	for _, model := range models {
+		total += len(model)
+	}
+	if list.Len() != total {
+		t.Fatalf("wrong length %v (expected %v)", list.Len(), total)
+	}
+	checkLazy(list, t)
+
+	//and fighting over a handful of keys must keep the structure consistent
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(w)))
+			for i := 0; i < 20000; i++ {
+				key := float64(r.Intn(16))
+				switch r.Intn(3) {
+				case 0:
+					list.Set(key, w)
+				case 1:
+					list.Del(key)
+				default:
+					list.Get(key)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	checkLazy(list, t)
+}
+
+// one setter and one deleter per key: a Set that a Get right after it finds undone must have been
+// removed by a Del, which then returned its value, and an overwrite of a node being unlinked would be lost
+func TestLazySetDelRace(t *testing.T) {
+	list := NewLazy()
+	const keys, rounds = 4, 20000
+
+	wg := &sync.WaitGroup{}
+	for k := 0; k < keys; k++ {
+		undone, deleted := map[int]bool{}, map[int]bool{}
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				list.Set(float64(k), i)
+				if v, ok := list.Get(float64(k)); !ok {
+					undone[i] = true
+				} else if v.(int) != i {
+					t.Errorf("Get(%v) after Set of %v returned %v", k, i, v)
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				if v, ok := list.Del(float64(k)); ok {
+					deleted[v.(int)] = true
+				}
+			}
+		}()
+		defer func() {
+			for i := range undone {
+				if !deleted[i] {
+					t.Fatalf("Set(%v, %v) was lost: gone right after it without a Del returning it", k, i)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	checkLazy(list, t)
+}
+
+func BenchmarkLazyParallelSetGet(b *testing.B) {
+	list := NewLazy()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		r := rand.New(rand.NewSource(rand.Int63()))
+		for pb.Next() {
+			key := float64(r.Intn(100000))
+			if r.Intn(10) == 0 {
+				list.Set(key, key)
+			} else {
+				list.Get(key)
+			}
+		}
+	})
+}
@@ -0,0 +1,128 @@
+package concurrent
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+type readNode struct {
+	key   float64
+	value atomic.Pointer[box]
+	next  []atomic.Pointer[readNode]
+}
+
+// ReadMostly is a skip list for workloads that mostly read. Writers take one mutex and publish every
+// change with atomic stores, so Get and Range take no lock, never wait and never retry, while writers
+// need none of the marking of SkipList or the per-node locks of Lazy.
+//
+// An insert fills the tower of the new node before linking it from the bottom up, and a removal
+// unlinks from the top down but leaves the forward pointers of the node as they were. A reader standing
+// on a removed node therefore still walks on to every key that stays in the list, and the garbage
+// collector keeps the node alive for it.
+type ReadMostly struct {
+	mutex         sync.Mutex //held by writers
+	head          *readNode  //sentinel below every key, the end of a level is nil
+	probabilities []float64
+	length        atomic.Int64
+}
+
+func NewReadMostlyWithLevel(level int) *ReadMostly {
+	if level < 1 || level > 64 {
+		panic("level must be 1~64")
+	}
+	return &ReadMostly{head: &readNode{next: make([]atomic.Pointer[readNode], level)}, probabilities: levelProbabilities(level)}
+}
+
+func NewReadMostly() *ReadMostly {
+	return NewReadMostlyWithLevel(18)
+}
+
+// find fills preds with the last node before key on every level and returns the node at key, nil if
+// there is none. Only writers call it, holding the mutex.
+func (list *ReadMostly) find(key float64, preds []*readNode) *readNode {
+	pred := list.head
+	for level := len(list.head.next) - 1; level >= 0; level-- {
+		curr := pred.next[level].Load()
+		for curr != nil && key > curr.key {
+			pred = curr
+			curr = pred.next[level].Load()
+		}
+		preds[level] = pred
+	}
+	if curr := pred.next[0].Load(); curr != nil && curr.key == key {
+		return curr
+	}
+	return nil
+}
+
+// Set inserts or overwrites key.
+func (list *ReadMostly) Set(key float64, value interface{}) {
+	preds := make([]*readNode, len(list.head.next))
+	b := &box{value}
+
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	if n := list.find(key, preds); n != nil {
+		n.value.Store(b)
+		return
+	}
+	n := &readNode{key: key, next: make([]atomic.Pointer[readNode], randLevel(list.probabilities))}
+	n.value.Store(b)
+	for level := range n.next {
+		n.next[level].Store(preds[level].next[level].Load())
+	}
+	for level := range n.next {
+		preds[level].next[level].Store(n) //from the bottom up, so a node seen on a level is on every level below
+	}
+	list.length.Add(1)
+}
+
+// Get returns the value of key and whether it is present, without any lock.
+func (list *ReadMostly) Get(key float64) (value interface{}, ok bool) {
+	pred := list.head
+	for level := len(list.head.next) - 1; level >= 0; level-- {
+		curr := pred.next[level].Load()
+		for curr != nil && key > curr.key {
+			pred = curr
+			curr = pred.next[level].Load()
+		}
+		if curr != nil && curr.key == key {
+			return curr.value.Load().value, true
+		}
+	}
+	return nil, false
+}
+
+// Del removes key and returns its value and whether it was present.
+func (list *ReadMostly) Del(key float64) (value interface{}, ok bool) {
+	preds := make([]*readNode, len(list.head.next))
+
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	n := list.find(key, preds)
+	if n == nil {
+		return nil, false
+	}
+	for level := len(n.next) - 1; level >= 0; level-- {
+		preds[level].next[level].Store(n.next[level].Load())
+	}
+	list.length.Add(-1)
+	return n.value.Load().value, true
+}
+
+// Len returns the number of keys.
+func (list *ReadMostly) Len() int {
+	return int(list.length.Load())
+}
+
+// Range calls fn for every key in ascending order until it returns false, without any lock. It is
+// weakly consistent: keys set or removed while it runs may or may not be seen.
+func (list *ReadMostly) Range(fn func(key float64, value interface{}) bool) {
+	for curr := list.head.next[0].Load(); curr != nil; curr = curr.next[0].Load() {
+		if !fn(curr.key, curr.value.Load().value) {
+			return
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package concurrent
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func checkReadMostly(list *ReadMostly, t *testing.T) {
+	for level := range list.head.next {
+		var prev *readNode
+		for curr := list.head.next[level].Load(); curr != nil; curr = curr.next[level].Load() {
+			if prev != nil && !(curr.key > prev.key) {
+				t.Fatalf("level %v is out of order: %v after %v", level, curr.key, prev.key)
+			}
+			prev = curr
+		}
+	}
+
+	cnt := 0
+	list.Range(func(key float64, value interface{}) bool {
+		cnt++
+		return true
+	})
+	if cnt != list.Len() {
+		t.Fatalf("ranged over %v keys, Len is %v", cnt, list.Len())
+	}
+}
+
+func TestReadMostlyCRUD(t *testing.T) {
+	list := NewReadMostly()
+	list.Set(10, 1)
+	list.Set(60, 2)
+	list.Set(30, 3)
+	list.Set(30, 9)
+
+	if v, ok := list.Get(30); !ok || v.(int) != 9 {
+		t.Fatal(`wrong "30" value (expected "9")`, v)
+	}
+	if v, ok := list.Del(10); !ok || v.(int) != 1 {
+		t.Fatal(`Del of "10" must return "1"`, v)
+	}
+	if _, ok := list.Del(10); ok {
+		t.Fatal(`second Del of "10" must fail`)
+	}
+	if _, ok := list.Get(10); ok {
+		t.Fatal(`found "10", which should have been deleted`)
+	}
+	if list.Len() != 2 {
+		t.Fatalf("wrong length %v (expected 2)", list.Len())
+	}
+	checkReadMostly(list, t)
+}
+
+// readers must always find the keys no writer removes, with a value some writer stored, while
+// writers churn the keys around them; run with -race to check the publication too
+func TestReadMostlyStress(t *testing.T) {
+	list := NewReadMostly()
+	const stable = 1000
+	for i := 0; i < stable; i++ {
+		list.Set(float64(i*2), i*2)
+	}
+
+	var stop atomic.Bool
+	wg := &sync.WaitGroup{}
+	for w := 0; w < 2; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(w)))
+			for i := 0; i < 30000; i++ {
+				key := r.Intn(2 * stable)
+				switch {
+				case key%2 == 0:
+					list.Set(float64(key), key) //overwrite with an equal value
+				case r.Intn(2) == 0:
+					list.Set(float64(key), key)
+				default:
+					list.Del(float64(key))
+				}
+			}
+		}(w)
+	}
+
+	readers := &sync.WaitGroup{}
+	for w := 0; w < 6; w++ {
+		readers.Add(1)
+		go func(w int) {
+			defer readers.Done()
+			r := rand.New(rand.NewSource(int64(100 + w)))
+			for !stop.Load() {
+				key := r.Intn(2 * stable)
+				v, ok := list.Get(float64(key))
+				if key%2 == 0 && !ok {
+					t.Errorf("Get(%v) missed a key that is never removed", key)
+					return
+				}
+				if ok && v.(int) != key {
+					t.Errorf("Get(%v) returned %v", key, v)
+					return
+				}
+				if r.Intn(100) == 0 {
+					last := -1.0
+					list.Range(func(key float64, value interface{}) bool {
+						if !(key > last) || value.(int) != int(key) {
+							t.Errorf("Range yields %v=%v after %v", key, value, last)
+							return false
+						}
+						last = key
+						return true
+					})
+				}
+			}
+		}(w)
+	}
+
+	wg.Wait()
+	stop.Store(true)
+	readers.Wait()
+	checkReadMostly(list, t)
+	for i := 0; i < stable; i++ {
+		if _, ok := list.Get(float64(i * 2)); !ok {
+			t.Fatalf("key %v is gone", i*2)
+		}
+	}
+}
+
+func BenchmarkReadMostlyParallelGet(b *testing.B) {
+	read := NewReadMostly()
+	lazy := NewLazy()
+	for i := 0; i < 100000; i++ {
+		read.Set(float64(i), i)
+		lazy.Set(float64(i), i)
+	}
+	run := func(set func(float64, interface{}), get func(float64) (interface{}, bool)) func(b *testing.B) {
+		return func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				r := rand.New(rand.NewSource(rand.Int63()))
+				for pb.Next() {
+					key := float64(r.Intn(100000))
+					if r.Intn(100) == 0 {
+						set(key, int(key))
+					} else {
+						get(key)
+					}
+				}
+			})
+		}
+	}
+	b.Run("readmostly", run(read.Set, read.Get))
+	b.Run("lazy", run(lazy.Set, lazy.Get))
+}
@@ -0,0 +1,284 @@
+// Package concurrent provides a lock-free skip list keyed by float64, following the Fraser/Harris
+// design: nodes are deleted by marking their forward pointers first and unlinked afterwards by
+// whichever operation runs into them. Lazy is the lock based alternative with per-node locks, and
+// ReadMostly serializes writers on one mutex for lock-free reads at the lowest cost.
+package concurrent
+
+import (
+	"math"
+	"math/rand"
+	"sync/atomic"
+)
+
+// ref is an immutable pointer plus deletion mark, swapped as a whole so both change in one CAS.
+type ref struct {
+	node   *node
+	marked bool
+}
+
+type box struct {
+	value interface{}
+}
+
+type node struct {
+	key   float64
+	value atomic.Pointer[box] //nil once the node is removed
+	next  []atomic.Pointer[ref]
+}
+
+type SkipList struct {
+	head          *node
+	maxLevel      int
+	probabilities []float64
+	length        atomic.Int64
+
+	epochs  *Epochs //see NewWithReclaimer
+	release func(key float64, value interface{})
+}
+
+func NewWithLevel(level int) *SkipList {
+	if level < 1 || level > 64 {
+		panic("level must be 1~64")
+	}
+	list := &SkipList{
+		head:          &node{next: make([]atomic.Pointer[ref], level)},
+		maxLevel:      level,
+		probabilities: levelProbabilities(level),
+	}
+	for i := range list.head.next {
+		list.head.next[i].Store(&ref{})
+	}
+	return list
+}
+
+func New() *SkipList {
+	return NewWithLevel(18) //e^18 = 65659969
+}
+
+// NewWithReclaimer returns a list whose operations pin epochs, so a removed or overwritten value is
+// passed to release only once no Get, View or Range that might still read it is running.
+// A value returned by Get has left the guard, read values inside View or Range to rely on this.
+func NewWithReclaimer(level int, epochs *Epochs, release func(key float64, value interface{})) *SkipList {
+	list := NewWithLevel(level)
+	list.epochs, list.release = epochs, release
+	return list
+}
+
+func (list *SkipList) pin() Guard {
+	if list.epochs == nil {
+		return Guard{}
+	}
+	return list.epochs.Pin()
+}
+
+// retire hands a value no longer reachable from the list to the reclaimer.
+func (list *SkipList) retire(key float64, value interface{}) {
+	if list.epochs != nil {
+		list.epochs.Retire(func() { list.release(key, value) })
+	}
+}
+
+// levelProbabilities returns the chance to grow past each of level levels, a ratio of 1/e apart.
+func levelProbabilities(level int) []float64 {
+	probabilities := []float64{}
+	prob := 1.0
+	for i := 1; i <= level; i++ {
+		prob /= math.E
+		probabilities = append(probabilities, prob)
+	}
+	return probabilities
+}
+
+func (list *SkipList) randLevel() int {
+	return randLevel(list.probabilities)
+}
+
+func randLevel(probabilities []float64) int {
+	r := float64(rand.Int63()) / (1 << 63) //the global source is safe for concurrent use
+
+	for level, prob := range probabilities {
+		if r > prob {
+			return level + 1
+		}
+	}
+	return len(probabilities)
+}
+
+// find fills preds and succs with the nodes around key on every level, unlinking marked nodes
+// on the way, and reports whether succs[0] holds key.
+func (list *SkipList) find(key float64, preds, succs []*node) bool {
+retry:
+	pred := list.head
+	for level := list.maxLevel - 1; level >= 0; level-- {
+		predRef := pred.next[level].Load()
+		curr := predRef.node
+
+		for curr != nil {
+			currRef := curr.next[level].Load()
+			if currRef.marked { //curr is being deleted, help unlinking it
+				if predRef.marked || !pred.next[level].CompareAndSwap(predRef, &ref{node: currRef.node}) {
+					goto retry
+				}
+				predRef = pred.next[level].Load()
+				curr = predRef.node
+				continue
+			}
+			if curr.key >= key {
+				break
+			}
+			pred, predRef = curr, currRef
+			curr = currRef.node
+		}
+
+		preds[level], succs[level] = pred, curr
+	}
+
+	return succs[0] != nil && succs[0].key == key
+}
+
+// mark flags every forward pointer of n from the top down, after which find will unlink it.
+func mark(n *node) {
+	for level := len(n.next) - 1; level >= 0; level-- {
+		for {
+			r := n.next[level].Load()
+			if r.marked || n.next[level].CompareAndSwap(r, &ref{node: r.node, marked: true}) {
+				break
+			}
+		}
+	}
+}
+
+// Set stores value at key, inserting a node if the key is absent.
+func (list *SkipList) Set(key float64, value interface{}) {
+	defer list.pin().Unpin()
+	preds := make([]*node, list.maxLevel)
+	succs := make([]*node, list.maxLevel)
+	b := &box{value}
+
+	for {
+		if list.find(key, preds, succs) {
+			n := succs[0]
+			old := n.value.Load()
+			if old != nil && n.value.CompareAndSwap(old, b) {
+				list.retire(key, old.value)
+				return
+			}
+			if old == nil {
+				mark(n) //lost to a Del, help it finish so find stops returning n
+			}
+			continue
+		}
+
+		n := &node{key: key, next: make([]atomic.Pointer[ref], list.randLevel())}
+		n.value.Store(b)
+		for level := range n.next {
+			n.next[level].Store(&ref{node: succs[level]})
+		}
+
+		//linking the bottom level is the linearization point of the insert
+		predRef := preds[0].next[0].Load()
+		if predRef.marked || predRef.node != succs[0] || !preds[0].next[0].CompareAndSwap(predRef, &ref{node: n}) {
+			continue
+		}
+		list.length.Add(1)
+
+		for level := 1; level < len(n.next); level++ {
+			for {
+				predRef := preds[level].next[level].Load()
+				if !predRef.marked && predRef.node == succs[level] &&
+					preds[level].next[level].CompareAndSwap(predRef, &ref{node: n}) {
+					break
+				}
+
+				list.find(key, preds, succs) //neighbourhood changed, look again
+				own := n.next[level].Load()
+				if own.marked {
+					return //n is already being deleted, stop building its tower
+				}
+				if own.node != succs[level] && !n.next[level].CompareAndSwap(own, &ref{node: succs[level]}) {
+					return //only a concurrent mark can change it
+				}
+			}
+		}
+		return
+	}
+}
+
+// Get returns the value at key.
+func (list *SkipList) Get(key float64) (value interface{}, ok bool) {
+	defer list.pin().Unpin()
+	return list.get(key)
+}
+
+// View calls fn with the value at key while the epoch is pinned, so the value is not released meanwhile.
+// It reports whether key was present.
+func (list *SkipList) View(key float64, fn func(value interface{})) bool {
+	defer list.pin().Unpin()
+	value, ok := list.get(key)
+	if ok {
+		fn(value)
+	}
+	return ok
+}
+
+func (list *SkipList) get(key float64) (value interface{}, ok bool) {
+	pred := list.head
+	var curr *node
+
+	for level := list.maxLevel - 1; level >= 0; level-- {
+		curr = pred.next[level].Load().node
+		for curr != nil && curr.key < key { //marked nodes are walked through, values tell if they are gone
+			pred = curr
+			curr = curr.next[level].Load().node
+		}
+	}
+
+	for ; curr != nil && curr.key == key; curr = curr.next[0].Load().node {
+		if b := curr.value.Load(); b != nil {
+			return b.value, true
+		}
+	}
+	return nil, false
+}
+
+// Del removes key and returns the value it held.
+func (list *SkipList) Del(key float64) (value interface{}, ok bool) {
+	defer list.pin().Unpin()
+	preds := make([]*node, list.maxLevel)
+	succs := make([]*node, list.maxLevel)
+
+	if !list.find(key, preds, succs) {
+		return nil, false
+	}
+
+	n := succs[0]
+	for {
+		b := n.value.Load()
+		if b == nil {
+			return nil, false //another Del won
+		}
+		if n.value.CompareAndSwap(b, nil) { //linearization point of the removal
+			list.length.Add(-1)
+			mark(n)
+			list.find(key, preds, succs) //unlink it
+			list.retire(key, b.value)
+			return b.value, true
+		}
+	}
+}
+
+// Len returns the number of keys.
+func (list *SkipList) Len() int {
+	return int(list.length.Load())
+}
+
+// Range calls fn for every key in ascending order until fn returns false. It is weakly
+// consistent: keys changed while it runs may or may not be seen.
+func (list *SkipList) Range(fn func(key float64, value interface{}) bool) {
+	defer list.pin().Unpin()
+	for curr := list.head.next[0].Load().node; curr != nil; curr = curr.next[0].Load().node {
+		if b := curr.value.Load(); b != nil && !fn(curr.key, b.value) {
+			return
+		}
+	}
+}
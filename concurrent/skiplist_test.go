@@ -0,0 +1,157 @@
+package concurrent
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func checkSanity(list *SkipList, t *testing.T) {
+	for level := 0; level < list.maxLevel; level++ {
+		var prev *node
+		for curr := list.head.next[level].Load().node; curr != nil; curr = curr.next[level].Load().node {
+			if prev != nil && !(curr.key > prev.key) {
+				t.Fatalf("level %v is out of order: %v after %v", level, curr.key, prev.key)
+			}
+			if level >= len(curr.next) {
+				t.Fatalf("node %v is linked above its height on level %v", curr.key, level)
+			}
+			prev = curr
+		}
+	}
+
+	cnt := 0
+	list.Range(func(key float64, value interface{}) bool {
+		cnt++
+		return true
+	})
+	if cnt != list.Len() {
+		t.Fatalf("ranged over %v keys, Len is %v", cnt, list.Len())
+	}
+}
+
+func TestBasicCRUD(t *testing.T) {
+	list := New()
+	list.Set(10, 1)
+	list.Set(60, 2)
+	list.Set(30, 3)
+	list.Set(30, 9)
+
+	if v, ok := list.Get(30); !ok || v.(int) != 9 {
+		t.Fatal(`wrong "30" value (expected "9")`, v)
+	}
+	if v, ok := list.Del(10); !ok || v.(int) != 1 {
+		t.Fatal(`Del of "10" must return "1"`, v)
+	}
+	if _, ok := list.Del(10); ok {
+		t.Fatal(`second Del of "10" must fail`)
+	}
+	if _, ok := list.Get(10); ok {
+		t.Fatal(`found "10", which should have been deleted`)
+	}
+	if list.Len() != 2 {
+		t.Fatalf("wrong length %v (expected 2)", list.Len())
+	}
+	checkSanity(list, t)
+}
+
+// every goroutine owns its own keys, so the result must match a model of its own operations
+func TestConcurrentDisjoint(t *testing.T) {
+	list := New()
+	const workers = 8
+
+	models := make([]map[float64]int, workers)
+	wg := &sync.WaitGroup{}
+	for w := 0; w < workers; w++ {
+		models[w] = map[float64]int{}
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(w)))
+			model := models[w]
+			for i := 0; i < 20000; i++ {
+				key := float64(r.Intn(500)*workers + w)
+				switch r.Intn(3) {
+				case 0:
+					list.Set(key, i)
+					model[key] = i
+				case 1:
+					_, ok := list.Del(key)
+					if _, held := model[key]; ok != held {
+						t.Errorf("Del(%v) returned %v, model says %v", key, ok, held)
+						return
+					}
+					delete(model, key)
+				default:
+					v, ok := list.Get(key)
+					if expected, held := model[key]; ok != held || (ok && v.(int) != expected) {
+						t.Errorf("Get(%v) returned %v %v, model says %v %v", key, v, ok, expected, held)
+						return
+					}
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	total := 0
+	for _, model := range models {
+		total += len(model)
+		for key, expected := range model {
+			if v, ok := list.Get(key); !ok || v.(int) != expected {
+				t.Fatalf("key %v holds %v %v, expected %v", key, v, ok, expected)
+			}
+		}
+	}
+	if list.Len() != total {
+		t.Fatalf("wrong length %v (expected %v)", list.Len(), total)
+	}
+	checkSanity(list, t)
+}
+
+// goroutines fight over a handful of keys, the structure must stay consistent
+func TestConcurrentContended(t *testing.T) {
+	list := New()
+
+	wg := &sync.WaitGroup{}
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(w)))
+			for i := 0; i < 20000; i++ {
+				key := float64(r.Intn(16))
+				switch r.Intn(3) {
+				case 0:
+					list.Set(key, w)
+				case 1:
+					list.Del(key)
+				default:
+					if v, ok := list.Get(key); ok && (v.(int) < 0 || v.(int) >= 8) {
+						t.Errorf("Get(%v) returned a value nobody stored: %v", key, v)
+						return
+					}
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	checkSanity(list, t)
+}
+
+func BenchmarkParallelSetGet(b *testing.B) {
+	list := New()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		r := rand.New(rand.NewSource(rand.Int63()))
+		for pb.Next() {
+			key := float64(r.Intn(100000))
+			if r.Intn(10) == 0 {
+				list.Set(key, key)
+			} else {
+				list.Get(key)
+			}
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package jumplist
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ExportCSV writes a key,value header to w and then one record per pair in key order, encoded one at a
+// time under the read lock like ExportNDJSON. Keys are written in the shortest form that parses back to
+// the same float64, string values as they are, nil as an empty field and other values as JSON.
+func (list *SkipList) ExportCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"key", "value"}); err != nil {
+		return err
+	}
+	err := list.StreamSortedTo(w, func(_ io.Writer, key float64, value interface{}) error {
+		field, err := csvField(value)
+		if err != nil {
+			return err
+		}
+		return cw.Write([]string{strconv.FormatFloat(key, 'g', -1, 64), field})
+	})
+	if err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvField(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	}
+	data, err := json.Marshal(value)
+	return string(data), err
+}
+
+// ImportCSV sets the pairs of the records read from r, a key and a value field each as ExportCSV
+// writes them, after an optional key,value header. Records are read and set one at a time like
+// ImportNDJSON does. A CSV field does not tell a number from text, so values are stored as the strings
+// read: use NDJSON to keep their types.
+func (list *SkipList) ImportCSV(r io.Reader) error {
+	list.initZero()
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 2
+	cr.ReuseRecord = true
+	for first := true; ; first = false {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err //a csv.ParseError, which tells the line
+		}
+		if first && record[0] == "key" && record[1] == "value" {
+			continue
+		}
+		key, err := strconv.ParseFloat(record[0], 64)
+		if err == nil && key != key {
+			err = errNaNKey
+		}
+		if err != nil {
+			line, _ := cr.FieldPos(0)
+			return fmt.Errorf("jumplist: CSV line %d: %w", line, err)
+		}
+		list.Set(key, record[1])
+	}
+}
@@ -0,0 +1,49 @@
+package jumplist
+
+import (
+	"bytes"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCSV(t *testing.T) {
+	list := New()
+	list.Set(math.Inf(-1), "first")
+	list.Set(0.1, "a, \"quoted\"\nline")
+	list.Set(2, 42)
+	list.Set(3, nil)
+	list.Set(4, []int{1, 2})
+
+	var buf bytes.Buffer
+	if err := list.ExportCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	expected := "key,value\n-Inf,first\n0.1,\"a, \"\"quoted\"\"\nline\"\n2,42\n3,\n4,\"[1,2]\"\n"
+	if buf.String() != expected {
+		t.Fatalf("ExportCSV wrote %q, expected %q", buf.String(), expected)
+	}
+
+	loaded := New()
+	if err := loaded.ImportCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(loaded.Keys(), list.Keys()) {
+		t.Fatal("ImportCSV loaded", loaded.Keys(), "expected", list.Keys())
+	}
+	if loaded.Get(0.1).Value != "a, \"quoted\"\nline" || loaded.Get(2).Value != "42" || loaded.Get(3).Value != "" {
+		t.Fatal("values must come back as the strings written, got", loaded.Values())
+	}
+
+	if err := loaded.ImportCSV(strings.NewReader("5,five\n6,six\n")); err != nil || loaded.Get(6).Value != "six" {
+		t.Fatal("the header is optional, got", err)
+	}
+	err := loaded.ImportCSV(strings.NewReader("7,seven\nNaN,x\n8,eight\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") || !loaded.Contains(7) || loaded.Contains(8) {
+		t.Fatal("a bad key must stop the import at its line, keeping the records before, got", err)
+	}
+	if err := loaded.ImportCSV(strings.NewReader("9,a,b\n")); err == nil {
+		t.Fatal("a record must have two fields")
+	}
+}
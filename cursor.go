@@ -0,0 +1,232 @@
+package jumplist
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+var errCursor = errors.New("jumplist: malformed cursor")
+
+// Leading byte of an encoded Cursor.
+const (
+	cursorAt  byte = iota + 1 //followed by the key and the number of equal keys before the column
+	cursorEnd                 //ran past the end
+)
+
+// Cursor walks forward over a list, remembering the predecessor of its position on every level
+// so that moving ahead only walks the distance covered instead of descending from the top again.
+//
+// Cursors are weakly consistent: the list may change between two moves, and the next move notices
+// and finds its place again by key in O(log n). A cursor never fails, never returns a column twice and
+// returns every column that stayed in the list the whole time, in key order; columns inserted or removed
+// meanwhile may or may not be seen. In a multiset, a cursor whose column was removed resumes after its
+// run of equal keys.
+type Cursor struct {
+	list    *SkipList
+	fingers []*Column //last column before the position on each level, nil for the start pointers
+	current *Column
+	key     float64 //of current, which may be recycled once removed from a pooled list
+	version uint64  //of the list when the fingers were taken
+}
+
+// Cursor returns a cursor positioned at the first column whose key is not less than startKey.
+func (list *SkipList) Cursor(startKey float64) *Cursor {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	cursor := &Cursor{list: list, fingers: make([]*Column, list.maxLevel), version: list.version}
+	cursor.seekTo(startKey)
+	return cursor
+}
+
+// Reset moves the cursor to the first column whose key is not less than startKey and returns it, like
+// a new Cursor but reusing the memory of this one, so a loop of scans allocates nothing.
+func (cursor *Cursor) Reset(startKey float64) *Column {
+	list := cursor.list
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	cursor.version = list.version
+	if len(cursor.fingers) == list.maxLevel {
+		clear(cursor.fingers)
+	} else {
+		cursor.fingers = make([]*Column, list.maxLevel)
+	}
+	cursor.seekTo(startKey)
+	return cursor.current
+}
+
+// resync finds the place of the cursor again after the list changed, and reports whether its column
+// is still in the list. If it is not, the cursor moves on to the column that followed it.
+func (cursor *Cursor) resync() bool {
+	list := cursor.list
+	cursor.version = list.version
+	if len(cursor.fingers) == list.maxLevel {
+		clear(cursor.fingers)
+	} else {
+		cursor.fingers = make([]*Column, list.maxLevel) //grown, or shrunk by Clear
+	}
+
+	column, key := cursor.current, cursor.key
+	cursor.seekTo(key)
+	for cursor.current != nil && cursor.current.key == key && cursor.current != column {
+		cursor.step() //an equal key in a multiset
+	}
+	return cursor.current != nil && cursor.current == column
+}
+
+func (cursor *Cursor) nextOf(column *Column, level int) *Column {
+	if column == nil {
+		return cursor.list.startPointers.next[level]
+	}
+	return column.next[level]
+}
+
+// seekTo moves forward to the first column with a key not less than key. It climbs from level 0
+// while the next column on the level is still short of key, then descends along the fingers.
+func (cursor *Cursor) seekTo(key float64) {
+	top := 0
+	for top < len(cursor.fingers)-1 {
+		next := cursor.nextOf(cursor.fingers[top], top)
+		if next == nil || !cursor.list.keyLess(next.key, key) {
+			break
+		}
+		top++
+	}
+
+	var at *Column
+	for i := top; i >= 0; i-- {
+		if finger := cursor.fingers[i]; finger != nil && (at == nil || cursor.list.keyLess(at.key, finger.key)) {
+			at = finger //the finger on this level is already further right
+		}
+		next := cursor.nextOf(at, i)
+		for next != nil && cursor.list.keyLess(next.key, key) {
+			at = next
+			next = next.next[i]
+		}
+		cursor.fingers[i] = at
+	}
+
+	cursor.current = cursor.nextOf(at, 0)
+	if cursor.current != nil {
+		cursor.key = cursor.current.key
+	}
+}
+
+// step moves over the current column, which becomes the predecessor on its levels.
+func (cursor *Cursor) step() {
+	for i := range cursor.current.next {
+		cursor.fingers[i] = cursor.current
+	}
+	cursor.current = cursor.current.next[0]
+	if cursor.current != nil {
+		cursor.key = cursor.current.key
+	}
+}
+
+// Column returns the column at the cursor, nil once it ran past the end.
+func (cursor *Cursor) Column() *Column {
+	return cursor.current
+}
+
+// Next moves to the following column and returns it.
+func (cursor *Cursor) Next() *Column {
+	cursor.list.mutex.RLock()
+	defer cursor.list.mutex.RUnlock()
+
+	if cursor.current == nil {
+		return nil
+	}
+	if cursor.version != cursor.list.version && !cursor.resync() {
+		return cursor.current //already moved past the removed column
+	}
+	cursor.step()
+	return cursor.current
+}
+
+// Seek moves forward to the first column whose key is not less than the current key plus delta
+// and returns it. A cursor never moves backwards, so delta must not be negative.
+func (cursor *Cursor) Seek(delta float64) *Column {
+	cursor.list.mutex.RLock()
+	defer cursor.list.mutex.RUnlock()
+
+	if delta < 0 {
+		panic("delta must not be negative")
+	}
+	if cursor.list.less != nil {
+		panic("Seek adds delta to the key, which needs the numeric order of keys")
+	}
+	if cursor.current == nil {
+		return nil
+	}
+	key := cursor.key + delta
+	if cursor.version != cursor.list.version {
+		cursor.resync()
+	}
+	cursor.seekTo(key)
+	return cursor.current
+}
+
+// MarshalBinary encodes the position of the cursor, so a long export can persist it and go on from
+// there after a restart with UnmarshalBinary on the reloaded list instead of starting over. The
+// position is the key of the current column and, in a multiset, how many equal keys come before it.
+func (cursor *Cursor) MarshalBinary() ([]byte, error) {
+	cursor.list.mutex.RLock()
+	defer cursor.list.mutex.RUnlock()
+
+	if cursor.current != nil && cursor.version != cursor.list.version {
+		cursor.resync()
+	}
+	if cursor.current == nil {
+		return []byte{cursorEnd}, nil
+	}
+	ties := uint64(0)
+	for column := cursor.current.prev; column != nil && column.key == cursor.key; column = column.prev {
+		ties++
+	}
+	data := make([]byte, 9, 9+binary.MaxVarintLen64)
+	data[0] = cursorAt
+	binary.BigEndian.PutUint64(data[1:], math.Float64bits(cursor.key))
+	return binary.AppendUvarint(data, ties), nil
+}
+
+// UnmarshalBinary moves the cursor to a position encoded by MarshalBinary, which may come from another
+// list such as the one a checkpoint was taken of, in O(log n). The cursor must have been returned by
+// the Cursor method of the list to resume on. If the column at the position is gone, it resumes at the
+// first column that follows it.
+func (cursor *Cursor) UnmarshalBinary(data []byte) error {
+	if cursor.list == nil {
+		return errors.New("jumplist: cursor has no list, take one with SkipList.Cursor")
+	}
+	var key float64
+	var ties uint64
+	switch {
+	case len(data) == 1 && data[0] == cursorEnd:
+	case len(data) > 9 && data[0] == cursorAt:
+		key = math.Float64frombits(binary.BigEndian.Uint64(data[1:]))
+		n := 0
+		ties, n = binary.Uvarint(data[9:])
+		if key != key || n <= 0 || 9+n != len(data) {
+			return errCursor
+		}
+	default:
+		return errCursor
+	}
+
+	list := cursor.list
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	cursor.version = list.version
+	cursor.fingers = make([]*Column, list.maxLevel)
+	cursor.current = nil
+	if data[0] == cursorEnd {
+		return nil
+	}
+	cursor.seekTo(key)
+	for ; ties > 0 && cursor.current != nil && cursor.current.key == key; ties-- {
+		cursor.step()
+	}
+	return nil
+}
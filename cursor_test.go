@@ -0,0 +1,167 @@
+package jumplist
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestCursor(t *testing.T) {
+	list := New()
+	for i := 0; i < 10000; i++ {
+		list.Set(float64(i*2), i)
+	}
+
+	//walking with Next matches the keys of a fresh scan over the region
+	cursor := list.Cursor(1001)
+	for expected := 1002.0; expected <= 3000; expected += 2 {
+		if c := cursor.Column(); c == nil || c.key != expected {
+			t.Fatalf("cursor is at %v, expected %v", c, expected)
+		}
+		cursor.Next()
+	}
+
+	//sliding windows with Seek land where a top-down seek does
+	cursor = list.Cursor(0)
+	for cursor.Column() != nil {
+		from := cursor.Column().key
+		c := cursor.Seek(37)
+		if expected := list.seek(from + 37); c != expected {
+			t.Fatalf("Seek from %v landed on %v, expected %v", from, c, expected)
+		}
+	}
+	if cursor.Next() != nil || cursor.Seek(1) != nil {
+		t.Fatal("cursor past the end must stay there")
+	}
+
+	if list.Cursor(100000).Column() != nil {
+		t.Fatal("cursor after the last key must start at the end")
+	}
+
+	//Reset starts over like a new cursor, also after the list changed
+	list.Del(500)
+	if c := cursor.Reset(499); c == nil || c.key != 502 || cursor.Next().key != 504 {
+		t.Fatal("Reset(499) must land on 502, got", c)
+	}
+}
+
+func BenchmarkCursorSlidingWindow(b *testing.B) {
+	b.ReportAllocs()
+	cursor := benchList.Cursor(0)
+	for i := 0; i < b.N; i++ {
+		if cursor.Seek(10) == nil {
+			cursor.Reset(0)
+		}
+	}
+}
+
+func BenchmarkSeekSlidingWindow(b *testing.B) {
+	b.ReportAllocs()
+	key := 0.0
+	for i := 0; i < b.N; i++ {
+		benchList.mutex.RLock()
+		c := benchList.seek(key + 10)
+		benchList.mutex.RUnlock()
+		if key = c.key; c.next[0] == nil {
+			key = 0
+		}
+	}
+}
+
+func TestCursorConcurrentModification(t *testing.T) {
+	list := New(WithPooling())
+	for i := 0; i < 1000; i++ {
+		list.Set(float64(i), i)
+	}
+
+	cursor := list.Cursor(0)
+	seen, removed := map[float64]bool{}, map[float64]bool{}
+	for c := cursor.Column(); c != nil; c = cursor.Next() {
+		key := c.Key()
+		if seen[key] {
+			t.Fatalf("key %v returned twice", key)
+		}
+		seen[key] = true
+		if key != math.Trunc(key) {
+			continue
+		}
+
+		list.Del(key) //the column under the cursor
+		list.Del(key + 3)
+		removed[key+3] = true
+		list.Set(key+0.5, nil) //new keys ahead may or may not be seen
+		if int(key)%100 == 0 {
+			for i := 0; i < 50; i++ {
+				list.Set(float64(2000+i), nil) //grows the list by levels
+			}
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		if !removed[float64(i)] && !seen[float64(i)] {
+			t.Fatalf("surviving key %v was skipped", i)
+		}
+	}
+
+	multi := New(AllowDuplicates())
+	for i := 0; i < 4; i++ {
+		multi.Set(1, i)
+	}
+	multi.Set(2, nil)
+	cursor = multi.Cursor(1)
+	cursor.Next()
+	multi.Set(0, nil) //an unrelated change
+	if c := cursor.Next(); c == nil || c.Key() != 1 || c.Value != 2 {
+		t.Fatal("a multiset cursor must keep its place among equal keys", c)
+	}
+	multi.Del(1) //removes the first, not the current one
+	if c := cursor.Next(); c == nil || c.Value != 3 {
+		t.Fatal("wrong column after a removal", c)
+	}
+
+	list.Clear()
+	if cursor := list.Cursor(0); cursor.Next() != nil {
+		t.Fatal("an empty list has nothing to walk")
+	}
+}
+
+func TestCursorMarshalBinary(t *testing.T) {
+	list := New(AllowDuplicates())
+	for i := 0; i < 3000; i++ {
+		list.Set(float64(i%700), i)
+	}
+
+	exported := []KV{}
+	cursor := list.Cursor(math.Inf(-1))
+	for c := cursor.Column(); c != nil && len(exported) < 1234; c = cursor.Next() {
+		exported = append(exported, KV{c.Key(), c.Value})
+	}
+	data, err := cursor.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded := New(AllowDuplicates()) //after a restart
+	reloaded.Load(list.Dump())
+	resumed := reloaded.Cursor(math.Inf(-1))
+	if err := resumed.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	for c := resumed.Column(); c != nil; c = resumed.Next() {
+		exported = append(exported, KV{c.Key(), c.Value})
+	}
+	if !slices.Equal(exported, list.Items()) {
+		t.Fatal("the resumed export must hold every pair once, in order")
+	}
+
+	if data, _ := resumed.MarshalBinary(); resumed.UnmarshalBinary(data) != nil || resumed.Column() != nil {
+		t.Fatal("a cursor past the end must resume past the end")
+	}
+	for _, bad := range [][]byte{nil, {cursorAt, 1, 2}, append(data, 0), {cursorAt, 0x7f, 0xf8, 0, 0, 0, 0, 0, 1, 0}} {
+		if resumed.UnmarshalBinary(bad) == nil {
+			t.Errorf("UnmarshalBinary(%v) must fail", bad)
+		}
+	}
+	if (&Cursor{}).UnmarshalBinary(data) == nil {
+		t.Error("a cursor without a list cannot resume")
+	}
+}
@@ -0,0 +1,101 @@
+// Package delayqueue provides a queue whose values become available at a deadline, kept in a
+// jumplist.SkipList keyed by the deadline in Unix nanoseconds. A goroutine sleeps until the deadline
+// of the list's Min and hands the value to the next Poll. Deadlines closer than a few hundred
+// nanoseconds may fire in either order, as float64 keys round them at today's Unix times.
+package delayqueue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/abbychau/jumplist"
+)
+
+// ErrClosed is returned by Poll once the queue is closed.
+var ErrClosed = errors.New("delayqueue: closed")
+
+// Queue is safe for concurrent use.
+type Queue struct {
+	list      *jumplist.SkipList
+	wake      chan struct{} //an Offer may have moved the next deadline
+	out       chan interface{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// New returns an empty queue and starts its goroutine, which runs until Close.
+func New() *Queue {
+	q := &Queue{
+		list: jumplist.New(jumplist.AllowDuplicates()), //equal deadlines fire in offer order
+		wake: make(chan struct{}, 1),
+		out:  make(chan interface{}),
+		done: make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+// Offer queues value to be polled once fireAt has passed. A deadline in the past fires right away.
+func (q *Queue) Offer(value interface{}, fireAt time.Time) {
+	q.list.Set(float64(fireAt.UnixNano()), value)
+	select {
+	case q.wake <- struct{}{}:
+	default: //a wake up is already pending
+	}
+}
+
+// Poll returns the value with the earliest passed deadline, blocking until there is one or ctx is done.
+func (q *Queue) Poll(ctx context.Context) (interface{}, error) {
+	select {
+	case value := <-q.out:
+		return value, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-q.done:
+		return nil, ErrClosed
+	}
+}
+
+// Len returns the number of values not polled yet.
+func (q *Queue) Len() int {
+	return q.list.Len()
+}
+
+// Close stops the goroutine. Values not polled yet stay counted by Len but are never handed out.
+func (q *Queue) Close() {
+	q.closeOnce.Do(func() { close(q.done) })
+}
+
+func (q *Queue) run() {
+	timer := time.NewTimer(time.Hour)
+	timer.Stop() //since Go 1.23 Stop and Reset leave no stale fire in the channel
+
+	for {
+		var fire <-chan time.Time
+		if min := q.list.Min(); min != nil {
+			wait := time.Until(time.Unix(0, int64(min.Key())))
+			if wait <= 0 {
+				column := q.list.PopMin() //only this goroutine pops, so it is min or an even earlier one
+				select {
+				case q.out <- column.Value:
+					continue
+				case <-q.done:
+					q.list.Set(column.Key(), column.Value) //keep it counted
+					return
+				}
+			}
+			timer.Reset(wait)
+			fire = timer.C
+		}
+
+		select {
+		case <-fire:
+		case <-q.wake:
+			timer.Stop()
+		case <-q.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package delayqueue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestQueue(t *testing.T) {
+	q := New()
+	defer q.Close()
+
+	now := time.Now()
+	q.Offer("third", now.Add(60*time.Millisecond))
+	q.Offer("first", now.Add(20*time.Millisecond))
+	q.Offer("late", now.Add(time.Hour))
+	q.Offer("past", now.Add(-time.Second))
+	q.Offer("second", now.Add(40*time.Millisecond))
+
+	for _, expected := range []string{"past", "first", "second", "third"} {
+		value, err := q.Poll(context.Background())
+		if err != nil || value != expected {
+			t.Fatalf("polled %v %v, expected %v", value, err, expected)
+		}
+		if expected != "past" && time.Now().Before(now.Add(20*time.Millisecond)) {
+			t.Fatalf("%v fired before its deadline", expected)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if value, err := q.Poll(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("nothing is due before the context ends", value, err)
+	}
+	if q.Len() != 1 {
+		t.Fatalf("queue holds %v values, expected 1", q.Len())
+	}
+
+	//an earlier offer wakes the goroutine sleeping until the hour long deadline
+	q.Offer("sooner", time.Now().Add(10*time.Millisecond))
+	if value, err := q.Poll(context.Background()); err != nil || value != "sooner" {
+		t.Fatal("an earlier deadline must fire first", value, err)
+	}
+
+	q.Close()
+	q.Close()
+	if _, err := q.Poll(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Fatal("polling a closed queue must fail", err)
+	}
+	if q.Len() != 1 {
+		t.Fatalf("closing must keep the values not polled, %v left", q.Len())
+	}
+}
@@ -0,0 +1,58 @@
+package jumplist
+
+import "reflect"
+
+// Equal reports whether a and b hold the same keys in the same order with equal values, whatever their
+// levels, in one walk of both level 0 chains under their read locks. valueEq compares two values, nil
+// using reflect.DeepEqual. In multisets equal keys must come with equal values in the same order.
+func Equal(a, b *SkipList, valueEq func(x, y interface{}) bool) bool {
+	if valueEq == nil {
+		valueEq = reflect.DeepEqual
+	}
+	unlock := readLockBoth(a, b)
+	defer unlock()
+
+	if a.length != b.length {
+		return false
+	}
+	for x, y := a.startPointers.next[0], b.startPointers.next[0]; x != nil; x, y = x.next[0], y.next[0] {
+		if x.key != y.key || !valueEq(x.Value, y.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Diff returns the changes that turn the contents of a into those of b, in key order and numbered from
+// 1, found in one merged walk of both level 0 chains under their read locks: an EventInsert with the
+// value of b for a key only b holds, an EventDelete with the value of a for a key only a holds and an
+// EventUpdate with the value of b for a key both hold with values that differ by reflect.DeepEqual.
+// Without duplicates, applying them to a copy of a with Set and Del, as a follower applies the records
+// of Changes, makes it equal to b. In multisets equal columns pair up one to one in order, the extra
+// ones of either list being inserted or deleted. Equal lists have no changes.
+func Diff(a, b *SkipList) []ChangeRecord {
+	unlock := readLockBoth(a, b)
+	defer unlock()
+
+	changes := []ChangeRecord{}
+	record := func(kind EventKind, key float64, value interface{}) {
+		changes = append(changes, ChangeRecord{uint64(len(changes) + 1), Event{kind, key, value}})
+	}
+	x, y := a.startPointers.next[0], b.startPointers.next[0]
+	for x != nil || y != nil {
+		switch {
+		case y == nil || x != nil && a.keyLess(x.key, y.key):
+			record(EventDelete, x.key, x.Value)
+			x = x.next[0]
+		case x == nil || a.keyLess(y.key, x.key):
+			record(EventInsert, y.key, y.Value)
+			y = y.next[0]
+		default:
+			if !reflect.DeepEqual(x.Value, y.Value) {
+				record(EventUpdate, y.key, y.Value)
+			}
+			x, y = x.next[0], y.next[0]
+		}
+	}
+	return changes
+}
@@ -0,0 +1,88 @@
+package jumplist
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	keys, values := []float64{1, 2, 3}, []interface{}{[]byte("a"), []byte("b"), []byte("c")}
+	a := NewFromSorted(keys, values)
+	b := New()
+	for i := len(keys) - 1; i >= 0; i-- {
+		b.Set(keys[i], []byte(string(values[i].([]byte)))) //other levels and equal, not identical, values
+	}
+	if !Equal(a, b, nil) || !Equal(a, a, nil) {
+		t.Fatal("lists with the same contents must be equal whatever their levels")
+	}
+	if Equal(a, b, func(x, y interface{}) bool { return false }) {
+		t.Fatal("valueEq must decide on the values")
+	}
+	b.Set(4, nil)
+	if Equal(a, b, nil) {
+		t.Fatal("lists of different lengths are not equal")
+	}
+	b.Del(4)
+	b.Set(2, []byte("x"))
+	if Equal(a, b, nil) {
+		t.Fatal("lists with a different value are not equal")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	a, b := New(), New()
+	for i := 0; i < 2000; i++ {
+		key := float64(r.Intn(500))
+		switch r.Intn(3) {
+		case 0:
+			a.Set(key, r.Intn(3))
+		case 1:
+			b.Set(key, r.Intn(3))
+		default:
+			a.Set(key, i)
+			b.Set(key, i)
+		}
+	}
+
+	changes := Diff(a, b)
+	replica := a.Clone()
+	for i, c := range changes {
+		if c.Seq != uint64(i+1) || i > 0 && c.Key <= changes[i-1].Key {
+			t.Fatalf("change %v is %+v, changes must be numbered in key order", i, c)
+		}
+		switch c.Kind {
+		case EventInsert:
+			if a.Contains(c.Key) || !b.Contains(c.Key) {
+				t.Fatalf("%v is inserted but a holds it or b does not", c.Key)
+			}
+			replica.Set(c.Key, c.Value)
+		case EventUpdate:
+			if a.Get(c.Key).Value == b.Get(c.Key).Value {
+				t.Fatalf("%v is updated to the value it has", c.Key)
+			}
+			replica.Set(c.Key, c.Value)
+		case EventDelete:
+			if b.Contains(c.Key) {
+				t.Fatalf("%v is deleted but b holds it", c.Key)
+			}
+			replica.Del(c.Key)
+		}
+	}
+	if !Equal(replica, b, nil) {
+		t.Fatal("applying the diff must turn a into b")
+	}
+	if len(Diff(replica, b)) != 0 || len(Diff(a, a)) != 0 {
+		t.Fatal("equal lists have no diff")
+	}
+
+	x, y := New(AllowDuplicates()), New(AllowDuplicates())
+	x.Set(1, "a")
+	x.Set(1, "b")
+	y.Set(1, "a")
+	y.Set(1, "c")
+	y.Set(1, "d")
+	if d := Diff(x, y); len(d) != 2 || d[0].Kind != EventUpdate || d[0].Value != "c" || d[1].Kind != EventInsert || d[1].Value != "d" {
+		t.Fatal("equal keys of multisets must pair up in order, got", d)
+	}
+}
@@ -0,0 +1,30 @@
+package jumplist
+
+import "math"
+
+// WithEpsilon makes the methods taking a key to look up or store, such as Set, Put, Append, SetBatch,
+// Upsert, GetOrSet, Get, Contains and Del, treat a key within eps of a stored key as that key, for keys
+// carrying floating point noise such as sensor timestamps. Setting a near key overwrites the stored
+// one, which keeps its exact key, so the columns stay apart by more than eps and the order stays
+// total. If several stored keys are within eps the smallest wins. Navigation and range queries such as
+// Floor and Count compare keys exactly. Each of these calls then costs one more descent, and Append
+// descends like Set instead of keeping the tails.
+func WithEpsilon(eps float64) Option {
+	if !(eps >= 0) || math.IsInf(eps, 0) {
+		panic("epsilon must be a finite non-negative number")
+	}
+	return func(list *SkipList) {
+		list.epsilon = eps
+	}
+}
+
+// snap returns the smallest stored key within epsilon of key, or key itself if there is none.
+func (list *SkipList) snap(key float64) float64 {
+	if list.epsilon == 0 {
+		return key
+	}
+	if column := list.seek(key - list.epsilon); column != nil && column.key <= key+list.epsilon {
+		return column.key
+	}
+	return key
+}
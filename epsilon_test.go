@@ -0,0 +1,122 @@
+package jumplist
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWithEpsilon(t *testing.T) {
+	list := New(WithEpsilon(1e-6))
+	list.Set(1, "a")
+	list.Set(1+4e-7, "b") //noise, overwrites 1
+	list.Set(2, "c")
+
+	if list.Len() != 2 {
+		t.Fatal("near keys must share a column, the list holds", list.Len())
+	}
+	if column := list.Get(1 - 9e-7); column == nil || column.Key() != 1 || column.Value != "b" {
+		t.Fatal("Get of a near key must find the stored one, got", column)
+	}
+	if !list.Contains(2+1e-7) || list.Contains(2+2e-6) {
+		t.Fatal("Contains must accept keys within epsilon and only those")
+	}
+	if _, prev, updated := list.Put(2-1e-7, "d"); !updated || prev != "c" {
+		t.Fatal("Put of a near key must update the stored one")
+	}
+
+	list.SetBatch([]KV{{3, "e"}, {3 + 5e-7, "f"}, {1 + 1e-7, "g"}})
+	if list.Len() != 3 || list.Get(3).Value != "f" || list.Get(1).Value != "g" {
+		t.Fatal("SetBatch must snap near keys like Set")
+	}
+
+	if list.Del(3+8e-7) == nil || list.Contains(3) {
+		t.Fatal("Del of a near key must remove the stored one")
+	}
+	if list.Floor(1+5e-7).Key() != 1 || list.Ceiling(1+5e-7).Key() != 2 {
+		t.Fatal("navigation must compare keys exactly")
+	}
+
+	near := New(WithEpsilon(0.01))
+	near.Set(1, "a")
+	near.Append(1.005, "b")
+	if column, inserted := near.GetOrSet(1.006, "c"); inserted || column.Key() != 1 {
+		t.Fatal("GetOrSet of a near key must find the stored one")
+	}
+	near.Upsert(0.995, func(old interface{}, exists bool) interface{} { return old.(string) + "d" })
+	near.Accumulate(1.001, "e", func(current, delta interface{}) interface{} { return current.(string) + delta.(string) })
+	if near.ReplaceOrInsert(0.999, "f") == nil || !near.CompareAndSwap(1.002, "f", "g") {
+		t.Fatal("ReplaceOrInsert and CompareAndSwap of a near key must find the stored one")
+	}
+	if keys := near.Keys(); len(keys) != 1 || near.Get(1).Value != "g" {
+		t.Fatal("Append, GetOrSet, Upsert and the like must snap near keys, keys are", keys)
+	}
+	near.Set(5, "h")
+	if near.UpdateKey(1.003, 5.004) == nil || near.Len() != 1 || near.Get(5).Value != "g" {
+		t.Fatal("UpdateKey must snap both keys, keys are", near.Keys())
+	}
+	if _, err := near.Move(4.999, 5.002, false); err != nil || near.Len() != 1 {
+		t.Fatal("moving a key within epsilon of itself is a no-op, got", err, near.Keys())
+	}
+	if values := near.GetMulti([]float64{4.998, 7}); len(values) != 1 || values[4.998] != "g" {
+		t.Fatal("GetMulti must snap near keys, got", values)
+	}
+	near.Update(func(tx *Txn) error {
+		tx.Set(5.001, "i")
+		if value, _ := tx.Get(5.003); value != "i" {
+			t.Error("a transaction must snap near keys, got", value)
+		}
+		return nil
+	})
+	if near.Len() != 1 || near.RemoveMulti([]float64{5.002}) != 1 {
+		t.Fatal("RemoveMulti must snap near keys, keys are", near.Keys())
+	}
+	checkSanity(near, t)
+
+	inf := New(WithEpsilon(0.5))
+	inf.Set(math.Inf(1), 1)
+	inf.Set(math.Inf(-1), 2)
+	if inf.Get(math.Inf(1)).Value != 1 || inf.Get(math.Inf(-1)).Value != 2 || inf.Get(1e300) != nil {
+		t.Fatal("infinite keys must only match themselves")
+	}
+	checkSanity(list, t)
+
+	for _, eps := range []float64{-1, math.NaN(), math.Inf(1)} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("epsilon %v must panic", eps)
+				}
+			}()
+			WithEpsilon(eps)
+		}()
+	}
+}
+
+func TestWithEpsilonMapAndFrozen(t *testing.T) {
+	m := NewMap(WithEpsilon(0.01))
+	m.Store(1, "a")
+	if actual, loaded := m.LoadOrStore(1.005, "b"); !loaded || actual != "a" {
+		t.Fatal("LoadOrStore of a near key must load the stored one, got", actual, loaded)
+	}
+	if m.CompareAndDelete(0.995, "x") || !m.CompareAndDelete(0.996, "a") {
+		t.Fatal("CompareAndDelete of a near key must compare the stored value")
+	}
+	m.Store(2, "c")
+	if value, loaded := m.LoadAndDelete(2.004); !loaded || value != "c" {
+		t.Fatal("LoadAndDelete of a near key must remove the stored one, got", value, loaded)
+	}
+	if _, ok := m.Load(2); ok {
+		t.Fatal("the near keys must not have stored columns of their own")
+	}
+
+	list := New(WithEpsilon(0.01))
+	list.Set(1, "a")
+	list.Set(2, "b")
+	frozen := list.Freeze()
+	if column := frozen.Get(1.006); column == nil || column.Value != "a" || !frozen.Contains(1.994) {
+		t.Fatal("a frozen list must keep snapping near keys, got", column)
+	}
+	if left, _ := New(WithEpsilon(0.01)).Split(0); left.epsilon != 0.01 {
+		t.Fatal("Split must keep WithEpsilon")
+	}
+}
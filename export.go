@@ -0,0 +1,106 @@
+package jumplist
+
+import "io"
+
+// ExportColumns returns all keys and values as two parallel slices in ascending key order,
+// filled by a single walk under the read lock.
+func (list *SkipList) ExportColumns() (keys []float64, values []interface{}) {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	for column := list.startPointers.next[0]; column != nil; column = column.next[0] {
+		keys = append(keys, column.key)
+		values = append(values, column.Value)
+	}
+	return keys, values
+}
+
+// Keys returns every key in ascending order, copied under the read lock.
+func (list *SkipList) Keys() []float64 {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	keys := make([]float64, 0, list.length)
+	for column := list.startPointers.next[0]; column != nil; column = column.next[0] {
+		keys = append(keys, column.key)
+	}
+	return keys
+}
+
+// Values returns every value in ascending key order, copied under the read lock.
+func (list *SkipList) Values() []interface{} {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	values := make([]interface{}, 0, list.length)
+	for column := list.startPointers.next[0]; column != nil; column = column.next[0] {
+		values = append(values, column.Value)
+	}
+	return values
+}
+
+// Items returns every key and value pair in ascending key order, copied under the read lock.
+func (list *SkipList) Items() []KV {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.items()
+}
+
+// StreamSortedTo walks level 0 once under the read lock and calls encode for every pair in key order.
+// The first error returned by encode stops the walk and is returned.
+func (list *SkipList) StreamSortedTo(w io.Writer, encode func(io.Writer, float64, interface{}) error) error {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	for column := list.startPointers.next[0]; column != nil; column = column.next[0] {
+		if err := encode(w, column.key, column.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Dump returns every pair in ascending key order like Items, the form Load takes back.
+func (list *SkipList) Dump() []KV {
+	return list.Items()
+}
+
+// Load replaces the contents of the list with items, which must be in ascending key order like the
+// output of Dump. It builds the levels bottom up in O(n) the way NewFromSorted does, keeping the last
+// value of repeated keys unless the list allows duplicates. Bounded, LRU, sampled, hooked and watched
+// lists insert the items one by one instead, so their bookkeeping and notifications stay right.
+func (list *SkipList) Load(items []KV) {
+	for i, item := range items {
+		checkKey(item.Key) //before the list is cleared
+		if i > 0 && list.keyLess(item.Key, items[i-1].Key) {
+			panic("items must be sorted in ascending order")
+		}
+	}
+
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	list.clear()
+	if list.capacity > 0 || list.lru != nil || list.sampleCap > 0 || list.hooked() || len(list.watchers) > 0 {
+		for _, item := range items {
+			list.set(item.Key, item.Value)
+		}
+		return
+	}
+
+	list.reserve(len(items))
+	levels := make(evenLevels, list.maxLevel)
+	t := list.newTails()
+	for _, item := range items {
+		if t.last != nil && item.Key == t.last.key && !list.duplicates {
+			old := t.last.Value
+			t.last.Value = item.Value
+			list.track(EventUpdate, t.last, old)
+			continue
+		}
+		list.appendColumn(t, levels.next(), item.Key, item.Value)
+	}
+	list.reweighAll()
+	list.resize(0)
+}
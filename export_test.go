@@ -0,0 +1,148 @@
+package jumplist
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestExportColumns(t *testing.T) {
+	list := New()
+	if keys, values := list.ExportColumns(); len(keys) != 0 || len(values) != 0 {
+		t.Fatal("empty list must export empty columns")
+	}
+
+	for _, k := range []float64{50, 10, 40, 20, 30, 10} {
+		list.Set(k, int(k)*2)
+	}
+
+	keys, values := list.ExportColumns()
+	if len(keys) != list.Len() || len(values) != list.Len() {
+		t.Fatalf("expected %v rows, got %v keys and %v values", list.Len(), len(keys), len(values))
+	}
+	for i := range keys {
+		if i > 0 && keys[i] <= keys[i-1] {
+			t.Fatal("keys must be ascending", keys)
+		}
+		if values[i].(int) != int(keys[i])*2 {
+			t.Fatalf("value %v is not aligned with key %v", values[i], keys[i])
+		}
+	}
+}
+
+func TestKeysValuesItems(t *testing.T) {
+	list := New()
+	if len(list.Keys()) != 0 || len(list.Values()) != 0 || len(list.Items()) != 0 {
+		t.Fatal("empty list must export empty slices")
+	}
+
+	for _, k := range []float64{5, 1, 4, 2, 3} {
+		list.Set(k, int(k)*10)
+	}
+	keys, values, items := list.Keys(), list.Values(), list.Items()
+	if len(keys) != 5 || len(values) != 5 || len(items) != 5 {
+		t.Fatalf("exported %v keys, %v values and %v items, expected 5", len(keys), len(values), len(items))
+	}
+	for i := range keys {
+		if keys[i] != float64(i+1) || values[i].(int) != (i+1)*10 || items[i] != (KV{keys[i], values[i]}) {
+			t.Fatalf("row %v is %v, %v, %v", i, keys[i], values[i], items[i])
+		}
+	}
+
+	keys[0] = 100
+	if list.Front().Key() != 1 {
+		t.Fatal("exported keys must be a copy")
+	}
+}
+
+func TestStreamSortedTo(t *testing.T) {
+	list := New()
+	for _, k := range []float64{3, 1, 4, 1.5, 9, 2.6} {
+		list.Set(k, int64(k*10))
+	}
+
+	buf := &bytes.Buffer{}
+	err := list.StreamSortedTo(buf, func(w io.Writer, key float64, value interface{}) error {
+		return binary.Write(w, binary.BigEndian, [2]float64{key, float64(value.(int64))})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys, values := list.ExportColumns()
+	for i := range keys {
+		var pair [2]float64
+		if err := binary.Read(buf, binary.BigEndian, &pair); err != nil {
+			t.Fatal(err)
+		}
+		if pair[0] != keys[i] || int64(pair[1]) != values[i].(int64) {
+			t.Fatalf("pair %v decoded as %v, expected %v=%v", i, pair, keys[i], values[i])
+		}
+	}
+	if buf.Len() != 0 {
+		t.Fatal("stream holds more pairs than the list")
+	}
+
+	failure := errors.New("disk full")
+	calls := 0
+	err = list.StreamSortedTo(io.Discard, func(w io.Writer, key float64, value interface{}) error {
+		if calls++; calls == 3 {
+			return failure
+		}
+		return nil
+	})
+	if err != failure || calls != 3 {
+		t.Fatalf("encode error must stop the stream, got %v after %v calls", err, calls)
+	}
+}
+
+func TestDumpLoad(t *testing.T) {
+	list := New()
+	for i := 0; i < 10000; i++ {
+		list.Set(float64(i), i)
+	}
+	items := list.Dump()
+
+	copied := New()
+	copied.Set(-5, "dropped by Load")
+	copied.Load(items)
+	checkSanity(copied, t)
+	if copied.Len() != 10000 || copied.Get(-5) != nil || copied.Get(1234).Value != 1234 {
+		t.Fatal("Load must replace the contents with the dump", copied.Len())
+	}
+	again := copied.Dump()
+	for i := range items {
+		if again[i] != items[i] {
+			t.Fatalf("round trip changed item %v: %v, expected %v", i, again[i], items[i])
+		}
+	}
+
+	copied.Load([]KV{{1, "a"}, {1, "b"}, {2, nil}})
+	if copied.Len() != 2 || copied.Get(1).Value != "b" {
+		t.Fatal("repeated keys must keep the last value", copied.Len())
+	}
+	multi := New(AllowDuplicates())
+	multi.Load([]KV{{1, "a"}, {1, "b"}})
+	if multi.Len() != 2 {
+		t.Fatal("a multiset must keep repeated keys", multi.Len())
+	}
+
+	bounded := NewLRU(18, 3)
+	bounded.Load(items[:10])
+	checkSanity(bounded, t)
+	if bounded.Len() != 3 || bounded.Front().key != 7 {
+		t.Fatal("a bounded list must keep its bound", bounded.Len())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("unsorted items must panic")
+		}
+		if copied.Len() != 2 {
+			t.Fatal("unsorted items must leave the list alone")
+		}
+	}()
+	copied.Load([]KV{{2, nil}, {1, nil}})
+}
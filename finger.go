@@ -0,0 +1,79 @@
+package jumplist
+
+import "sync/atomic"
+
+// Finger remembers where its last lookup ended on every level, so a lookup near the previous one
+// climbs only as high as the distance between the keys requires: O(log d) for a distance of d columns.
+// It works in both directions. After any change to the list the next Seek starts from the top again.
+type Finger struct {
+	list    *SkipList
+	fingers []*Column //last column before the position on each level, nil for the start pointers
+	version uint64    //of the list when the fingers were taken
+}
+
+// NewFinger returns a finger positioned at the front of the list.
+func (list *SkipList) NewFinger() *Finger {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return &Finger{list: list, fingers: make([]*Column, list.maxLevel), version: list.version}
+}
+
+func (finger *Finger) nextOf(column *Column, level int) *Column {
+	if column == nil {
+		return finger.list.startPointers.next[level]
+	}
+	return column.next[level]
+}
+
+// Seek moves the finger to key and returns the column holding it, nil if key is absent.
+func (finger *Finger) Seek(key float64) *Column {
+	finger.list.mutex.RLock()
+	defer finger.list.mutex.RUnlock()
+
+	if finger.version != finger.list.version {
+		finger.version = finger.list.version
+		if len(finger.fingers) == finger.list.maxLevel {
+			clear(finger.fingers) //the columns they point at may be gone
+		} else {
+			finger.fingers = make([]*Column, finger.list.maxLevel) //grown, or shrunk by Clear
+		}
+	}
+
+	//climb until the level brackets key, the fingers higher up are further left
+	top := 0
+	for ; top < len(finger.fingers)-1; top++ {
+		at := finger.fingers[top]
+		if at != nil && !finger.list.keyLess(at.key, key) {
+			continue //key is behind the finger
+		}
+		if next := finger.nextOf(at, top); next == nil || !finger.list.keyLess(next.key, key) {
+			break
+		}
+	}
+	atomic.AddInt64(&finger.list.fingerSeeks, 1)
+	if top < len(finger.fingers)-1 {
+		atomic.AddInt64(&finger.list.fingerHits, 1)
+	}
+
+	at := finger.fingers[top]
+	if at != nil && !finger.list.keyLess(at.key, key) {
+		at = nil //even the top level is past key, start from the front
+	}
+	for i := top; i >= 0; i-- {
+		if f := finger.fingers[i]; f != nil && finger.list.keyLess(f.key, key) && (at == nil || finger.list.keyLess(at.key, f.key)) {
+			at = f //the finger on this level is closer
+		}
+		next := finger.nextOf(at, i)
+		for next != nil && finger.list.keyLess(next.key, key) {
+			at = next
+			next = next.next[i]
+		}
+		finger.fingers[i] = at
+	}
+
+	if column := finger.nextOf(at, 0); column != nil && column.key == key {
+		return column
+	}
+	return nil
+}
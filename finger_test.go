@@ -0,0 +1,53 @@
+package jumplist
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestFinger(t *testing.T) {
+	list := New()
+	for i := 0; i < 10000; i++ {
+		list.Set(float64(i*2), i)
+	}
+
+	finger := list.NewFinger()
+	r := rand.New(rand.NewSource(1))
+	key := 0
+	for i := 0; i < 10000; i++ {
+		if i%100 == 0 {
+			key = r.Intn(20000) //jump anywhere now and then
+		} else {
+			key += r.Intn(21) - 10 //small steps both ways
+		}
+		c := finger.Seek(float64(key))
+		if key%2 == 0 && key >= 0 && key < 20000 {
+			if c == nil || c.Key() != float64(key) || c.Value.(int) != key/2 {
+				t.Fatalf("Seek(%v) returned %v", key, c)
+			}
+		} else if c != nil {
+			t.Fatalf("Seek(%v) found missing key %v", key, c.Key())
+		}
+	}
+
+	if finger.Seek(-1) != nil || finger.Seek(19998) == nil || finger.Seek(0) == nil || finger.Seek(1e9) != nil {
+		t.Fatal("Seek must work at both ends")
+	}
+	if New().NewFinger().Seek(1) != nil {
+		t.Fatal("empty list must find nothing")
+	}
+}
+
+func BenchmarkFinger(b *testing.B) {
+	b.Run("Get", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchList.Get(float64(i % 10000000))
+		}
+	})
+	b.Run("Seek", func(b *testing.B) {
+		finger := benchList.NewFinger()
+		for i := 0; i < b.N; i++ {
+			finger.Seek(float64(i % 10000000))
+		}
+	})
+}
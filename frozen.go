@@ -0,0 +1,142 @@
+package jumplist
+
+import (
+	"iter"
+)
+
+// FrozenList is a list that can no longer change, so its read methods take no lock at all and any number
+// of goroutines can share it, such as an index built once at startup. It keeps the columns and levels of
+// the list it was frozen from, so lookups cost the same without the locking.
+type FrozenList struct {
+	list *SkipList //owned by the frozen list, nothing writes to it
+}
+
+// Freeze moves the columns of the list into a FrozenList in O(1) and leaves the list empty, like Split.
+// Deadlines set with SetWithTTL are not carried over, a frozen column does not expire.
+func (list *SkipList) Freeze() *FrozenList {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	frozen := list.sibling()
+	copy(frozen.startPointers.next, list.startPointers.next)
+	copy(frozen.startPointers.span, list.startPointers.span)
+	frozen.length = list.length
+	frozen.resize(0)
+
+	for i := range list.startPointers.next {
+		list.startPointers.next[i] = nil //the columns belong to the frozen list now, clear must not release them
+	}
+	list.ownsArena = false //the frozen columns still live in it
+	list.clear()
+
+	return &FrozenList{frozen}
+}
+
+// Len returns the number of columns.
+func (frozen *FrozenList) Len() int {
+	return frozen.list.length
+}
+
+// Get returns the column with key, nil if there is none.
+func (frozen *FrozenList) Get(key float64) *Column {
+	key = frozen.list.snap(key)
+	if next := frozen.list.seek(key); next != nil && next.key == key {
+		return next
+	}
+	return nil
+}
+
+// GetValue is Get returning the value and whether key is present.
+func (frozen *FrozenList) GetValue(key float64) (interface{}, bool) {
+	if column := frozen.Get(key); column != nil {
+		return column.Value, true
+	}
+	return nil, false
+}
+
+// Contains reports whether key is present.
+func (frozen *FrozenList) Contains(key float64) bool {
+	return frozen.Get(key) != nil
+}
+
+// Front returns the column with the smallest key, nil if the list is empty.
+func (frozen *FrozenList) Front() *Column {
+	return frozen.list.startPointers.next[0]
+}
+
+// Back returns the column with the largest key, nil if the list is empty.
+func (frozen *FrozenList) Back() *Column {
+	return frozen.list.back()
+}
+
+// Floor returns the column with the largest key less than or equal to key, or nil.
+func (frozen *FrozenList) Floor(key float64) *Column {
+	column := frozen.list.seek(key)
+	if column != nil && column.key == key {
+		return column
+	}
+	return frozen.list.before(column)
+}
+
+// Ceiling returns the column with the smallest key greater than or equal to key, or nil.
+func (frozen *FrozenList) Ceiling(key float64) *Column {
+	return frozen.list.seek(key)
+}
+
+// Rank returns the number of keys less than key in O(log n).
+func (frozen *FrozenList) Rank(key float64) int {
+	return frozen.list.rank(key)
+}
+
+// GetByRank returns the column at zero based position i in key order, nil if i is out of range.
+func (frozen *FrozenList) GetByRank(i int) *Column {
+	return frozen.list.byRank(i)
+}
+
+// Count returns how many keys lie within [min, max].
+func (frozen *FrozenList) Count(min, max float64) int {
+	if frozen.list.keyLess(max, min) {
+		return 0
+	}
+	return frozen.list.rankThrough(max) - frozen.list.rank(min)
+}
+
+// Range returns the columns with keys within [min, max] in ascending order.
+func (frozen *FrozenList) Range(min, max float64) []*Column {
+	columns := []*Column{}
+	for column := frozen.list.seek(min); column != nil && !frozen.list.keyLess(max, column.key); column = column.next[0] {
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+// All iterates over every key and value in key order.
+func (frozen *FrozenList) All() iter.Seq2[float64, interface{}] {
+	return frozen.from(frozen.list.startPointers.next[0])
+}
+
+// From iterates in key order starting at the first key not less than key.
+func (frozen *FrozenList) From(key float64) iter.Seq2[float64, interface{}] {
+	return frozen.from(frozen.list.seek(key))
+}
+
+func (frozen *FrozenList) from(first *Column) iter.Seq2[float64, interface{}] {
+	return func(yield func(float64, interface{}) bool) {
+		for column := first; column != nil; column = column.next[0] {
+			if !yield(column.key, column.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward iterates in descending key order.
+func (frozen *FrozenList) Backward() iter.Seq2[float64, interface{}] {
+	return func(yield func(float64, interface{}) bool) {
+		for column := frozen.list.back(); column != nil; column = column.prev {
+			if !yield(column.key, column.Value) {
+				return
+			}
+		}
+	}
+}
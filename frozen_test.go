@@ -0,0 +1,62 @@
+package jumplist
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestFreeze(t *testing.T) {
+	list := New()
+	for i := 0; i < 1000; i++ {
+		list.Set(float64(i*2), i)
+	}
+	frozen := list.Freeze()
+	if list.Len() != 0 || list.Front() != nil {
+		t.Fatal("Freeze must leave the list empty")
+	}
+	list.Set(1, "new") //the list keeps working on its own
+	if frozen.Contains(1) || frozen.Len() != 1000 {
+		t.Fatal("the frozen list must not see later writes")
+	}
+
+	wg := &sync.WaitGroup{}
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				if v, ok := frozen.GetValue(float64(i * 2)); !ok || v != i {
+					t.Errorf("key %v holds %v", i*2, v)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if frozen.Rank(100) != 50 || frozen.GetByRank(50).Key() != 100 || frozen.Count(10, 20) != 6 {
+		t.Fatal("wrong rank lookups")
+	}
+	if frozen.Floor(101).Key() != 100 || frozen.Ceiling(101).Key() != 102 || frozen.Back().Key() != 1998 || frozen.Front().Key() != 0 {
+		t.Fatal("wrong neighbours")
+	}
+	if len(frozen.Range(10, 20)) != 6 || frozen.Get(3) != nil {
+		t.Fatal("wrong lookups")
+	}
+	n, last := 0, -1.0
+	for k := range frozen.All() {
+		if k <= last {
+			t.Fatal("All must be in key order")
+		}
+		n, last = n+1, k
+	}
+	for k := range frozen.Backward() {
+		if k != 1998 {
+			t.Fatal("Backward must start at the largest key", k)
+		}
+		break
+	}
+	if n != 1000 {
+		t.Fatalf("All visited %v columns", n)
+	}
+}
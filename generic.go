@@ -0,0 +1,242 @@
+package jumplist
+
+import (
+	"cmp"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Node is a column of a List, holding a typed key and value.
+type Node[K any, V any] struct {
+	Value V //first, so a zero size value adds no padding at the end
+	next  []*Node[K, V]
+	key   K
+}
+
+// Key returns the key the node is ordered by.
+func (node *Node[K, V]) Key() K {
+	return node.key
+}
+
+// Level is the number of levels this node takes part in.
+func (node *Node[K, V]) Level() int {
+	return len(node.next)
+}
+
+// Next returns the node with the following key, nil at the end. It does not lock, like Column.Next.
+func (node *Node[K, V]) Next() *Node[K, V] {
+	return node.next[0]
+}
+
+// List is the type parameterized form of SkipList, ordered by a less function so keys and values
+// need no boxing or type assertions. SkipList stays the float64 keyed list it always was.
+type List[K any, V any] struct {
+	startPointers []*Node[K, V]
+	maxLevel      int
+	randomSeed    rand.Source
+	levels        levelDist
+	mutex         sync.RWMutex
+	levelCursors  [][]*Node[K, V] //next slice of the predecessor on each level
+	less          func(a, b K) bool
+	length        int
+}
+
+// NewList returns an empty List over an ordered key type.
+func NewList[K cmp.Ordered, V any]() *List[K, V] {
+	return NewListFunc[K, V](18, cmp.Less[K])
+}
+
+// NewListFunc returns an empty List with maxLevel levels and keys ordered by less.
+func NewListFunc[K any, V any](maxLevel int, less func(a, b K) bool) *List[K, V] {
+	return &List[K, V]{
+		startPointers: make([]*Node[K, V], maxLevel),
+		levelCursors:  make([][]*Node[K, V], maxLevel),
+		maxLevel:      maxLevel,
+		randomSeed:    rand.New(rand.NewSource(time.Now().UnixNano())),
+		levels:        newLevelDist(maxLevel, 1/math.E),
+		less:          less,
+	}
+}
+
+func (list *List[K, V]) moveCursors(key K) {
+	next := list.startPointers
+
+	for i := list.maxLevel - 1; i >= 0; i-- { //move from the top
+		for next[i] != nil && list.less(next[i].key, key) {
+			next = next[i].next //keep move to the right
+		}
+		list.levelCursors[i] = next
+	}
+}
+
+// found reports whether node holds key, given that node is not less than key.
+func (list *List[K, V]) found(node *Node[K, V], key K) bool {
+	return node != nil && !list.less(key, node.key)
+}
+
+func (list *List[K, V]) Set(key K, value V) *Node[K, V] {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	node, _ := list.set(key, value)
+	return node
+}
+
+// set reports whether it inserted a node rather than updating one.
+func (list *List[K, V]) set(key K, value V) (*Node[K, V], bool) {
+	list.moveCursors(key)
+	node := list.levelCursors[0][0]
+	if list.found(node, key) {
+		node.Value = value
+		return node, false
+	}
+
+	node = &Node[K, V]{next: make([]*Node[K, V], randLevel(list.randomSeed, list.levels)), key: key, Value: value}
+	for i := range node.next {
+		node.next[i] = list.levelCursors[i][i]
+		list.levelCursors[i][i] = node
+	}
+	list.length++
+	return node, true
+}
+
+func (list *List[K, V]) Get(key K) *Node[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	if node := list.seek(key); list.found(node, key) {
+		return node
+	}
+	return nil
+}
+
+// seek returns the first node whose key is not less than key, leaving the cursors alone.
+func (list *List[K, V]) seek(key K) *Node[K, V] {
+	next := list.startPointers
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		for next[i] != nil && list.less(next[i].key, key) {
+			next = next[i].next
+		}
+	}
+	return next[0]
+}
+
+func (list *List[K, V]) Del(key K) *Node[K, V] {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	return list.del(key)
+}
+
+func (list *List[K, V]) del(key K) *Node[K, V] {
+	list.moveCursors(key)
+	node := list.levelCursors[0][0]
+	if !list.found(node, key) {
+		return nil
+	}
+
+	for i, next := range node.next {
+		list.levelCursors[i][i] = next
+	}
+	list.length--
+	return node
+}
+
+// Front returns the node with the smallest key, nil if the list is empty.
+func (list *List[K, V]) Front() *Node[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.startPointers[0]
+}
+
+// Back returns the node with the largest key, nil if the list is empty.
+func (list *List[K, V]) Back() *Node[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.last(func(*Node[K, V]) bool { return true })
+}
+
+// Ceiling returns the node with the smallest key not less than key, or nil.
+func (list *List[K, V]) Ceiling(key K) *Node[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.seek(key)
+}
+
+// Floor returns the node with the largest key not greater than key, or nil.
+func (list *List[K, V]) Floor(key K) *Node[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.last(func(node *Node[K, V]) bool { return !list.less(key, node.key) })
+}
+
+// Lower returns the node with the largest key less than key, or nil. Nodes have no back links, so
+// walking backwards is a Lower per step, O(log n) each.
+func (list *List[K, V]) Lower(key K) *Node[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.last(func(node *Node[K, V]) bool { return list.less(node.key, key) })
+}
+
+// last returns the last node before satisfies turns false, satisfies holding for a prefix of the list.
+func (list *List[K, V]) last(satisfies func(node *Node[K, V]) bool) *Node[K, V] {
+	next := list.startPointers
+	var node *Node[K, V]
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		for next[i] != nil && satisfies(next[i]) {
+			node = next[i]
+			next = node.next
+		}
+	}
+	return node
+}
+
+// Range returns the nodes with keys within [min, max] in ascending order.
+func (list *List[K, V]) Range(min, max K) []*Node[K, V] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	nodes := []*Node[K, V]{}
+	for node := list.seek(min); node != nil && !list.less(max, node.key); node = node.next[0] {
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// Len returns the number of nodes.
+func (list *List[K, V]) Len() int {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.length
+}
+
+// NewWithComparator returns a List over arbitrary keys ordered by less, for keys that are not
+// cmp.Ordered such as composite structs. NewListFunc does the same without interface{} keys.
+func NewWithComparator(less func(a, b interface{}) bool) *List[interface{}, interface{}] {
+	return NewListFunc[interface{}, interface{}](18, less)
+}
+
+// Int64SkipList is a List keyed by int64, exact where float64 keys collide past 2^53.
+type Int64SkipList = List[int64, interface{}]
+
+// Uint64SkipList is a List keyed by uint64, exact over the whole range.
+type Uint64SkipList = List[uint64, interface{}]
+
+// NewInt64SkipList returns an empty int64 keyed list. Keys are compared with < and never
+// subtracted, so the extremes of the range order correctly without overflowing.
+func NewInt64SkipList() *Int64SkipList {
+	return NewList[int64, interface{}]()
+}
+
+// NewUint64SkipList returns an empty uint64 keyed list, compared like NewInt64SkipList.
+func NewUint64SkipList() *Uint64SkipList {
+	return NewList[uint64, interface{}]()
+}
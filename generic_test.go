@@ -0,0 +1,189 @@
+package jumplist
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestListStringKeys(t *testing.T) {
+	list := NewList[string, int]()
+	for _, k := range []string{"pear", "apple", "fig", "kiwi", "apple"} {
+		list.Set(k, len(k))
+	}
+	list.Set("fig", 30)
+	list.Del("kiwi")
+	list.Del("missing")
+
+	if list.Len() != 3 {
+		t.Fatalf("list holds %v nodes, expected 3", list.Len())
+	}
+	if n := list.Get("fig"); n == nil || n.Value != 30 || n.Key() != "fig" {
+		t.Fatal(`wrong "fig" value (expected 30)`, n)
+	}
+	if list.Get("kiwi") != nil {
+		t.Fatal(`found "kiwi", which should have been deleted`)
+	}
+
+	expected := []string{"apple", "fig", "pear"}
+	i := 0
+	for n := list.startPointers[0]; n != nil; n = n.next[0] {
+		if n.key != expected[i] {
+			t.Fatalf("node %v is %q, expected %q", i, n.key, expected[i])
+		}
+		for level := 1; level < n.Level(); level++ {
+			if next := n.next[level]; next != nil && next.key <= n.key {
+				t.Fatalf("level %v is out of order after %q", level, n.key)
+			}
+		}
+		i++
+	}
+}
+
+func TestListIntKeys(t *testing.T) {
+	list := NewList[int64, string]()
+	const big = int64(1) << 60 //float64 keys cannot tell these apart
+	list.Set(big, "a")
+	list.Set(big+1, "b")
+
+	if list.Len() != 2 || list.Get(big).Value != "a" || list.Get(big+1).Value != "b" {
+		t.Fatal("neighbouring int64 keys must not collide")
+	}
+}
+
+func TestListNavigation(t *testing.T) {
+	list := NewList[int, string]()
+	if list.Front() != nil || list.Back() != nil || list.Floor(1) != nil || list.Lower(1) != nil || list.Ceiling(1) != nil {
+		t.Fatal("an empty list has no nodes to navigate to")
+	}
+	for k := 10; k <= 50; k += 10 {
+		list.Set(k, strconv.Itoa(k))
+	}
+
+	key := func(n *Node[int, string]) int {
+		if n == nil {
+			return -1
+		}
+		return n.Key()
+	}
+	for _, tc := range []struct {
+		got, expected int
+		name          string
+	}{
+		{key(list.Front()), 10, "Front"},
+		{key(list.Back()), 50, "Back"},
+		{key(list.Front().Next()), 20, "Next"},
+		{key(list.Ceiling(25)), 30, "Ceiling(25)"},
+		{key(list.Ceiling(30)), 30, "Ceiling(30)"},
+		{key(list.Ceiling(51)), -1, "Ceiling(51)"},
+		{key(list.Floor(25)), 20, "Floor(25)"},
+		{key(list.Floor(30)), 30, "Floor(30)"},
+		{key(list.Floor(9)), -1, "Floor(9)"},
+		{key(list.Lower(30)), 20, "Lower(30)"},
+		{key(list.Lower(10)), -1, "Lower(10)"},
+		{key(list.Lower(99)), 50, "Lower(99)"},
+	} {
+		if tc.got != tc.expected {
+			t.Errorf("%v is %v, expected %v", tc.name, tc.got, tc.expected)
+		}
+	}
+}
+
+func BenchmarkListIncSet(b *testing.B) {
+	b.ReportAllocs()
+	list := NewList[int, [1]byte]()
+	for i := 0; i < b.N; i++ {
+		list.Set(i, [1]byte{})
+	}
+}
+
+func BenchmarkListIncGet(b *testing.B) {
+	list := NewList[int, string]()
+	for i := 0; i < 100000; i++ {
+		list.Set(i, strconv.Itoa(i))
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if list.Get(i%100000) == nil {
+			b.Fatal("failed to Get an element that should exist")
+		}
+	}
+}
+
+func TestNewWithComparator(t *testing.T) {
+	type score struct {
+		points float64
+		at     int64
+	}
+	list := NewWithComparator(func(a, b interface{}) bool {
+		x, y := a.(score), b.(score)
+		if x.points != y.points {
+			return x.points < y.points
+		}
+		return x.at < y.at //earlier wins the tie
+	})
+
+	list.Set(score{10, 3}, "c")
+	list.Set(score{20, 1}, "d")
+	list.Set(score{10, 1}, "a")
+	list.Set(score{10, 2}, "b")
+	list.Set(score{10, 2}, "B")
+
+	expected := []string{"a", "B", "c", "d"}
+	i := 0
+	for n := list.startPointers[0]; n != nil; n = n.next[0] {
+		if n.Value != expected[i] {
+			t.Fatalf("node %v holds %v, expected %v", i, n.Value, expected[i])
+		}
+		i++
+	}
+	if i != len(expected) || list.Len() != len(expected) {
+		t.Fatalf("list holds %v nodes, expected %v", i, len(expected))
+	}
+	if n := list.Get(score{10, 3}); n == nil || n.Value != "c" {
+		t.Fatal("composite key lookup failed", n)
+	}
+}
+
+func TestInt64SkipList(t *testing.T) {
+	list := NewInt64SkipList()
+	const exact = int64(1) << 53 //the last integer float64 holds exactly
+	keys := []int64{exact + 1, exact, exact - 1, exact + 2, math.MaxInt64, math.MinInt64, -1, 0}
+	for _, k := range keys {
+		list.Set(k, k)
+	}
+	if float64(exact) != float64(exact+1) {
+		t.Fatal("the boundary moved, 2^53 and 2^53+1 must collide as float64")
+	}
+
+	if list.Len() != len(keys) {
+		t.Fatalf("list holds %v nodes, expected %v", list.Len(), len(keys))
+	}
+	for _, k := range keys {
+		if n := list.Get(k); n == nil || n.Value != k {
+			t.Fatalf("key %v holds %v", k, n)
+		}
+	}
+
+	expected := []int64{math.MinInt64, -1, 0, exact - 1, exact, exact + 1, exact + 2, math.MaxInt64}
+	for i, n := 0, list.startPointers[0]; n != nil; i, n = i+1, n.next[0] {
+		if n.Key() != expected[i] {
+			t.Fatalf("node %v is %v, expected %v", i, n.Key(), expected[i])
+		}
+	}
+	if got := list.Range(exact, exact+1); len(got) != 2 || got[0].Key() != exact || got[1].Key() != exact+1 {
+		t.Fatal("a range at the boundary must hold exactly its keys", len(got))
+	}
+	if got := list.Range(math.MinInt64, math.MaxInt64); len(got) != len(keys) {
+		t.Fatal("the full range must hold every key", len(got))
+	}
+
+	unsigned := NewUint64SkipList()
+	unsigned.Set(math.MaxUint64, "max")
+	unsigned.Set(math.MaxUint64-1, "below")
+	unsigned.Set(0, "zero")
+	if got := unsigned.Range(1<<63, math.MaxUint64); len(got) != 2 || got[0].Value != "below" || got[1].Value != "max" {
+		t.Fatal("keys above the int64 range must order as unsigned", len(got))
+	}
+}
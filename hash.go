@@ -0,0 +1,43 @@
+package jumplist
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"hash/fnv"
+	"math"
+)
+
+// ContentHash returns an FNV-1a fingerprint of the ordered key/value pairs, so lists holding the
+// same contents hash equal whatever their insertion order or level layout.
+// Each value is gob encoded on its own; values gob cannot encode are hashed by their %#v text.
+// Gob does not sort map entries, so map values (or values containing maps) may not hash stably.
+func (list *SkipList) ContentHash() uint64 {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	h := fnv.New64a()
+	var scratch [8]byte
+	buf := &bytes.Buffer{}
+
+	for column := list.startPointers.next[0]; column != nil; column = column.next[0] {
+		binary.BigEndian.PutUint64(scratch[:], math.Float64bits(column.key))
+		h.Write(scratch[:])
+
+		buf.Reset()
+		if column.Value == nil {
+			buf.WriteString("<nil>")
+		} else if err := gob.NewEncoder(buf).Encode(column.Value); err != nil {
+			buf.Reset()
+			fmt.Fprintf(buf, "%#v", column.Value)
+		}
+
+		//length prefix keeps neighbouring values from running into each other
+		binary.BigEndian.PutUint64(scratch[:], uint64(buf.Len()))
+		h.Write(scratch[:])
+		h.Write(buf.Bytes())
+	}
+
+	return h.Sum64()
+}
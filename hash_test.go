@@ -0,0 +1,37 @@
+package jumplist
+
+import "testing"
+
+func TestContentHash(t *testing.T) {
+	a, b := New(), New()
+	if a.ContentHash() != b.ContentHash() {
+		t.Fatal("empty lists must hash equal")
+	}
+
+	for i := 0; i < 100; i++ {
+		a.Set(float64(i), i*i)
+		b.Set(float64(99-i), (99-i)*(99-i))
+	}
+	a.Set(1000, nil)
+	b.Set(1000, nil)
+	a.Set(2000, struct{ Name string }{"x"})
+	b.Set(2000, struct{ Name string }{"x"})
+	a.Set(3000, struct{ n int }{1}) //not gob encodable
+	b.Set(3000, struct{ n int }{1})
+
+	if a.ContentHash() != b.ContentHash() {
+		t.Fatal("lists with the same contents must hash equal")
+	}
+
+	b.Set(50, -1)
+	if a.ContentHash() == b.ContentHash() {
+		t.Fatal("a different value must change the hash")
+	}
+
+	b.Set(50, 2500)
+	b.Del(99)
+	b.Set(99.5, 99*99)
+	if a.ContentHash() == b.ContentHash() {
+		t.Fatal("a different key must change the hash")
+	}
+}
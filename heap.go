@@ -0,0 +1,113 @@
+package jumplist
+
+import "iter"
+
+// Heap is a priority queue kept in a multiset list, for migrating code written against container/heap.
+// It implements heap.Interface, so heap.Push, heap.Pop and heap.Remove work on it unchanged, and adds
+// what a slice heap lacks: Remove and Reprioritize of any item in O(log n) and All in priority order.
+//
+// Position i of heap.Interface is the item of rank i, the i-th smallest priority with equal ones in
+// insertion order. A sorted sequence is already a heap, so the sift steps of container/heap never move
+// anything, Swap only records which position the next Pop removes and each heap function costs
+// O(log n). heap.Fix has nothing to fix, as an item cannot change its priority in place: use
+// Reprioritize. Direct callers use PopMin rather than Pop, which is the half of heap.Interface that
+// removes the last position. The methods lock the list, but like any heap.Interface the heap functions
+// must not be called concurrently.
+type Heap struct {
+	list    *SkipList
+	popping int //position the next Pop removes, set by Swap, -1 for the last one
+}
+
+// HeapItem is what Push takes and Pop returns.
+type HeapItem struct {
+	Priority float64
+	Value    interface{}
+}
+
+// NewHeap returns an empty heap, smallest priority first. opts are applied to the list, which is
+// always a multiset.
+func NewHeap(opts ...Option) *Heap {
+	return &Heap{list: New(append(opts, AllowDuplicates())...), popping: -1}
+}
+
+// Len returns the number of items.
+func (h *Heap) Len() int {
+	return h.list.Len()
+}
+
+// Less orders positions, which are ranks, so it does not need to look at the items.
+func (h *Heap) Less(i, j int) bool {
+	return i < j
+}
+
+// Swap moves nothing. container/heap swaps the position it is about to Pop to the end, so Swap
+// remembers it for the Pop that follows.
+func (h *Heap) Swap(i, j int) {
+	if last := h.Len() - 1; j == last {
+		h.popping = i
+	} else if i == last {
+		h.popping = j
+	}
+}
+
+// Push adds x, which must be a HeapItem. Use heap.Push(h, x) or Insert.
+func (h *Heap) Push(x interface{}) {
+	item := x.(HeapItem)
+	h.list.Set(item.Priority, item.Value)
+}
+
+// Pop removes the item at the position the last Swap named, the last one without a Swap, and returns
+// it as a HeapItem. Use heap.Pop(h) or PopMin.
+func (h *Heap) Pop() interface{} {
+	list := h.list
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	i := h.popping
+	h.popping = -1
+	if i < 0 {
+		i = list.length - 1
+	}
+	column := list.byRank(i)
+	list.removeColumn(column)
+	list.trace("Del", column.key, column, true)
+	return HeapItem{column.key, column.Value}
+}
+
+// Insert adds value with priority and returns its column, to Remove or Reprioritize it later.
+func (h *Heap) Insert(priority float64, value interface{}) *Column {
+	return h.list.Set(priority, value)
+}
+
+// Peek returns the column with the smallest priority without removing it, nil if the heap is empty.
+func (h *Heap) Peek() *Column {
+	return h.list.Front()
+}
+
+// PopMin removes and returns the column with the smallest priority, nil if the heap is empty.
+func (h *Heap) PopMin() *Column {
+	return h.list.PopMin()
+}
+
+// Remove removes column, as returned by Insert, and reports whether it was still in the heap.
+func (h *Heap) Remove(column *Column) bool {
+	return h.list.RemoveElement(column)
+}
+
+// Reprioritize moves the value of column to priority and returns the column now holding it, nil if
+// column was no longer in the heap. It goes after the items already at priority.
+func (h *Heap) Reprioritize(column *Column, priority float64) *Column {
+	list := h.list
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	if !list.removeColumn(column) {
+		return nil
+	}
+	return list.set(priority, column.Value)
+}
+
+// All iterates over the priorities and values in priority order, under the read lock like SkipList.All.
+func (h *Heap) All() iter.Seq2[float64, interface{}] {
+	return h.list.All()
+}
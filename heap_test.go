@@ -0,0 +1,84 @@
+package jumplist
+
+import (
+	"container/heap"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+var _ heap.Interface = &Heap{}
+
+func TestHeap(t *testing.T) {
+	h := NewHeap()
+	heap.Init(h)
+	r := rand.New(rand.NewSource(1))
+	model := []float64{} //sorted priorities
+
+	for i := 0; i < 2000; i++ {
+		switch op := r.Intn(4); {
+		case op < 2 || len(model) == 0:
+			p := float64(r.Intn(100))
+			heap.Push(h, HeapItem{p, i})
+			j, _ := slices.BinarySearch(model, p+0.5)
+			model = slices.Insert(model, j, p)
+		case op == 2:
+			item := heap.Pop(h).(HeapItem)
+			if item.Priority != model[0] {
+				t.Fatalf("heap.Pop returned %v, expected %v", item.Priority, model[0])
+			}
+			model = model[1:]
+		default:
+			j := r.Intn(len(model))
+			item := heap.Remove(h, j).(HeapItem)
+			if item.Priority != model[j] {
+				t.Fatalf("heap.Remove(%v) returned %v, expected %v", j, item.Priority, model[j])
+			}
+			model = slices.Delete(model, j, j+1)
+		}
+		if h.Len() != len(model) {
+			t.Fatalf("heap holds %v items, expected %v", h.Len(), len(model))
+		}
+	}
+
+	got := []float64{}
+	for p := range h.All() {
+		got = append(got, p)
+	}
+	if !slices.Equal(got, model) {
+		t.Fatal("All is", got, "expected", model)
+	}
+}
+
+func TestHeapDirect(t *testing.T) {
+	h := NewHeap()
+	if h.Peek() != nil || h.PopMin() != nil {
+		t.Fatal("an empty heap has nothing to peek at or pop")
+	}
+	a := h.Insert(5, "a")
+	b := h.Insert(3, "b")
+	h.Insert(3, "c")
+	h.Insert(8, "d")
+
+	if h.Peek() != b {
+		t.Fatal("Peek must return the first of the smallest priority")
+	}
+	if !h.Remove(b) || h.Remove(b) {
+		t.Fatal("Remove must remove a column only once")
+	}
+	a = h.Reprioritize(a, 1)
+	if a == nil || a.Key() != 1 || a.Value != "a" {
+		t.Fatal("Reprioritize must move the value to the new priority, got", a)
+	}
+
+	order := []interface{}{}
+	for column := h.PopMin(); column != nil; column = h.PopMin() {
+		order = append(order, column.Value)
+	}
+	if !slices.Equal(order, []interface{}{"a", "c", "d"}) {
+		t.Fatal("PopMin order is", order)
+	}
+	if h.Reprioritize(a, 2) != nil {
+		t.Fatal("a popped column cannot be reprioritized")
+	}
+}
@@ -0,0 +1,63 @@
+package jumplist
+
+import "math"
+
+// BucketCount is one bar of a Histogram: the columns with keys within [Min, Max), the last bucket
+// including its Max.
+type BucketCount struct {
+	Min, Max float64
+	Count    int
+}
+
+// Histogram splits the keys from the smallest to the largest into buckets of equal width and counts
+// the columns in each, for distribution summaries such as latency dashboards. Counts come from rank
+// lookups at the bucket bounds, so it costs O(buckets log n) without visiting the columns. Sampled
+// lists count observations, which takes a walk over level 0. An empty list has no buckets, and a list
+// with a single distinct key or an infinite one is summed up in one bucket.
+func (list *SkipList) Histogram(buckets int) []BucketCount {
+	if buckets < 1 {
+		panic("buckets must be positive")
+	}
+	if list.less != nil {
+		panic("Histogram needs the numeric order of keys, not WithComparator")
+	}
+
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	if list.length == 0 {
+		return nil
+	}
+	lo, hi := list.startPointers.next[0].key, list.back().key
+	width := (hi - lo) / float64(buckets)
+	if width == 0 || math.IsInf(width, 0) || math.IsNaN(width) {
+		buckets, width = 1, hi-lo
+	}
+
+	counts := make([]BucketCount, buckets)
+	for i := range counts {
+		counts[i].Min = lo + float64(i)*width
+		counts[i].Max = lo + float64(i+1)*width
+	}
+	counts[0].Min, counts[buckets-1].Max = lo, hi //exact despite rounding
+
+	if list.sampleCap > 0 {
+		i := 0
+		for column := list.startPointers.next[0]; column != nil; column = column.next[0] {
+			for i < buckets-1 && column.key >= counts[i].Max {
+				i++
+			}
+			counts[i].Count += list.weight(column)
+		}
+		return counts
+	}
+
+	below := 0
+	for i := range counts[:buckets-1] {
+		rank := list.rank(counts[i].Max) //keys below the bound
+		counts[i].Count = rank - below
+		below = rank
+	}
+	counts[buckets-1].Count = list.length - below
+	return counts
+}
@@ -0,0 +1,40 @@
+package jumplist
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHistogram(t *testing.T) {
+	list := New()
+	if list.Histogram(4) != nil {
+		t.Fatal("an empty list has no buckets")
+	}
+	for i := 0; i <= 100; i++ {
+		list.Set(float64(i), nil)
+	}
+
+	buckets := list.Histogram(4)
+	expected := []BucketCount{{0, 25, 25}, {25, 50, 25}, {50, 75, 25}, {75, 100, 26}}
+	if len(buckets) != len(expected) {
+		t.Fatal("wrong bucket count", buckets)
+	}
+	for i := range expected {
+		if buckets[i] != expected[i] {
+			t.Fatalf("bucket %v is %v, expected %v", i, buckets[i], expected[i])
+		}
+	}
+
+	list.Set(math.Inf(1), nil)
+	if b := list.Histogram(4); len(b) != 1 || b[0].Count != 102 {
+		t.Fatal("an infinite key must sum up the list in one bucket", b)
+	}
+
+	sampled := NewSampled(18, 100)
+	for _, x := range []float64{1, 1, 1, 2, 9, 10} {
+		sampled.Observe(x)
+	}
+	if b := sampled.Histogram(3); b[0].Count != 4 || b[1].Count != 0 || b[2].Count != 2 {
+		t.Fatal("sampled lists must count observations", b)
+	}
+}
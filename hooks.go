@@ -0,0 +1,64 @@
+package jumplist
+
+// OnInsert calls fn with every column added to the list. Like OnUpdate and OnRemove it runs once the
+// operation released the lock, in the order the changes happened, so fn may call back into the list.
+// By then other writers may have changed the list again.
+func OnInsert(fn func(column *Column)) Option {
+	return func(list *SkipList) {
+		list.onInsert = fn
+	}
+}
+
+// OnUpdate calls fn with every column whose value changed and the value it held before.
+func OnUpdate(fn func(column *Column, old interface{})) Option {
+	return func(list *SkipList) {
+		list.onUpdate = fn
+	}
+}
+
+// OnRemove calls fn with every column that left the list, evictions and expiries included.
+// Columns are not recycled while it is set, so WithPooling never hands fn a reused column.
+func OnRemove(fn func(column *Column)) Option {
+	return func(list *SkipList) {
+		list.onRemove = fn
+	}
+}
+
+// OnExpire calls fn after the lock is released with every column swept for outliving its TTL.
+// Like OnRemove it keeps WithPooling from reusing the columns.
+func OnExpire(fn func(column *Column)) Option {
+	return func(list *SkipList) {
+		list.onExpire = fn
+	}
+}
+
+// hookLocker runs the hooks queued while the write lock was held right after releasing it.
+type hookLocker struct {
+	Locker
+	list *SkipList
+}
+
+func (h *hookLocker) Unlock() {
+	pending := h.list.pending
+	h.list.pending = nil
+	h.Locker.Unlock()
+	for _, hook := range pending {
+		hook()
+	}
+}
+
+func (list *SkipList) hooked() bool {
+	return list.onInsert != nil || list.onUpdate != nil || list.onRemove != nil || list.onExpire != nil
+}
+
+// queueHook queues the hook for a change, the write lock being held.
+func (list *SkipList) queueHook(kind EventKind, column *Column, old interface{}) {
+	switch {
+	case kind == EventInsert && list.onInsert != nil:
+		list.pending = append(list.pending, func() { list.onInsert(column) })
+	case kind == EventUpdate && list.onUpdate != nil:
+		list.pending = append(list.pending, func() { list.onUpdate(column, old) })
+	case kind == EventDelete && list.onRemove != nil:
+		list.pending = append(list.pending, func() { list.onRemove(column) })
+	}
+}
@@ -0,0 +1,53 @@
+package jumplist
+
+import "testing"
+
+func TestHooks(t *testing.T) {
+	var log []string
+	var list *SkipList
+	list = New(
+		OnInsert(func(c *Column) {
+			log = append(log, "insert")
+			list.Get(c.Key()) //hooks run unlocked
+		}),
+		OnUpdate(func(c *Column, old interface{}) {
+			if old != 1 || c.Value != 2 {
+				t.Fatal("wrong update", old, c.Value)
+			}
+			log = append(log, "update")
+		}),
+		OnRemove(func(c *Column) { log = append(log, "remove") }),
+		WithPooling(),
+	)
+
+	list.Set(1, 1)
+	list.Set(1, 2)
+	removed := list.Del(1)
+	list.Set(2, nil) //would reuse the removed column if it was pooled
+	if removed == list.Get(2) {
+		t.Fatal("a column handed to OnRemove must not be recycled")
+	}
+
+	expected := []string{"insert", "update", "remove", "insert"}
+	if len(log) != len(expected) {
+		t.Fatal("wrong hooks", log)
+	}
+	for i := range expected {
+		if log[i] != expected[i] {
+			t.Fatal("wrong hooks", log)
+		}
+	}
+
+	//a batch runs every hook once the lock is released
+	log = nil
+	list.SetBatch([]KV{{3, nil}, {4, nil}})
+	list.RemoveRange(0, 10)
+	if len(log) != 5 || log[4] != "remove" {
+		t.Fatal("wrong hooks", log)
+	}
+
+	unlocked := New(WithLocking(false), OnInsert(func(*Column) {}))
+	if unlocked.sibling().mutex != (noLock{}) {
+		t.Fatal("siblings must stay unlocked")
+	}
+}
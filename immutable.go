@@ -0,0 +1,207 @@
+package jumplist
+
+import (
+	"iter"
+	"math"
+	"sort"
+)
+
+// cowNode is an entry, or a block of the nodes one level down. Each entry starts a new block on every
+// level below its height, so a block on level l reaches from one entry taller than l to the next one.
+// This is the skip list seen from the top, which is what lets a change copy only its path.
+type cowNode struct {
+	key      float64 //the entry key, or the first key in the block
+	value    interface{}
+	height   int //0 for the sentinel and for blocks
+	children []*cowNode
+}
+
+// child returns the index of the last child whose first key is not greater than key.
+func (node *cowNode) child(key float64) int {
+	return sort.Search(len(node.children), func(i int) bool { return node.children[i].key > key }) - 1
+}
+
+// cowLevels is shared by every Immutable, the heights come from the keys and not from a source.
+var cowLevels = newLevelDist(32, 1/math.E)
+
+// Immutable is a persistent skip list: Set and Remove return a new list and leave the old one
+// unchanged, copying O(log n) blocks and sharing the rest. No version is ever written to, so any
+// number of goroutines may read or derive from any version without locks.
+// Heights are drawn from a hash of the key, so a list has the same shape whatever order it was
+// built in. Keys chosen to collide would make it tall, as with any hashed structure.
+type Immutable struct {
+	root   *cowNode
+	level  int //of the root block
+	length int
+}
+
+// NewImmutable returns an empty persistent list.
+func NewImmutable() *Immutable {
+	sentinel := &cowNode{key: math.Inf(-1)} //heads every level, so nothing is inserted in front of a block
+	return &Immutable{root: &cowNode{key: math.Inf(-1), children: []*cowNode{sentinel}}, level: 1}
+}
+
+// keyDraw is a rand.Source handing out the splitmix64 sequence of a key, the first draw being its hash,
+// for randLevel to draw as often as it needs.
+type keyDraw uint64
+
+func (d *keyDraw) Int63() int64 {
+	*d += 0x9e3779b97f4a7c15
+	return int64(mix64(uint64(*d)) >> 1)
+}
+
+func (*keyDraw) Seed(int64) {}
+
+func cowHeight(key float64) int {
+	if key == 0 {
+		key = 0
+	}
+	d := keyDraw(math.Float64bits(key))
+	return randLevel(&d, cowLevels)
+}
+
+// hashKey mixes the bits of key with splitmix64, -0 hashing like 0 since they are equal.
+func hashKey(key float64) uint64 {
+	if key == 0 {
+		key = 0
+	}
+	return mix64(math.Float64bits(key) + 0x9e3779b97f4a7c15)
+}
+
+// mix64 is the finalizer of splitmix64.
+func mix64(x uint64) uint64 {
+	x = (x ^ x>>30) * 0xbf58476d1ce4e5b9
+	x = (x ^ x>>27) * 0x94d049bb133111eb
+	return x ^ x>>31
+}
+
+// Get returns the value of key and whether it is present, in O(log n).
+func (list *Immutable) Get(key float64) (interface{}, bool) {
+	if key != key {
+		return nil, false
+	}
+	node := list.root
+	for l := list.level; l >= 1; l-- {
+		node = node.children[node.child(key)]
+	}
+	if node.height > 0 && node.key == key {
+		return node.value, true
+	}
+	return nil, false
+}
+
+// Len returns the number of keys in O(1).
+func (list *Immutable) Len() int {
+	return list.length
+}
+
+// Set returns a list with key set to value. NaN keys panic, like in SkipList.
+func (list *Immutable) Set(key float64, value interface{}) *Immutable {
+	checkKey(key)
+	entry := &cowNode{key: key, value: value, height: cowHeight(key)}
+
+	root, level := list.root, list.level
+	for ; level < entry.height; level++ {
+		root = &cowNode{key: root.key, children: []*cowNode{root}} //the root is never split
+	}
+	root, _, replaced := cowInsert(root, level, entry)
+
+	length := list.length
+	if !replaced {
+		length++
+	}
+	return &Immutable{root: root, level: level, length: length}
+}
+
+// cowInsert returns a copy of the level l block node holding entry. The copy is split in two where
+// entry starts a new block if entry is taller than l.
+func cowInsert(node *cowNode, l int, entry *cowNode) (copied, split *cowNode, replaced bool) {
+	c := node.child(entry.key)
+	children := make([]*cowNode, len(node.children), len(node.children)+1)
+	copy(children, node.children)
+
+	var started *cowNode //child starting at entry, linked after c
+	if l == 1 {
+		if children[c].height > 0 && children[c].key == entry.key {
+			children[c], replaced = entry, true
+		} else {
+			started = entry
+		}
+	} else {
+		children[c], started, replaced = cowInsert(children[c], l-1, entry)
+	}
+
+	if started != nil {
+		children = append(children[:c+1], append([]*cowNode{started}, children[c+1:]...)...)
+		if entry.height > l {
+			return &cowNode{key: children[0].key, children: children[: c+1 : c+1]},
+				&cowNode{key: entry.key, children: children[c+1:]}, false
+		}
+	}
+	return &cowNode{key: children[0].key, children: children}, nil, replaced
+}
+
+// Remove returns a list without key, the list itself if key is absent.
+func (list *Immutable) Remove(key float64) *Immutable {
+	if _, ok := list.Get(key); !ok {
+		return list
+	}
+
+	root, level := cowRemove(list.root, list.level, key, cowHeight(key)), list.level
+	for level > 1 && len(root.children) == 1 {
+		root, level = root.children[0], level-1
+	}
+	return &Immutable{root: root, level: level, length: list.length - 1}
+}
+
+// cowRemove returns a copy of the level l block node without the entry key of the given height.
+// On the level of its height the entry starts a child, which is merged into the child before it.
+func cowRemove(node *cowNode, l int, key float64, height int) *cowNode {
+	c := node.child(key)
+	children := make([]*cowNode, 0, len(node.children))
+
+	switch {
+	case l == 1:
+		children = append(append(children, node.children[:c]...), node.children[c+1:]...)
+	case l == height:
+		children = append(children, node.children[:c-1]...) //c > 0, the sentinel comes first
+		children = append(children, cowMerge(node.children[c-1], node.children[c], l-1))
+		children = append(children, node.children[c+1:]...)
+	default:
+		children = append(children, node.children...)
+		children[c] = cowRemove(node.children[c], l-1, key, height)
+	}
+	return &cowNode{key: children[0].key, children: children}
+}
+
+// cowMerge joins two neighbouring level l blocks, dropping the entry that starts b.
+func cowMerge(a, b *cowNode, l int) *cowNode {
+	children := make([]*cowNode, 0, len(a.children)+len(b.children))
+	if l == 1 {
+		children = append(children, a.children...)
+	} else {
+		children = append(children, a.children[:len(a.children)-1]...)
+		children = append(children, cowMerge(a.children[len(a.children)-1], b.children[0], l-1))
+	}
+	children = append(children, b.children[1:]...)
+	return &cowNode{key: a.key, children: children}
+}
+
+// All iterates over every key in order.
+func (list *Immutable) All() iter.Seq2[float64, interface{}] {
+	return func(yield func(float64, interface{}) bool) {
+		cowWalk(list.root, yield)
+	}
+}
+
+func cowWalk(node *cowNode, yield func(float64, interface{}) bool) bool {
+	if node.children == nil {
+		return node.height == 0 || yield(node.key, node.value)
+	}
+	for _, child := range node.children {
+		if !cowWalk(child, yield) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,101 @@
+package jumplist
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// shape prints the blocks of a persistent list, for comparing layouts.
+func shape(node *cowNode) string {
+	if node.children == nil {
+		return fmt.Sprint(node.key)
+	}
+	parts := make([]string, len(node.children))
+	for i, child := range node.children {
+		parts[i] = shape(child)
+	}
+	return "(" + strings.Join(parts, " ") + ")"
+}
+
+func TestImmutable(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	list := NewImmutable()
+	reference := map[float64]int{}
+	versions := []*Immutable{}
+	snapshots := []map[float64]int{}
+
+	for i := 0; i < 20000; i++ {
+		key := float64(r.Intn(3000))
+		if r.Intn(3) == 0 {
+			list = list.Remove(key)
+			delete(reference, key)
+		} else {
+			list = list.Set(key, i)
+			reference[key] = i
+		}
+		if i%2000 == 0 {
+			snapshot := make(map[float64]int, len(reference))
+			for k, v := range reference {
+				snapshot[k] = v
+			}
+			versions, snapshots = append(versions, list), append(snapshots, snapshot)
+		}
+	}
+
+	check := func(list *Immutable, reference map[float64]int) {
+		if list.Len() != len(reference) {
+			t.Fatalf("length %v, expected %v", list.Len(), len(reference))
+		}
+		n, last := 0, math.Inf(-1)
+		for k, v := range list.All() {
+			if k <= last && n > 0 || reference[k] != v.(int) {
+				t.Fatalf("wrong pair %v=%v", k, v)
+			}
+			n, last = n+1, k
+		}
+		if n != len(reference) {
+			t.Fatalf("iterated %v keys, expected %v", n, len(reference))
+		}
+		for k, v := range reference {
+			if got, ok := list.Get(k); !ok || got.(int) != v {
+				t.Fatalf("key %v holds %v, expected %v", k, got, v)
+			}
+		}
+	}
+	check(list, reference)
+	for i := range versions {
+		check(versions[i], snapshots[i]) //old versions are untouched
+	}
+
+	//the layout only depends on the keys
+	sorted := NewImmutable()
+	for k := -1.0; k < 3000; k++ {
+		sorted = sorted.Set(k, nil)
+		if _, ok := reference[k]; !ok {
+			sorted = sorted.Remove(k)
+		}
+	}
+	if shape(sorted.root) != shape(list.root) {
+		t.Fatal("lists with the same keys must have the same layout")
+	}
+
+	if list.Remove(-100) != list {
+		t.Fatal("removing a missing key must return the list itself")
+	}
+	if _, ok := NewImmutable().Set(math.Inf(-1), 1).Get(math.Inf(-1)); !ok {
+		t.Fatal("-Inf is a key like any other")
+	}
+	if _, ok := list.Get(math.NaN()); ok {
+		t.Fatal("NaN is never present")
+	}
+}
+
+func BenchmarkImmutableSet(b *testing.B) {
+	list := NewImmutable()
+	for i := 0; i < b.N; i++ {
+		list = list.Set(float64(i), nil)
+	}
+}
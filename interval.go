@@ -0,0 +1,166 @@
+package jumplist
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Interval is a half open range [Low, High) carrying a value.
+type Interval struct {
+	Low, High float64
+	Value     interface{}
+}
+
+// intervalNode holds one interval. cover[i] is the largest High among the nodes from this one up to,
+// not including, next[i] on level 0, so a whole span that ends before a point is skipped at once.
+type intervalNode struct {
+	next     []*intervalNode
+	cover    []float64
+	interval Interval
+}
+
+func (node *intervalNode) less(low, high float64) bool {
+	return node.interval.Low < low || node.interval.Low == low && node.interval.High < high
+}
+
+// IntervalSkipList stores intervals ordered by Low and then High, each pointer augmented with the
+// largest High it steps over. Stab and Overlaps descend only into the spans that can hold a match,
+// so they take O(log n) plus a few steps per interval found. Equal intervals may be stored repeatedly.
+type IntervalSkipList struct {
+	mutex        sync.RWMutex
+	head         *intervalNode //holds no interval, its cover starts at -Inf
+	predecessors []*intervalNode
+	randomSeed   rand.Source
+	levels       levelDist
+	length       int
+}
+
+// NewIntervalSkipList returns an empty interval skip list.
+func NewIntervalSkipList() *IntervalSkipList {
+	const maxLevel = 18
+	head := &intervalNode{next: make([]*intervalNode, maxLevel), cover: make([]float64, maxLevel)}
+	head.interval.Low, head.interval.High = math.Inf(-1), math.Inf(-1)
+	for i := range head.cover {
+		head.cover[i] = math.Inf(-1)
+	}
+	return &IntervalSkipList{
+		head:         head,
+		predecessors: make([]*intervalNode, maxLevel),
+		randomSeed:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		levels:       newLevelDist(maxLevel, 1/math.E),
+	}
+}
+
+// find fills the predecessors with the last node before [low, high) on each level.
+func (list *IntervalSkipList) find(low, high float64) {
+	node := list.head
+	for i := len(node.next) - 1; i >= 0; i-- {
+		for node.next[i] != nil && node.next[i].less(low, high) {
+			node = node.next[i]
+		}
+		list.predecessors[i] = node
+	}
+}
+
+// recompute rebuilds the cover of node on level i from level i-1, which must be up to date.
+func (node *intervalNode) recompute(i int) {
+	cover := node.cover[i-1]
+	for next := node.next[i-1]; next != node.next[i]; next = next.next[i-1] {
+		cover = math.Max(cover, next.cover[i-1])
+	}
+	node.cover[i] = cover
+}
+
+// Insert adds the interval [low, high), which must not be empty.
+func (list *IntervalSkipList) Insert(low, high float64, value interface{}) {
+	if !(low < high) {
+		panic("low must be less than high")
+	}
+
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	list.find(low, high)
+	level := randLevel(list.randomSeed, list.levels)
+	node := &intervalNode{next: make([]*intervalNode, level), cover: make([]float64, level), interval: Interval{low, high, value}}
+	for i := range node.next {
+		node.next[i], list.predecessors[i].next[i] = list.predecessors[i].next[i], node
+	}
+
+	node.cover[0] = high
+	for i := 1; i < len(list.predecessors); i++ {
+		if i < level {
+			list.predecessors[i].recompute(i) //its span was cut at node
+			node.recompute(i)
+		} else if prev := list.predecessors[i]; high > prev.cover[i] {
+			prev.cover[i] = high
+		}
+	}
+	list.length++
+}
+
+// Delete removes one interval equal to [low, high) and reports whether there was one.
+func (list *IntervalSkipList) Delete(low, high float64) bool {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	list.find(low, high)
+	node := list.predecessors[0].next[0]
+	if node == nil || node.interval.Low != low || node.interval.High != high {
+		return false
+	}
+
+	for i := range node.next {
+		list.predecessors[i].next[i] = node.next[i]
+	}
+	for i := 1; i < len(list.predecessors); i++ {
+		list.predecessors[i].recompute(i) //node may have held the largest High of the span
+	}
+	list.length--
+	return true
+}
+
+// Stab returns the intervals containing point, low <= point < high, in order.
+func (list *IntervalSkipList) Stab(point float64) []Interval {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	var found []Interval
+	list.collect(list.head, nil, len(list.head.next)-1, func(low float64) bool { return low <= point }, point, &found)
+	return found
+}
+
+// Overlaps returns the intervals sharing a point with [lo, hi), in order.
+func (list *IntervalSkipList) Overlaps(lo, hi float64) []Interval {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	var found []Interval
+	list.collect(list.head, nil, len(list.head.next)-1, func(low float64) bool { return low < hi }, lo, &found)
+	return found
+}
+
+// collect walks level i from node to end and appends the intervals whose low passes starts and whose
+// high is above after. Spans whose cover is not above after are skipped, the others are searched one level down.
+func (list *IntervalSkipList) collect(node, end *intervalNode, i int, starts func(low float64) bool, after float64, found *[]Interval) {
+	for ; node != end && starts(node.interval.Low); node = node.next[i] {
+		if node.cover[i] <= after {
+			continue
+		}
+		if i > 0 {
+			list.collect(node, node.next[i], i-1, starts, after, found)
+		} else if node != list.head {
+			*found = append(*found, node.interval)
+		}
+	}
+}
+
+// Len returns the number of intervals.
+func (list *IntervalSkipList) Len() int {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.length
+}
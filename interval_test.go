@@ -0,0 +1,91 @@
+package jumplist
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestIntervalSkipList(t *testing.T) {
+	list := NewIntervalSkipList()
+	r := rand.New(rand.NewSource(1))
+	var reference []Interval
+
+	for i := 0; i < 3000; i++ {
+		low := float64(r.Intn(1000))
+		high := low + 1 + float64(r.Intn(50))
+		if r.Intn(20) == 0 {
+			high = low + 500 //a few long ones
+		}
+		list.Insert(low, high, i)
+		reference = append(reference, Interval{low, high, i})
+	}
+	for i := 0; i < 1000; i++ {
+		j := r.Intn(len(reference))
+		if !list.Delete(reference[j].Low, reference[j].High) {
+			t.Fatal("stored interval not found", reference[j])
+		}
+		//an equal interval stored earlier may have gone instead, so drop any one equal
+		for k := range reference {
+			if reference[k].Low == reference[j].Low && reference[k].High == reference[j].High {
+				reference = append(reference[:k], reference[k+1:]...)
+				break
+			}
+		}
+	}
+	if list.Delete(-5, -1) || list.Len() != len(reference) {
+		t.Fatal("wrong length after deletes", list.Len(), len(reference))
+	}
+
+	count := func(match func(Interval) bool) int {
+		n := 0
+		for _, iv := range reference {
+			if match(iv) {
+				n++
+			}
+		}
+		return n
+	}
+	for i := 0; i < 300; i++ {
+		point := r.Float64()*1600 - 50
+		stabbed := list.Stab(point)
+		if len(stabbed) != count(func(iv Interval) bool { return iv.Low <= point && point < iv.High }) {
+			t.Fatalf("Stab(%v) found %v intervals", point, len(stabbed))
+		}
+		for k, iv := range stabbed {
+			if !(iv.Low <= point && point < iv.High) || k > 0 && stabbed[k-1].Low > iv.Low {
+				t.Fatalf("Stab(%v) returned %v", point, iv)
+			}
+		}
+
+		hi := point + r.Float64()*30
+		overlapping := list.Overlaps(point, hi)
+		if len(overlapping) != count(func(iv Interval) bool { return iv.Low < hi && point < iv.High }) {
+			t.Fatalf("Overlaps(%v, %v) found %v intervals", point, hi, len(overlapping))
+		}
+	}
+
+	if got := list.Stab(0); len(got) > 0 && got[0].Value == nil {
+		t.Fatal("values must be kept")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("an empty interval must panic")
+		}
+	}()
+	list.Insert(3, 3, nil)
+}
+
+func TestIntervalBoundaries(t *testing.T) {
+	list := NewIntervalSkipList()
+	list.Insert(1, 2, "a")
+	list.Insert(2, 3, "b")
+	if got := list.Stab(2); len(got) != 1 || got[0].Value != "b" {
+		t.Fatal("intervals are half open", got)
+	}
+	if got := list.Overlaps(0, 1); len(got) != 0 {
+		t.Fatal("touching ranges do not overlap", got)
+	}
+	if got := list.Overlaps(1.5, 2.5); len(got) != 2 {
+		t.Fatal("wrong overlaps", got)
+	}
+}
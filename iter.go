@@ -0,0 +1,69 @@
+package jumplist
+
+import "iter"
+
+// All iterates over every key and value in key order, for use with range.
+// The read lock is held until the loop ends, so the loop body must not write to the list.
+func (list *SkipList) All() iter.Seq2[float64, interface{}] {
+	return func(yield func(float64, interface{}) bool) {
+		list.mutex.RLock()
+		defer list.mutex.RUnlock()
+
+		for column := list.startPointers.next[0]; column != nil; column = column.next[0] {
+			if !yield(column.key, column.Value) {
+				return
+			}
+		}
+	}
+}
+
+// From iterates like All, starting at the first key not less than key.
+func (list *SkipList) From(key float64) iter.Seq2[float64, interface{}] {
+	return func(yield func(float64, interface{}) bool) {
+		list.mutex.RLock()
+		defer list.mutex.RUnlock()
+
+		for column := list.seek(key); column != nil; column = column.next[0] {
+			if !yield(column.key, column.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward iterates like All in descending key order, following the level 0 back links.
+func (list *SkipList) Backward() iter.Seq2[float64, interface{}] {
+	return func(yield func(float64, interface{}) bool) {
+		list.mutex.RLock()
+		defer list.mutex.RUnlock()
+
+		for column := list.back(); column != nil; column = column.prev {
+			if !yield(column.key, column.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Filter iterates over the pairs of seq for which pred holds, such as Filter(list.All(), pred).
+func Filter[V any](seq iter.Seq2[float64, V], pred func(key float64, value V) bool) iter.Seq2[float64, V] {
+	return func(yield func(float64, V) bool) {
+		for key, value := range seq {
+			if pred(key, value) && !yield(key, value) {
+				return
+			}
+		}
+	}
+}
+
+// MapValues iterates over seq with every value replaced by fn(key, value). With a typed result it does
+// the type assertion once, so the loops consuming it work on T directly.
+func MapValues[V, T any](seq iter.Seq2[float64, V], fn func(key float64, value V) T) iter.Seq2[float64, T] {
+	return func(yield func(float64, T) bool) {
+		for key, value := range seq {
+			if !yield(key, fn(key, value)) {
+				return
+			}
+		}
+	}
+}
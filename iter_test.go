@@ -0,0 +1,76 @@
+package jumplist
+
+import "testing"
+
+func TestIterators(t *testing.T) {
+	list := New()
+	for i := 0; i < 100; i++ {
+		list.Set(float64(i), i)
+	}
+
+	n := 0
+	for k, v := range list.All() {
+		if k != float64(n) || v.(int) != n {
+			t.Fatalf("All yielded %v=%v at step %v", k, v, n)
+		}
+		n++
+	}
+	if n != 100 {
+		t.Fatalf("All yielded %v pairs, expected 100", n)
+	}
+
+	n = 0
+	for k := range list.From(89.5) {
+		if k != float64(90+n) {
+			t.Fatalf("From yielded %v at step %v", k, n)
+		}
+		n++
+	}
+	if n != 10 {
+		t.Fatalf("From yielded %v keys, expected 10", n)
+	}
+
+	n = 0
+	for k := range list.Backward() {
+		if k != float64(99-n) {
+			t.Fatalf("Backward yielded %v at step %v", k, n)
+		}
+		n++
+		if n == 5 {
+			break
+		}
+	}
+	list.Set(1000, nil) //breaking out must release the lock
+
+	for range New().Backward() {
+		t.Fatal("empty list must not yield")
+	}
+}
+
+func TestFilterMapValues(t *testing.T) {
+	list := New()
+	for i := 0; i < 100; i++ {
+		list.Set(float64(i), i)
+	}
+
+	even := Filter(list.From(50), func(key float64, value interface{}) bool { return value.(int)%2 == 0 })
+	squares := MapValues(even, func(key float64, value interface{}) int { return value.(int) * value.(int) })
+
+	n := 0
+	for key, square := range squares {
+		if int(key)%2 != 0 || key < 50 || square != int(key)*int(key) {
+			t.Fatalf("got %v=%v", key, square)
+		}
+		n++
+		if n == 10 {
+			break //stopping early reaches through both combinators
+		}
+	}
+	if n != 10 {
+		t.Fatalf("got %v pairs, expected 10", n)
+	}
+
+	for range Filter(list.All(), func(float64, interface{}) bool { return false }) {
+		t.Fatal("nothing passes the filter")
+	}
+}
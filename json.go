@@ -0,0 +1,127 @@
+package jumplist
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+type jsonItem struct {
+	Key     float64     `json:"key"`
+	Value   interface{} `json:"value"`
+	Codec   string      `json:"codec,omitempty"` //a value with a codec is left null and stored in data
+	Version uint32      `json:"version,omitempty"`
+	Data    []byte      `json:"data,omitempty"`
+}
+
+// newJSONItem encodes a pair, a value with a codec going into Data.
+func newJSONItem(key float64, value interface{}) (jsonItem, error) {
+	value, err := encodeValue(value)
+	if err != nil {
+		return jsonItem{}, err
+	}
+	if coded, ok := value.(codedValue); ok {
+		return jsonItem{Key: key, Codec: coded.Codec, Version: coded.Version, Data: coded.Data}, nil
+	}
+	return jsonItem{Key: key, Value: value}, nil
+}
+
+// value returns the value of the item, decoded by its codec if it names one.
+func (item jsonItem) value() (interface{}, error) {
+	if item.Codec == "" {
+		return item.Value, nil
+	}
+	return decodeValue(codedValue{item.Codec, item.Version, item.Data})
+}
+
+// MarshalJSON encodes the list as an array of {"key": k, "value": v} objects in key order.
+// A value with a codec, see RegisterValueCodec, is stored as {"key": k, "value": null, "codec": name,
+// "version": v, "data": base64 bytes} instead.
+func (list *SkipList) MarshalJSON() ([]byte, error) {
+	list.mutex.RLock()
+	items := make([]jsonItem, 0, list.length)
+	for column := list.startPointers.next[0]; column != nil; column = column.next[0] {
+		item, err := newJSONItem(column.key, column.Value)
+		if err != nil {
+			list.mutex.RUnlock()
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	list.mutex.RUnlock()
+
+	return json.Marshal(items)
+}
+
+// UnmarshalJSON replaces the content of the list with the objects of a JSON array as written by MarshalJSON.
+// Values are decoded the way encoding/json decodes into interface{}, so numbers come back as float64.
+// A zero SkipList is set up with the default level count first.
+func (list *SkipList) UnmarshalJSON(data []byte) error {
+	var items []jsonItem
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	for i, item := range items {
+		value, err := item.value()
+		if err != nil {
+			return err
+		}
+		items[i].Value = value
+	}
+
+	list.initZero()
+
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	list.clear()
+	for _, item := range items {
+		list.set(item.Key, item.Value)
+	}
+	return nil
+}
+
+// ExportNDJSON writes the objects of MarshalJSON to w one per line in key order, for line oriented
+// tools such as jq. The pairs are encoded one at a time under the read lock, so memory stays constant
+// however long the list is, and writers wait until the export is done.
+func (list *SkipList) ExportNDJSON(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	encoder := json.NewEncoder(bw)
+	err := list.StreamSortedTo(bw, func(_ io.Writer, key float64, value interface{}) error {
+		item, err := newJSONItem(key, value)
+		if err != nil {
+			return err
+		}
+		return encoder.Encode(item)
+	})
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// ImportNDJSON sets the pairs of the objects read from r as ExportNDJSON writes them, one object at a
+// time, so memory stays constant beyond what the list holds. Each pair is set like Set, taking the lock
+// on its own and over the current contents: Clear the list first to reload it. On error the pairs read
+// before stay set. Values are decoded like UnmarshalJSON does, numbers coming back as float64.
+func (list *SkipList) ImportNDJSON(r io.Reader) error {
+	list.initZero()
+
+	decoder := json.NewDecoder(r)
+	for n := 1; ; n++ {
+		var item jsonItem
+		err := decoder.Decode(&item)
+		if err == io.EOF {
+			return nil
+		}
+		var value interface{}
+		if err == nil {
+			value, err = item.value()
+		}
+		if err != nil {
+			return fmt.Errorf("jumplist: NDJSON object %d: %w", n, err)
+		}
+		list.Set(item.Key, value)
+	}
+}
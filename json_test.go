@@ -0,0 +1,88 @@
+package jumplist
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	list := New()
+	list.Set(3, "c")
+	list.Set(1, 1.5)
+	list.Set(2, []interface{}{"x", true})
+
+	data, err := json.Marshal(list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `[{"key":1,"value":1.5},{"key":2,"value":["x",true]},{"key":3,"value":"c"}]`
+	if string(data) != expected {
+		t.Fatalf("marshalled %s, expected %s", data, expected)
+	}
+
+	var state struct {
+		List SkipList `json:"list"`
+	}
+	if err := json.Unmarshal([]byte(`{"list":`+string(data)+`}`), &state); err != nil {
+		t.Fatal(err)
+	}
+	decoded := &state.List
+	checkSanity(decoded, t)
+	if decoded.Len() != 3 || decoded.Get(1).Value.(float64) != 1.5 || decoded.Get(3).Value != "c" {
+		t.Fatal("round trip must keep the pairs", decoded.Items())
+	}
+	decoded.Set(4, nil)
+	checkSanity(decoded, t)
+
+	existing := New()
+	existing.Set(100, nil)
+	if err := json.Unmarshal([]byte(`[{"key":2,"value":null},{"key":1,"value":"a"}]`), existing); err != nil {
+		t.Fatal(err)
+	}
+	checkSanity(existing, t)
+	if keys := existing.Keys(); len(keys) != 2 || keys[0] != 1 || keys[1] != 2 {
+		t.Fatal("unmarshalling must replace the content", keys)
+	}
+
+	if err := json.Unmarshal([]byte(`{"key":1}`), existing); err == nil {
+		t.Fatal("an object instead of an array must fail")
+	}
+	if existing.Len() != 2 {
+		t.Fatal("a failed unmarshal must leave the list alone")
+	}
+}
+
+func TestNDJSON(t *testing.T) {
+	list := New()
+	list.Set(3, "c")
+	list.Set(1, 1.5)
+	list.Set(2, []interface{}{"x", true})
+	list.Set(4, nil)
+
+	var buf bytes.Buffer
+	if err := list.ExportNDJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 4 || lines[0] != `{"key":1,"value":1.5}` || lines[2] != `{"key":3,"value":"c"}` {
+		t.Fatalf("ExportNDJSON wrote %q", buf.String())
+	}
+
+	loaded := New()
+	loaded.Set(0, "kept") //imports go over the current contents
+	if err := loaded.ImportNDJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Len() != 5 || loaded.Get(0).Value != "kept" || loaded.Get(1).Value != 1.5 ||
+		!reflect.DeepEqual(loaded.Get(2).Value, []interface{}{"x", true}) || loaded.Get(4).Value != nil {
+		t.Fatal("ImportNDJSON loaded", loaded.Items())
+	}
+
+	err := loaded.ImportNDJSON(strings.NewReader(`{"key":7,"value":"a"}` + "\n" + `{"key":"x"}`))
+	if err == nil || !strings.Contains(err.Error(), "object 2") || !loaded.Contains(7) {
+		t.Fatal("a bad object must stop the import, keeping the objects before, got", err)
+	}
+}
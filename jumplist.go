@@ -2,138 +2,633 @@ package jumplist
 
 import (
 	"math"
+	"math/bits"
 	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type pointerColumn struct {
 	next []*Column
+	span []int     //level 0 steps to next[i], or to the end of the list when next[i] is nil
+	sum  []float64 //weights of the columns span steps over, nil without WithWeights
 }
 
 type Column struct {
 	pointerColumn
+	prev  *Column //previous column on level 0
 	key   float64
-	Value interface{}
+	seq   uint64      //of the last insert or update, see ChangedSince
+	Value interface{} //written under the write lock, read it with LoadValue while others may write
+}
+
+// Key returns the key the column is ordered by. The value is the exported Value field.
+func (column *Column) Key() float64 {
+	return column.key
+}
+
+// Level is the number of levels this column takes part in.
+func (column *Column) Level() int {
+	return len(column.next)
+}
+
+// Prev returns the column with the preceding key, nil at the front.
+func (column *Column) Prev() *Column {
+	return column.prev
+}
+
+// Next returns the column with the following key, nil at the end.
+// Like the other column methods it does not lock, so writers must be kept out while walking.
+func (column *Column) Next() *Column {
+	return column.next[0]
+}
+
+// Neighbors returns the columns up to radius positions before and after column, itself included, in key
+// order, such as the entries around a player on a leaderboard. Fewer come back near the ends of the list.
+// It walks the level 0 links in O(radius) without locking, like Next.
+func (column *Column) Neighbors(radius int) []*Column {
+	if radius < 0 {
+		panic("radius must not be negative")
+	}
+	first, before := column, 0
+	for before < radius && first.prev != nil {
+		first = first.prev
+		before++
+	}
+
+	neighbors := make([]*Column, 0, before+1+radius)
+	for c := first; c != nil && len(neighbors) <= before+radius; c = c.next[0] {
+		neighbors = append(neighbors, c)
+	}
+	return neighbors
 }
 
 type SkipList struct {
+	approxLen   int64 //atomic copy of length, kept first for 64-bit alignment
+	fingerSeeks int64 //atomic counters of Finger.Seek, see Stats
+	fingerHits  int64
+
 	startPointers pointerColumn
-	maxLevel      int
+	maxLevel      int //levels in use, grown with the length up to levelCap
+	levelCap      int //see WithMaxLevel
+	growAt        int //length past which another level is added
 	randomSeed    rand.Source
-	probabilities []float64
-	mutex         sync.RWMutex
-	levelCursors  []*pointerColumn
+	seedMutex     sync.Mutex //serializes the draws from randomSeed made under the read lock, see sibling
+	probability   float64    //ratio between the column counts of neighbouring levels
+	levels        levelDist
+	mutex         Locker
+	levelCursors  []*pointerColumn //predecessors on each level, written by writers only, readers descend with seek
+	cursorRanks   []int            //rank of each cursor, the start pointers being 0
+	cursorColumn  *Column          //column owning levelCursors[0], nil for the start pointers
+	tail          *tails           //end of every level, kept by Append until the next resize
+	length        int              //guarded by mutex
+	version       uint64           //bumped by every change to the links, so cursors and fingers notice they are stale
+	seq           uint64           //sequence number of the last insert or update
+
+	tracer    func(op string, key float64, level int, found bool)
+	lru       *lruState //recency or insertion order for EvictLeastRecent, EvictOldest and EvictIdle
+	ttl       *ttlState //deadlines of columns set with SetWithTTL
+	arena     *Arena
+	ownsArena bool           //the arena is reset with the list, see WithOwnArena
+	pool      *[64]sync.Pool //removed columns by level, see WithPooling
+	watchers  []*watcher     //see Watch
+	changes   uint64         //number of the last notified change, see Changes
+	onInsert  func(column *Column)
+	onUpdate  func(column *Column, old interface{})
+	onRemove  func(column *Column)
+	onExpire  func(column *Column)
+	pending   []func() //hooks to run once the write lock is released
+	metrics   *metrics //see WithMetrics
+
+	valueKey   func(value interface{}) string //see WithValueIndex
+	valueIndex map[string]*Column
+	bloom      *bloomFilter                    //see WithBloomFilter
+	metadata   map[*Column]Metadata            //see WithMetadata
+	aggregates *aggregateState                 //see WithAggregates
+	weigher    func(value interface{}) float64 //see WithWeights
+	nonEmpty   *sync.Cond                      //signalled on growth for WaitPopMin, created by the first wait
+
+	capacity int //bounded when positive, see NewWithCapacity
+	policy   EvictPolicy
+	onEvict  func(key float64, value interface{})
+
+	duplicates bool                        //multiset mode, see AllowDuplicates
+	ties       TieOrder                    //see WithTieOrder
+	tieLess    func(a, b interface{}) bool //see WithTieBreaker
+	epsilon    float64                     //see WithEpsilon
+	less       func(a, b float64) bool     //order of the keys, numeric when nil, see WithComparator
+
+	sampleCap  int //sampling mode when positive, see NewSampled
+	sampleLen  int
+	sampleSeen int64
 }
 
 func (list *SkipList) moveCursors(key float64) {
+	list.moveCursorsTo(key, false)
+}
+
+// moveCursorsTo stops before the first column with key, or after the last one if past is set.
+func (list *SkipList) moveCursorsTo(key float64, past bool) {
 	pointerColumn := &list.startPointers
+	var column *Column
+	rank := 0
 
 	for i := list.maxLevel - 1; i >= 0; i-- { //move from the top
 		nextColumn := pointerColumn.next[i]
 
-		for nextColumn != nil && key > nextColumn.key {
+		for nextColumn != nil && (list.keyLess(nextColumn.key, key) || past && key == nextColumn.key) {
+			rank += pointerColumn.span[i]
+			column = nextColumn
 			pointerColumn = &nextColumn.pointerColumn //result if it is the end
 			nextColumn = nextColumn.next[i]           //keep move to the right
 		}
 		list.levelCursors[i] = pointerColumn //this is to save fingers
+		list.cursorRanks[i] = rank
+	}
+	list.cursorColumn = column
+}
+
+// advanceCursorsTo works like moveCursorsTo for a key not less than the one the cursors were
+// moved to last, starting from the cursors instead of the start pointers.
+func (list *SkipList) advanceCursorsTo(key float64, past bool) {
+	var pointerColumn *pointerColumn
+	var column *Column
+	rank := -1
+
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		if list.cursorRanks[i] >= rank { //the old cursor on this level is at least as far right
+			pointerColumn, rank = list.levelCursors[i], list.cursorRanks[i]
+			if i == 0 {
+				column = list.cursorColumn
+			}
+		}
+		nextColumn := pointerColumn.next[i]
+
+		for nextColumn != nil && (list.keyLess(nextColumn.key, key) || past && key == nextColumn.key) {
+			rank += pointerColumn.span[i]
+			column = nextColumn
+			pointerColumn = &nextColumn.pointerColumn
+			nextColumn = nextColumn.next[i]
+		}
+		list.levelCursors[i] = pointerColumn
+		list.cursorRanks[i] = rank
 	}
+	list.cursorColumn = column
 }
 
+// Set inserts key or overwrites its value and returns the column. Keys are totally ordered with -Inf
+// first and +Inf last. NaN compares false with every key, so setting it panics instead of breaking the order.
 func (list *SkipList) Set(key float64, value interface{}) *Column {
+	list.checkUnsampled("Set")
+	if column, _, ok := list.putStriped(key, value); ok {
+		return column
+	}
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	return list.set(key, value)
+}
+
+func (list *SkipList) set(key float64, value interface{}) *Column {
+	column, _, _ := list.put(key, value)
+	return column
+}
+
+// Put is Set also returning the value it overwrote and whether there was one, so callers need no Get before.
+// In multisets a Put always inserts, so updated is always false.
+func (list *SkipList) Put(key float64, value interface{}) (column *Column, prev interface{}, updated bool) {
+	list.checkUnsampled("Put")
+	if column, prev, ok := list.putStriped(key, value); ok {
+		return column, prev, true
+	}
 	list.mutex.Lock()
 	defer list.mutex.Unlock()
 
+	return list.put(key, value)
+}
+
+func (list *SkipList) put(key float64, value interface{}) (*Column, interface{}, bool) {
+	key = list.snap(key)
+	if list.duplicates {
+		list.moveCursorsTo(key, list.tiesLast()) //after the equal keys in FIFO order, before them otherwise
+		list.stepTies(key, value)
+		column := list.insertAtCursors(key, value)
+		list.trace("Set", key, column, false)
+		return column, nil, false
+	}
+
 	list.moveCursors(key)
 	column := list.levelCursors[0].next[0]  //bottom layer
-	if column != nil && column.key <= key { //check if successfully get
+	if column != nil && column.key == key { //check if successfully get
+		old := column.Value
 		column.Value = value
-		return column
+		list.dropExpiry(column)
+		list.touch(column)
+		list.notify(EventUpdate, column, old)
+		list.trace("Set", key, column, true)
+		return column, old, true
 	}
 
 	//not exists, so create a column
-	column = &Column{pointerColumn{make([]*Column, list.randLevel())}, key, value}
+	column = list.insertAtCursors(key, value)
+	list.trace("Set", key, column, false)
+	return column, nil, false
+}
+
+func (list *SkipList) trace(op string, key float64, column *Column, found bool) {
+	if list.tracer == nil {
+		return
+	}
+	level := 0
+	if column != nil {
+		level = column.Level()
+	}
+	list.tracer(op, key, level, found)
+}
+
+// insertAtCursors links a new column right after the cursors left by moveCursors(key).
+// The cursors may be moved again afterwards if an LRU eviction is needed.
+func (list *SkipList) insertAtCursors(key float64, value interface{}) *Column {
+	column := list.newColumn(list.randLevel(), key, value)
+	prevRank := list.cursorRanks[0] //the new column ranks right after it
 
 	//set column next and previous column next
 	for i := range column.next { //remember that resultPointers[i].next[i] is the previous column
 		column.next[i] = list.levelCursors[i].next[i] // resultPointers[i].next[i] is the future next
 		list.levelCursors[i].next[i] = column         //update resultPointers[i].next[i] to new column
+
+		//split the span of the cursor around the new column
+		column.span[i] = list.levelCursors[i].span[i] - (prevRank - list.cursorRanks[i])
+		list.levelCursors[i].span[i] = prevRank - list.cursorRanks[i] + 1
+	}
+	for i := len(column.next); i < list.maxLevel; i++ {
+		list.levelCursors[i].span[i]++ //one more column to step over
 	}
+	column.prev = list.cursorColumn
+	if column.next[0] != nil {
+		column.next[0].prev = column
+	}
+	list.reweighCursors(column)
 
+	list.resize(1)
+	list.touch(column)
+	list.notify(EventInsert, column, nil)
+	list.evict()
 	return column
 }
 
-func (list *SkipList) Get(key float64) *Column {
-	list.mutex.Lock()
-	defer list.mutex.Unlock()
+// resize changes the column count, every link and unlink goes through it while holding the write lock.
+// It drops the tails kept by Append, which are only valid as long as nothing else changed the list.
+func (list *SkipList) resize(delta int) {
+	list.tail = nil
+	list.version++
+	list.length += delta
+	if list.length > list.growAt {
+		list.reserve(list.length)
+	}
+	if list.nonEmpty != nil && list.length > 0 {
+		list.nonEmpty.Broadcast()
+	}
+	atomic.StoreInt64(&list.approxLen, int64(list.length))
+}
 
-	pointers := &list.startPointers
-	var next *Column
+// Get only takes the read lock, so lookups run in parallel, unless an LRU list has to record the access.
+func (list *SkipList) Get(key float64) *Column {
+	if list.lru != nil && !list.lru.insertionOrder {
+		list.mutex.Lock()
+		defer list.mutex.Unlock()
+	} else {
+		list.rlockKeys()
+		defer list.runlockKeys()
+	}
 
-	for i := list.maxLevel - 1; i >= 0; i-- { // from the top level to the bottom level
-		next = pointers.next[i]
+	return list.get(key)
+}
 
-		for next != nil && key > next.key { // if key > storage of this column, move to the right
-			pointers = &next.pointerColumn
-			next = next.next[i]
-		} //move down again
+// get is Get under the lock Get takes, the write lock for EvictLeastRecent.
+func (list *SkipList) get(key float64) *Column {
+	key = list.snap(key)
+	if list.bloom != nil && !list.bloom.mayContain(key) {
+		list.countGet(false)
+		list.trace("Get", key, nil, false)
+		return nil
 	}
+	next := list.unexpired(list.seek(key), key) //read only descent, the cursors belong to writers
 
-	if next != nil && next.key == key {
+	if next != nil {
+		list.touch(next)
+		list.countGet(true)
+		list.trace("Get", key, next, true)
 		return next
 	}
 
+	list.countGet(false)
+	list.trace("Get", key, nil, false)
 	return nil
 }
 
+// GetValue is Get returning the value and whether key is present, with no column to nil check.
+func (list *SkipList) GetValue(key float64) (interface{}, bool) {
+	if column := list.Get(key); column != nil {
+		return column.Value, true
+	}
+	return nil, false
+}
+
+// Contains reports whether key is present under the read lock. Unlike Get it does not count as a use
+// for EvictLeastRecent, so checking membership never reorders or blocks an LRU list.
+func (list *SkipList) Contains(key float64) bool {
+	list.rlockKeys()
+	defer list.runlockKeys()
+
+	key = list.snap(key)
+	if list.bloom != nil && !list.bloom.mayContain(key) {
+		list.countGet(false)
+		return false
+	}
+	found := list.unexpired(list.seek(key), key) != nil
+	list.countGet(found)
+	return found
+}
+
+func (list *SkipList) newColumn(level int, key float64, value interface{}) *Column {
+	checkKey(key) //before anything is linked
+	var column *Column
+	if list.arena != nil {
+		column = list.arena.column(level)
+	} else if column = list.pooled(level); column == nil {
+		column = &Column{pointerColumn: pointerColumn{next: make([]*Column, level), span: make([]int, level)}}
+	}
+	column.key, column.Value = key, value
+	if list.weigher != nil {
+		column.sum = resized(column.sum, level)
+	}
+	return column
+}
+
+// checkKey panics on NaN, every column is created through it.
+func checkKey(key float64) {
+	if key != key {
+		panic(errNaNKey)
+	}
+}
+
 func (list *SkipList) Del(key float64) *Column {
 	list.mutex.Lock()
 	defer list.mutex.Unlock()
 
+	column := list.del(list.snap(key))
+	list.trace("Del", key, column, column != nil)
+	return column
+}
+
+func (list *SkipList) del(key float64) *Column {
 	list.moveCursors(key)
+	if column := list.levelCursors[0].next[0]; column != nil && column.key == key { //value found
+		return list.unlinkAtCursors()
+	}
+	return nil
+}
+
+// unlinkAtCursors removes the column right after the cursors, which stay valid for a later key.
+func (list *SkipList) unlinkAtCursors() *Column {
 	column := list.levelCursors[0].next[0]
+	for k, v := range column.next { //found next column (which is results[0].next[0].next[k])
+		list.levelCursors[k].next[k] = v //modify current column to next-next
+		list.levelCursors[k].span[k] += column.span[k] - 1
+	}
+	for k := len(column.next); k < list.maxLevel; k++ {
+		list.levelCursors[k].span[k]--
+	}
+	if column.next[0] != nil {
+		column.next[0].prev = column.prev
+	}
+	list.reweighCursors(nil)
 
-	if column != nil && column.key <= key { //value found
-		for k, v := range column.next { //found next column (which is results[0].next[0].next[k])
-			list.levelCursors[k].next[k] = v //modify current column to next-next
-		}
+	list.resize(-1)
+	list.forget(column)
+	list.release(column)
+	return column
+}
 
-		return column
+// stepCursors moves the cursors over the column right after them, which becomes their predecessor on its levels.
+func (list *SkipList) stepCursors() {
+	column := list.levelCursors[0].next[0]
+	rank := list.cursorRanks[0] + 1
+	for i := range column.next {
+		list.levelCursors[i], list.cursorRanks[i] = &column.pointerColumn, rank
 	}
-
-	return nil
+	list.cursorColumn = column
 }
 
 func (list *SkipList) randLevel() int {
-	r := float64(list.randomSeed.Int63()) / (1 << 63) // https://golang.org/src/math/rand/rand.go#L178
+	return randLevel(list.randomSeed, list.levels)
+}
+
+// levelDist holds the chance to grow past each level, scaled to the range of Int63 so a draw is
+// compared without converting it to a float. Past 2^-32 the scaled chances lose precision and past
+// 2^-63 they vanish, so the thresholds cover the window of levels resolved well and randLevel draws
+// again for the levels above: the chance to grow further does not depend on the height reached.
+type levelDist struct {
+	max        int              //levels a column may have
+	thresholds []int64          //chance to grow past level i+1 of the window
+	buckets    *[64]levelBucket //by leading zero bits of the draw, nil for ratios above 1/2
+}
+
+// levelBucket covers the draws with the same number of leading zero bits, which span a factor of 2.
+// With a ratio of at most 1/2 between levels, at most one threshold falls inside it.
+type levelBucket struct {
+	level int   //level of the largest draw in the bucket
+	cut   int64 //the threshold inside the bucket, draws up to it grow one more level, -1 if there is none
+}
+
+// randLevel draws a column height, one Int63 per window of levels the column grows through.
+func randLevel(randomSeed rand.Source, dist levelDist) int {
+	window := len(dist.thresholds)
+	for height := 0; ; height += window {
+		level := dist.draw(randomSeed.Int63())
+		if level <= window || height+window >= dist.max {
+			return min(height+level, dist.max)
+		}
+	}
+}
 
-	for level, prob := range list.probabilities {
-		if r > prob {
+// draw returns the level within the window a draw r reaches, one past the window if it passes every
+// threshold. The leading zero bits of r pick a bucket, which leaves at most one comparison to make.
+func (dist levelDist) draw(r int64) int {
+	if dist.buckets != nil {
+		bucket := dist.buckets[bits.LeadingZeros64(uint64(r))&63] //64 zero bits, a zero draw, wraps to bucket 0
+		if r <= bucket.cut {
+			return bucket.level + 1
+		}
+		return bucket.level
+	}
+	for level, threshold := range dist.thresholds {
+		if r > threshold {
 			return level + 1
 		}
 	}
-	return list.maxLevel
+	return len(dist.thresholds) + 1
 }
 
-func NewWithLevel(level int) *SkipList {
+// levelResolution is the smallest scaled chance a threshold may have, leaving 32 bits of precision.
+const levelResolution = 1 << 32
+
+// newLevelDist returns the level distribution for level levels, p being the ratio between levels.
+func newLevelDist(level int, p float64) levelDist {
 	if level < 1 || level > 64 {
 		panic("level must be 1~64")
 	}
-	probabilities := []float64{}
+	if !(p > 0 && p < 1) {
+		panic("probability must be between 0 and 1")
+	}
+	dist := levelDist{max: level}
 	prob := 1.0
-	for i := 1; i <= level; i++ {
-		prob /= math.E
-		probabilities = append(probabilities, prob)
+	for len(dist.thresholds) < level {
+		prob *= p
+		threshold := int64(prob * (1 << 63))
+		if threshold < levelResolution && len(dist.thresholds) > 0 {
+			break
+		}
+		dist.thresholds = append(dist.thresholds, threshold)
+	}
+	if p > 0.5 {
+		return dist
 	}
-	return &SkipList{
-		startPointers: pointerColumn{next: make([]*Column, level)},
-		levelCursors:  make([]*pointerColumn, level),
-		maxLevel:      level,
-		randomSeed:    rand.New(rand.NewSource(time.Now().UnixNano())),
-		probabilities: probabilities,
+
+	window := len(dist.thresholds)
+	dist.buckets = &[64]levelBucket{{level: window, cut: int64(math.MaxInt64)}} //a zero draw is below every threshold
+	for zeros := 1; zeros < 64; zeros++ {
+		top, bottom := int64(1)<<(64-zeros)-1, int64(1)<<(63-zeros)
+		if zeros == 63 {
+			bottom = 1
+		}
+		passed := 0 //thresholds the largest draw of the bucket does not exceed
+		for passed < window && top <= dist.thresholds[passed] {
+			passed++
+		}
+		bucket := levelBucket{level: passed + 1, cut: -1}
+		if passed < window && dist.thresholds[passed] >= bottom {
+			bucket.cut = dist.thresholds[passed]
+		}
+		dist.buckets[zeros] = bucket
+	}
+	return dist
+}
+
+// initialLevels is the height a list starts at, enough for (1/p)^4 columns, 54 with the default 1/e.
+const initialLevels = 4
+
+// setLevels caps the list at level levels with ratio p and sizes the start pointers and cursors
+// for the initial height. The list must be empty.
+func (list *SkipList) setLevels(level int, p float64) {
+	newLevelDist(level, p) //panics on a bad level or p
+	list.levelCap, list.probability = level, p
+
+	height := min(level, initialLevels)
+	list.levels = newLevelDist(height, p)
+	list.maxLevel = height
+	list.startPointers = pointerColumn{next: make([]*Column, height), span: make([]int, height)}
+	if list.weigher != nil {
+		list.startPointers.sum = make([]float64, height)
+	}
+	list.levelCursors = make([]*pointerColumn, height)
+	list.cursorRanks = make([]int, height)
+	list.growAt = list.growthAt(height)
+}
+
+// reserve raises the list to the height n columns need, staying within levelCap.
+func (list *SkipList) reserve(n int) {
+	if height := min(levelsFor(list.probability, n), list.levelCap); height > list.maxLevel {
+		list.raise(height)
+	}
+}
+
+// raise adds empty levels on top until the list is height levels tall. Columns keep their levels,
+// new ones are drawn up to the new height. The new cursors start at the start pointers.
+func (list *SkipList) raise(height int) {
+	for list.maxLevel < height {
+		list.startPointers.next = append(list.startPointers.next, nil)
+		list.startPointers.span = append(list.startPointers.span, list.length)
+		if list.weigher != nil {
+			list.startPointers.sum = append(list.startPointers.sum, 0)
+			list.reweigh(&list.startPointers, list.maxLevel)
+		}
+		list.levelCursors = append(list.levelCursors, &list.startPointers)
+		list.cursorRanks = append(list.cursorRanks, 0)
+		list.maxLevel++
+	}
+	list.levels = newLevelDist(height, list.probability)
+	list.growAt = list.growthAt(height)
+}
+
+// growthAt returns the length past which height levels are too few, (1/p)^height.
+func (list *SkipList) growthAt(height int) int {
+	n := math.Pow(1/list.probability, float64(height))
+	if height >= list.levelCap || n >= math.MaxInt {
+		return math.MaxInt
 	}
+	return int(n)
+}
+
+// NewWithLevel is New with WithMaxLevel(level) applied first.
+func NewWithLevel(level int, opts ...Option) *SkipList {
+	return New(append([]Option{WithMaxLevel(level)}, opts...)...)
+}
+
+// NewWithProbability returns a list with probability p between levels and just enough levels to stay
+// fast up to expectedN columns, log base 1/p of expectedN rounded up. NewWithProbability(1/math.E, 65659969)
+// is New. Options are applied afterwards.
+func NewWithProbability(p float64, expectedN int, opts ...Option) *SkipList {
+	return New(append([]Option{WithProbability(p), WithMaxLevel(levelsFor(p, expectedN))}, opts...)...)
+}
+
+// levelsFor returns the levels for n columns with probability p, clamped to the 1 to 64 supported.
+func levelsFor(p float64, n int) int {
+	if n < 2 || !(p > 0 && p < 1) {
+		return 1 //a bad p is reported by WithProbability
+	}
+	level := math.Ceil(math.Log(float64(n))/math.Log(1/p) - 1e-9) //exact powers must not round up
+	return int(math.Min(math.Max(level, 1), 64))
+}
+
+// New returns an empty list with 18 levels and a ratio of 1/e between them, e^18 = 65659969,
+// changed by the options in order.
+func New(opts ...Option) *SkipList {
+	list := &SkipList{}
+	list.initZero()
+	for _, opt := range opts {
+		opt(list)
+	}
+	if list.less != nil && list.epsilon > 0 {
+		panic("WithEpsilon needs the numeric order of keys, not WithComparator")
+	}
+	if list.hooked() {
+		list.mutex = &hookLocker{list.mutex, list}
+	}
+	return list
+}
+
+// initZero sets up a zero SkipList, as found in a struct being decoded into, with the default settings.
+func (list *SkipList) initZero() {
+	if list.maxLevel == 0 {
+		list.mutex = &sync.RWMutex{}
+		list.randomSeed = rand.New(rand.NewSource(time.Now().UnixNano()))
+		list.setLevels(18, 1/math.E)
+	}
+}
+
+// Len returns the number of columns in O(1).
+func (list *SkipList) Len() int {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.length
 }
 
-func New() *SkipList {
-	return NewWithLevel(18) //e^18 = 65659969
+// ApproxLen returns the number of columns without taking the lock, so it never waits on writers.
+// The count is published after each change, so it may lag the operations still in flight.
+func (list *SkipList) ApproxLen() int {
+	return int(atomic.LoadInt64(&list.approxLen))
 }
@@ -2,7 +2,10 @@ package jumplist
 
 import (
 	"fmt"
+	"math"
+	"math/rand"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"unsafe"
 )
@@ -24,6 +27,41 @@ func init() {
 }
 
 func checkSanity(list *SkipList, t *testing.T) {
+	if err := list.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	// level 0 must link back to the previous column
+	var prev *Column
+	for c := list.startPointers.next[0]; c != nil; c = c.next[0] {
+		if c.prev != prev {
+			t.Fatalf("column %v links back to %v instead of %v", c.key, c.prev, prev)
+		}
+		prev = c
+	}
+
+	// spans must count the level 0 steps to the next column, or to the end of the list
+	rank := map[*pointerColumn]int{&list.startPointers: 0}
+	total := 0
+	for c := list.startPointers.next[0]; c != nil; c = c.next[0] {
+		total++
+		rank[&c.pointerColumn] = total
+	}
+	if total != list.length {
+		t.Fatalf("list holds %v columns, length is %v", total, list.length)
+	}
+	for pointers, r := range rank {
+		for i, next := range pointers.next {
+			expected := total - r
+			if next != nil {
+				expected = rank[&next.pointerColumn] - r
+			}
+			if pointers.span[i] != expected {
+				t.Fatalf("span %v of the column at rank %v is %v, expected %v", i, r, pointers.span[i], expected)
+			}
+		}
+	}
+
 	// each level must be correctly ordered
 	for k, v := range list.startPointers.next {
 		//t.Log("Level", k)
@@ -80,7 +118,7 @@ func TestBasicIntCRUD(t *testing.T) {
 	v5 := list.Get(90)
 	v6 := list.Get(0)
 
-	if v1 == nil || v1.Value.(int) != 1 || v1.key != 10 {
+	if v1 == nil || v1.Value.(int) != 1 || v1.Key() != 10 {
 		t.Fatal(`wrong "10" value (expected "1")`, v1)
 	}
 
@@ -103,6 +141,31 @@ func TestBasicIntCRUD(t *testing.T) {
 	if v6 != nil {
 		t.Fatal(`found value for key "0", which should have been deleted`)
 	}
+
+	if list.Len() != 4 {
+		t.Fatalf("wrong length %v (expected 4)", list.Len())
+	}
+}
+
+func TestLen(t *testing.T) {
+	list := New()
+	if list.Len() != 0 {
+		t.Fatal("new list must be empty")
+	}
+
+	for i := 0; i < 100; i++ {
+		list.Set(float64(i%50), i) //every key is set twice
+	}
+	if list.Len() != 50 {
+		t.Fatalf("wrong length %v after overwrites (expected 50)", list.Len())
+	}
+
+	for i := 0; i < 60; i++ {
+		list.Del(float64(i)) //10 of them are missing
+	}
+	if list.Len() != 0 {
+		t.Fatalf("wrong length %v after deleting everything (expected 0)", list.Len())
+	}
 }
 
 func TestMaxLevel(t *testing.T) {
@@ -112,6 +175,118 @@ func TestMaxLevel(t *testing.T) {
 	list2.Set(0, struct{}{})
 }
 
+func TestContainsGetValue(t *testing.T) {
+	list := New()
+	list.Set(1, "one")
+	list.Set(2, nil)
+
+	if !list.Contains(1) || !list.Contains(2) || list.Contains(3) {
+		t.Fatal("wrong membership")
+	}
+	if v, ok := list.GetValue(1); !ok || v != "one" {
+		t.Fatal("wrong value", v, ok)
+	}
+	if v, ok := list.GetValue(2); !ok || v != nil {
+		t.Fatal("a nil value must be found", v, ok)
+	}
+	if _, ok := list.GetValue(3); ok {
+		t.Fatal("a missing key must not be found")
+	}
+
+	lru := NewLRU(18, 2)
+	lru.Set(1, nil)
+	lru.Set(2, nil)
+	lru.Contains(1) //not a use, so 1 stays the least recent
+	lru.Set(3, nil)
+	if lru.Contains(1) || !lru.Contains(2) {
+		t.Fatal("Contains must not touch the LRU order")
+	}
+}
+
+func TestPut(t *testing.T) {
+	list := New()
+	if c, prev, updated := list.Put(1, "one"); c == nil || c.Value != "one" || prev != nil || updated {
+		t.Fatal("a new key must not report an update", prev, updated)
+	}
+	if c, prev, updated := list.Put(1, "uno"); c.Value != "uno" || prev != "one" || !updated {
+		t.Fatal("an overwrite must return the previous value", prev, updated)
+	}
+	if _, prev, updated := list.Put(1, nil); prev != "uno" || !updated || list.Len() != 1 {
+		t.Fatal("wrong overwrite", prev, updated)
+	}
+
+	multi := New(AllowDuplicates())
+	multi.Put(1, "a")
+	if _, _, updated := multi.Put(1, "b"); updated || multi.Len() != 2 {
+		t.Fatal("multisets must insert equal keys")
+	}
+}
+
+func TestInfiniteAndNaNKeys(t *testing.T) {
+	list := New()
+	for _, key := range []float64{0, math.Inf(1), -5, math.Inf(-1), math.MaxFloat64} {
+		list.Set(key, nil)
+	}
+	checkSanity(list, t)
+	if list.Front().Key() != math.Inf(-1) || list.Back().Key() != math.Inf(1) || list.Get(math.Inf(1)) == nil {
+		t.Fatal("infinities must be the smallest and largest keys")
+	}
+	if list.Get(math.NaN()) != nil || list.Del(math.NaN()) != nil {
+		t.Fatal("NaN must never be found")
+	}
+
+	for name, insert := range map[string]func(){
+		"Set":       func() { list.Set(math.NaN(), nil) },
+		"Append":    func() { list.Append(math.NaN(), nil) },
+		"SetBatch":  func() { list.SetBatch([]KV{{math.NaN(), nil}}) },
+		"UpdateKey": func() { list.UpdateKey(0, math.NaN()) },
+		"Parallel":  func() { NewParallel(18, 2, []float64{1, math.NaN()}, []interface{}{nil, nil}) },
+	} {
+		func() {
+			defer func() {
+				if recover() != errNaNKey {
+					t.Fatalf("%v must panic on a NaN key", name)
+				}
+			}()
+			insert()
+		}()
+	}
+	checkSanity(list, t)
+	if list.Len() != 5 || list.Get(0) == nil {
+		t.Fatal("a rejected NaN must leave the list alone", list.Len())
+	}
+}
+
+func TestLevelGrowth(t *testing.T) {
+	list := New()
+	if list.maxLevel != initialLevels || len(list.startPointers.next) != initialLevels {
+		t.Fatal("a new list must start low", list.maxLevel)
+	}
+
+	c := list.NewFinger()
+	for i := 0; i < 20000; i++ {
+		list.Set(float64(i), nil)
+		if i == 1000 {
+			checkSanity(list, t)
+		}
+	}
+	checkSanity(list, t)
+	if list.maxLevel != 10 || list.levelCap != 18 {
+		t.Fatal("the list must grow with its length", list.maxLevel)
+	}
+	if c.Seek(1234) == nil || list.Get(19999) == nil {
+		t.Fatal("lookups must reach every level")
+	}
+
+	list.Clear()
+	if list.maxLevel != initialLevels {
+		t.Fatal("an emptied list must start low again", list.maxLevel)
+	}
+	if small := NewWithLevel(2); small.maxLevel != 2 {
+		t.Fatal("the height must stay within the cap", small.maxLevel)
+	}
+}
+
 func TestConcurrency(t *testing.T) {
 	list := New()
 
@@ -135,6 +310,200 @@ func TestConcurrency(t *testing.T) {
 
 }
 
+// run with -race to check the locking of mixed workloads
+func TestConcurrentMixed(t *testing.T) {
+	list := New()
+	for i := 0; i < 1000; i++ {
+		list.Set(float64(i), i)
+	}
+
+	wg := &sync.WaitGroup{}
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 20000; i++ {
+				key := float64((i * (w + 1)) % 2000)
+				switch w % 4 {
+				case 0:
+					list.Set(key, i)
+				case 1:
+					list.Del(key)
+				default:
+					if c := list.Get(key); c != nil && c.Key() != key {
+						t.Errorf("Get(%v) returned %v", key, c.Key())
+						return
+					}
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	checkSanity(list, t)
+	cnt := 0
+	for c := list.Front(); c != nil; c = c.Next() {
+		cnt++
+	}
+	if cnt != list.Len() {
+		t.Fatalf("walked %v columns, Len is %v", cnt, list.Len())
+	}
+}
+
+func TestApproxLen(t *testing.T) {
+	list := New()
+	var started, done int64
+
+	wg := &sync.WaitGroup{}
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			for i := 0; i < 20000; i++ {
+				atomic.AddInt64(&started, 1)
+				list.Set(float64(i*4+w), nil)
+				atomic.AddInt64(&done, 1)
+			}
+			wg.Done()
+		}(w)
+	}
+
+	stop := make(chan struct{})
+	polled := make(chan error)
+	go func() {
+		for {
+			select {
+			case <-stop:
+				polled <- nil
+				return
+			default:
+			}
+			d := atomic.LoadInt64(&done)
+			n := int64(list.ApproxLen())
+			s := atomic.LoadInt64(&started)
+			if n < d || n > s {
+				polled <- fmt.Errorf("ApproxLen %v outside of completed %v and started %v", n, d, s)
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+	close(stop)
+	if err := <-polled; err != nil {
+		t.Fatal(err)
+	}
+	if list.ApproxLen() != 80000 {
+		t.Fatalf("ApproxLen settled at %v, expected 80000", list.ApproxLen())
+	}
+}
+
+func TestRandLevel(t *testing.T) {
+	for _, p := range []float64{1 / math.E, 0.25, 0.5} {
+		dist := newLevelDist(18, p)
+		src := rand.NewSource(1)
+		reached := make([]int, 19)
+		const draws = 1000000
+		for i := 0; i < draws; i++ {
+			level := randLevel(src, dist)
+			if level < 1 || level > 18 {
+				t.Fatalf("p=%v: drew level %v", p, level)
+			}
+			for l := 1; l <= level; l++ {
+				reached[l]++
+			}
+		}
+		for l := 2; l <= 4; l++ {
+			expected := draws * math.Pow(p, float64(l-1))
+			if math.Abs(float64(reached[l])-expected) > 0.05*expected {
+				t.Fatalf("p=%v: %v draws reached level %v, expected about %v", p, reached[l], l, expected)
+			}
+		}
+	}
+
+	//buckets must pick the same level as comparing against every threshold
+	for _, p := range []float64{1 / math.E, 0.5, 0.3, 0.01} {
+		dist := newLevelDist(18, p)
+		reference := levelDist{max: dist.max, thresholds: dist.thresholds}
+		a, b := rand.NewSource(2), rand.NewSource(2)
+		for i := 0; i < 1000000; i++ {
+			if x, y := randLevel(a, dist), randLevel(b, reference); x != y {
+				t.Fatalf("p=%v: draw %v got level %v, expected %v", p, i, x, y)
+			}
+		}
+	}
+	if newLevelDist(18, 0.7).buckets != nil {
+		t.Fatal("ratios above 1/2 must compare against every threshold")
+	}
+
+	if dist := newLevelDist(1, 0.5); randLevel(rand.NewSource(1), dist) != 1 {
+		t.Fatal("a single level list must only draw level 1")
+	}
+}
+
+// zeroSource draws 0, which passes every threshold.
+type zeroSource struct{}
+
+func (zeroSource) Int63() int64 { return 0 }
+func (zeroSource) Seed(int64)   {}
+
+func TestRandLevelTall(t *testing.T) {
+	for _, p := range []float64{1 / math.E, 0.5, 0.9} {
+		dist := newLevelDist(64, p)
+		if window := len(dist.thresholds); window < 1 || dist.thresholds[window-1] < levelResolution {
+			t.Fatalf("p=%v: threshold %v is below the resolution", p, dist.thresholds[window-1])
+		}
+		if level := randLevel(zeroSource{}, dist); level != 64 {
+			t.Fatalf("p=%v: a draw passing every window must reach level 64, got %v", p, level)
+		}
+	}
+
+	//levels past a window come from further draws and keep the geometric distribution
+	for _, p := range []float64{0.25, 0.5, 0.9} {
+		dist := newLevelDist(64, p)
+		dist.thresholds, dist.buckets = dist.thresholds[:3], nil
+		src := rand.NewSource(3)
+		reached := make([]int, 65)
+		const draws = 1000000
+		for i := 0; i < draws; i++ {
+			level := randLevel(src, dist)
+			if level < 1 || level > 64 {
+				t.Fatalf("p=%v: drew level %v", p, level)
+			}
+			for l := 1; l <= level; l++ {
+				reached[l]++
+			}
+		}
+		for l := 2; l <= 64; l++ {
+			expected := draws * math.Pow(p, float64(l-1))
+			if expected < 2000 {
+				break
+			}
+			if math.Abs(float64(reached[l])-expected) > 0.05*expected {
+				t.Fatalf("p=%v: %v draws reached level %v, expected about %v", p, reached[l], l, expected)
+			}
+		}
+	}
+
+	//hashed heights of Immutable draw again too, and stay the same for a key
+	for key := 0.0; key < 1000; key++ {
+		if a, b := cowHeight(key), cowHeight(key); a != b || a < 1 || a > 32 {
+			t.Fatalf("key %v got heights %v and %v", key, a, b)
+		}
+	}
+}
+
+func BenchmarkRandLevel(b *testing.B) {
+	for _, p := range []float64{1 / math.E, 0.5, 0.7} {
+		b.Run(fmt.Sprintf("p=%.3f", p), func(b *testing.B) {
+			dist := newLevelDist(18, p)
+			src := rand.NewSource(1)
+			for i := 0; i < b.N; i++ {
+				randLevel(src, dist)
+			}
+		})
+	}
+}
+
 func BenchmarkIncSet(b *testing.B) {
 	b.ReportAllocs()
 	list := New()
@@ -180,3 +549,36 @@ func BenchmarkDecGet(b *testing.B) {
 
 	b.SetBytes(int64(b.N))
 }
+
+// BenchmarkParallelGet compares lookups descending with seek under the shared read lock, as Get does,
+// against the same lookups through the writer cursors, which need the lock exclusively.
+func BenchmarkParallelGet(b *testing.B) {
+	list := New()
+	const n = 100000
+	for i := 0; i < n; i++ {
+		list.Set(float64(i), i)
+	}
+
+	b.Run("seek", func(b *testing.B) {
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				if list.Get(float64(i%n)) == nil {
+					b.Fatal("failed to Get an element that should exist")
+				}
+			}
+		})
+	})
+	b.Run("cursors", func(b *testing.B) {
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				list.mutex.Lock()
+				list.moveCursors(float64(i % n))
+				found := list.levelCursors[0].next[0] != nil
+				list.mutex.Unlock()
+				if !found {
+					b.Fatal("failed to find an element that should exist")
+				}
+			}
+		})
+	})
+}
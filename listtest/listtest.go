@@ -0,0 +1,160 @@
+// Package listtest is the randomized correctness harness of jumplist, for wrappers built on top of it.
+// Check runs sequences of random operations against a list and a map standing in as its model, and
+// fails the test at the first operation where the two disagree or the structure is broken:
+//
+//	func TestMyWrapper(t *testing.T) {
+//		listtest.Check(t, func() listtest.List { return newMyWrapper() })
+//	}
+package listtest
+
+import (
+	"fmt"
+	"iter"
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/abbychau/jumplist"
+)
+
+// List is the map-like behaviour Check expects: Set inserts or overwrites, Del reports whether the key
+// was present, and All iterates in ascending key order. A List that also has a Validate() error
+// method, as *jumplist.SkipList does, has it called after every operation.
+type List interface {
+	Set(key float64, value interface{})
+	Get(key float64) (interface{}, bool)
+	Del(key float64) bool
+	Len() int
+	All() iter.Seq2[float64, interface{}]
+}
+
+const (
+	sequences = 20   //seeded runs, each on a new list from the factory
+	ops       = 2000 //operations per run
+	keySpace  = 64   //small, so keys collide and the list grows and shrinks all the time
+	logTail   = 10   //operations shown when a run fails
+)
+
+// Check runs the randomized sequences against lists made by factory, see the package comment.
+// Runs are seeded, so a failure reproduces.
+func Check(t testing.TB, factory func() List) {
+	t.Helper()
+	for seed := int64(1); seed <= sequences; seed++ {
+		if err := run(factory(), seed); err != nil {
+			t.Fatalf("seed %v: %v", seed, err)
+		}
+	}
+}
+
+// key draws from a small space with the corner cases mixed in.
+func key(r *rand.Rand) float64 {
+	switch r.Intn(20) {
+	case 0:
+		return math.Inf(1)
+	case 1:
+		return math.Inf(-1)
+	case 2:
+		return math.Copysign(0, -1) //equal to 0
+	default:
+		return float64(r.Intn(keySpace)) - keySpace/4
+	}
+}
+
+func run(list List, seed int64) error {
+	r := rand.New(rand.NewSource(seed))
+	model := map[float64]interface{}{}
+	log := []string{}
+	fail := func(format string, args ...interface{}) error {
+		return fmt.Errorf("after %v: %v", strings.Join(log[max(0, len(log)-logTail):], ", "), fmt.Sprintf(format, args...))
+	}
+
+	for i := 0; i < ops; i++ {
+		k := key(r)
+		if k == 0 {
+			k = 0 //the model map must see -0 and 0 as the same key too
+		}
+		switch op := r.Intn(10); {
+		case op < 5:
+			list.Set(k, i)
+			model[k] = i
+			log = append(log, fmt.Sprintf("Set(%v, %v)", k, i))
+		case op < 8:
+			removed := list.Del(k)
+			_, present := model[k]
+			delete(model, k)
+			log = append(log, fmt.Sprintf("Del(%v)", k))
+			if removed != present {
+				return fail("Del reported %v, the key was present: %v", removed, present)
+			}
+		default:
+			value, ok := list.Get(k)
+			expected, present := model[k]
+			log = append(log, fmt.Sprintf("Get(%v)", k))
+			if ok != present || value != expected {
+				return fail("Get returned %v, %v, expected %v, %v", value, ok, expected, present)
+			}
+		}
+
+		if list.Len() != len(model) {
+			return fail("Len is %v, expected %v", list.Len(), len(model))
+		}
+		if v, ok := list.(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return fail("%v", err)
+			}
+		}
+		if i%50 == 0 || i == ops-1 {
+			if err := compare(list, model); err != nil {
+				return fail("%v", err)
+			}
+		}
+	}
+	return nil
+}
+
+// compare checks All against the sorted model.
+func compare(list List, model map[float64]interface{}) error {
+	keys := make([]float64, 0, len(model))
+	for k := range model {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	i := 0
+	for k, v := range list.All() {
+		if i == len(keys) {
+			return fmt.Errorf("All yields %v past the last key", k)
+		}
+		if k != keys[i] || v != model[k] {
+			return fmt.Errorf("All yields %v=%v at position %v, expected %v=%v", k, v, i, keys[i], model[keys[i]])
+		}
+		i++
+	}
+	if i != len(keys) {
+		return fmt.Errorf("All yields %v keys, expected %v", i, len(keys))
+	}
+	return nil
+}
+
+// Wrap adapts a *jumplist.SkipList to List, keeping its Validate.
+func Wrap(list *jumplist.SkipList) List {
+	return wrapped{list}
+}
+
+type wrapped struct {
+	*jumplist.SkipList
+}
+
+func (w wrapped) Set(key float64, value interface{}) {
+	w.SkipList.Set(key, value)
+}
+
+func (w wrapped) Get(key float64) (interface{}, bool) {
+	return w.SkipList.GetValue(key)
+}
+
+func (w wrapped) Del(key float64) bool {
+	return w.SkipList.Del(key) != nil
+}
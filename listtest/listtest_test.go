@@ -0,0 +1,41 @@
+package listtest
+
+import (
+	"testing"
+
+	"github.com/abbychau/jumplist"
+)
+
+func TestSkipList(t *testing.T) {
+	for name, opts := range map[string][]jumplist.Option{
+		"default":      nil,
+		"pooled":       {jumplist.WithPooling()},
+		"arena":        {jumplist.WithOwnArena(64)},
+		"bloom":        {jumplist.WithBloomFilter(0.01)},
+		"tall":         {jumplist.WithMaxLevel(32), jumplist.WithProbability(0.5)},
+		"unlocked":     {jumplist.WithoutLocking()},
+		"access times": {jumplist.WithAccessTracking()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			Check(t, func() List { return Wrap(jumplist.New(opts...)) })
+		})
+	}
+}
+
+// lossy drops every tenth Set, Check must notice
+type lossy struct {
+	List
+	sets int
+}
+
+func (l *lossy) Set(key float64, value interface{}) {
+	if l.sets++; l.sets%10 != 0 {
+		l.List.Set(key, value)
+	}
+}
+
+func TestCheckFails(t *testing.T) {
+	if err := run(&lossy{List: Wrap(jumplist.New())}, 1); err == nil {
+		t.Fatal("a broken list must fail the check")
+	}
+}
@@ -0,0 +1,161 @@
+package jumplist
+
+import "time"
+
+// lruNode is an entry of the recency list, most recently used first.
+type lruNode struct {
+	column     *Column
+	accessed   time.Time
+	prev, next *lruNode
+}
+
+type lruState struct {
+	root           lruNode //sentinel, root.next is the most recent and root.prev the least
+	nodes          map[*Column]*lruNode
+	insertionOrder bool //only inserts count as use, see EvictOldest
+}
+
+// NewLRU returns a list bounded to capacity columns. Get and Set mark a key as used and inserting
+// past the capacity removes the least recently used column, whatever its key.
+func NewLRU(maxLevel, capacity int) *SkipList {
+	if capacity < 1 {
+		panic("capacity must be positive")
+	}
+	list := NewWithLevel(maxLevel)
+	list.bound(capacity, EvictLeastRecent)
+	return list
+}
+
+// WithAccessTracking records when each column was last used by Get or Set, for EvictIdle on a list
+// without an LRU bound. Like on an LRU list, Get then takes the write lock to record the access.
+func WithAccessTracking() Option {
+	return func(list *SkipList) {
+		list.lru = &lruState{}
+		list.lru.reset()
+	}
+}
+
+// EvictIdle removes the columns not used for olderThan and returns how many it removed, oldest first,
+// calling the OnEvict function for each. It needs the access times kept by WithAccessTracking or
+// an LRU bound, on other lists it removes nothing. With EvictOldest, inserts are the only use.
+// Idle columns are found from the tail of the recency list, so it costs O(log n) per removal.
+func (list *SkipList) EvictIdle(olderThan time.Duration) int {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	if list.lru == nil {
+		return 0
+	}
+	deadline := time.Now().Add(-olderThan)
+	n := 0
+	for node := list.lru.root.prev; node != &list.lru.root && node.accessed.Before(deadline); node = list.lru.root.prev {
+		victim := node.column
+		list.removeColumn(victim) //forgets the node
+		list.trace("Del", victim.key, victim, true)
+		if list.onEvict != nil {
+			list.onEvict(victim.key, victim.Value)
+		}
+		n++
+	}
+	return n
+}
+
+// reset forgets every column.
+func (l *lruState) reset() {
+	l.nodes = map[*Column]*lruNode{}
+	l.root.prev = &l.root
+	l.root.next = &l.root
+}
+
+func (l *lruState) unlinkNode(node *lruNode) {
+	node.prev.next = node.next
+	node.next.prev = node.prev
+}
+
+func (l *lruState) pushFront(node *lruNode) {
+	node.prev = &l.root
+	node.next = l.root.next
+	l.root.next.prev = node
+	l.root.next = node
+}
+
+// touch moves column to the most recent end, adding it if it is new.
+func (list *SkipList) touch(column *Column) {
+	if list.lru == nil {
+		return
+	}
+
+	node, ok := list.lru.nodes[column]
+	if ok && list.lru.insertionOrder {
+		return
+	}
+	if ok {
+		list.lru.unlinkNode(node)
+	} else {
+		node = &lruNode{column: column}
+		list.lru.nodes[column] = node
+	}
+	node.accessed = time.Now()
+	list.lru.pushFront(node)
+}
+
+// forget drops column from the recency list once it left the skip list.
+func (list *SkipList) forget(column *Column) {
+	list.dropExpiry(column)
+	list.notify(EventDelete, column, nil)
+	if list.lru == nil {
+		return
+	}
+	if node, ok := list.lru.nodes[column]; ok {
+		list.lru.unlinkNode(node)
+		delete(list.lru.nodes, column)
+	}
+}
+
+// replaced hands the recency of old over to the column that took its place.
+func (list *SkipList) replaced(old, column *Column) {
+	if list.ttl != nil {
+		if at, ok := list.ttl.expires[old]; ok {
+			list.setExpiry(column, at)
+		}
+		delete(list.ttl.expires, old)
+	}
+	if list.lru != nil {
+		if node, ok := list.lru.nodes[old]; ok {
+			delete(list.lru.nodes, old)
+			node.column = column
+			list.lru.nodes[column] = node
+		}
+		list.touch(column)
+	}
+	if list.valueIndex != nil {
+		list.unindex(list.valueKey(old.Value), old) //the update below only knows the new column
+	}
+	if m, ok := list.metadata[old]; ok {
+		delete(list.metadata, old)
+		list.metadata[column] = m
+	}
+	list.notify(EventUpdate, column, old.Value)
+}
+
+// evict removes columns chosen by the eviction policy until the list fits its capacity.
+func (list *SkipList) evict() {
+	if list.capacity == 0 {
+		return
+	}
+	for list.length > list.capacity {
+		var victim *Column
+		switch list.policy {
+		case EvictSmallest:
+			victim = list.startPointers.next[0]
+		case EvictLargest:
+			victim = list.back()
+		default:
+			victim = list.lru.root.prev.column
+		}
+		list.removeColumn(victim) //the victim itself, not the first of its equal keys in a multiset
+		if list.onEvict != nil {
+			list.onEvict(victim.key, victim.Value)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package jumplist
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRU(t *testing.T) {
+	list := NewLRU(18, 3)
+	has := func(keys ...float64) {
+		t.Helper()
+		keysHeld, _ := list.ExportColumns()
+		if len(keysHeld) != len(keys) {
+			t.Fatalf("list holds %v, expected %v", keysHeld, keys)
+		}
+		for i := range keys {
+			if keysHeld[i] != keys[i] {
+				t.Fatalf("list holds %v, expected %v", keysHeld, keys)
+			}
+		}
+	}
+
+	list.Set(1, nil)
+	list.Set(2, nil)
+	list.Set(3, nil)
+	list.Get(1)
+	list.Set(4, nil) //2 is the least recently used
+	has(1, 3, 4)
+
+	list.Get(3)
+	list.Set(5, nil) //1 is now the oldest
+	has(3, 4, 5)
+
+	list.Set(4, "updated") //updating counts as use
+	list.Set(6, nil)
+	has(4, 5, 6)
+
+	list.Del(5)
+	list.Set(7, nil) //room was made by Del, nothing is evicted
+	has(4, 6, 7)
+
+	list.ReplaceOrInsert(4, "replaced")
+	list.Set(8, nil)
+	has(4, 7, 8)
+	checkSanity(list, t)
+
+	if len(list.lru.nodes) != 3 {
+		t.Fatalf("recency list tracks %v columns, expected 3", len(list.lru.nodes))
+	}
+}
+
+func TestEvictIdle(t *testing.T) {
+	evicted := []float64{}
+	list := New(WithAccessTracking(), OnEvict(func(key float64, value interface{}) { evicted = append(evicted, key) }))
+	for i := 0; i < 10; i++ {
+		list.Set(float64(i), i)
+	}
+	time.Sleep(20 * time.Millisecond)
+	list.Get(3) //used again, so no longer idle
+	list.Set(7, "updated")
+
+	if n := list.EvictIdle(10 * time.Millisecond); n != 8 || list.Len() != 2 {
+		t.Fatalf("evicted %v columns, %v left", n, list.Len())
+	}
+	checkSanity(list, t)
+	if !list.Contains(3) || !list.Contains(7) || len(evicted) != 8 || evicted[0] != 0 {
+		t.Fatal("only the idle columns must go, least recent first", evicted)
+	}
+	if list.EvictIdle(time.Hour) != 0 {
+		t.Fatal("nothing is idle for an hour")
+	}
+
+	if New().EvictIdle(0) != 0 {
+		t.Fatal("a list without access times has nothing to evict")
+	}
+
+	multi := New(AllowDuplicates(), WithAccessTracking())
+	multi.Set(1, "a")
+	time.Sleep(20 * time.Millisecond)
+	multi.Set(1, "b")
+	if multi.EvictIdle(10*time.Millisecond) != 1 || multi.Front().Value != "b" {
+		t.Fatal("the idle column itself must go, not the first with its key")
+	}
+}
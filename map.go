@@ -0,0 +1,175 @@
+package jumplist
+
+import (
+	"sync"
+)
+
+// Map is a SkipList behind the method set of sync.Map, keyed by float64, so code written against
+// sync.Map can switch to it and gain Range in ascending key order. Like sync.Map the zero value is
+// an empty map ready to use, it is safe for concurrent use, and Range holds no lock while calling f.
+type Map struct {
+	once sync.Once
+	list *SkipList
+}
+
+// mapRangeBatch is how many pairs Range copies under one read lock before calling f on them.
+const mapRangeBatch = 64
+
+// NewMap returns an empty Map over a list built with opts. A multiset would break LoadOrStore and
+// Swap, so AllowDuplicates, WithTieOrder and WithTieBreaker must not be among them.
+func NewMap(opts ...Option) *Map {
+	return &Map{list: New(opts...)}
+}
+
+func (m *Map) skipList() *SkipList {
+	m.once.Do(func() {
+		if m.list == nil {
+			m.list = New()
+		}
+	})
+	return m.list
+}
+
+// Load returns the value stored at key and whether there is one.
+func (m *Map) Load(key float64) (value interface{}, ok bool) {
+	list := m.skipList()
+	if column := list.Get(key); column != nil {
+		return list.LoadValue(column), true
+	}
+	return nil, false
+}
+
+// Store sets the value at key.
+func (m *Map) Store(key float64, value interface{}) {
+	m.skipList().Set(key, value)
+}
+
+// LoadOrStore returns the value at key if there is one, loaded being true, and otherwise stores value
+// and returns it. A key whose TTL ran out is absent, as for Load, even before it is swept.
+func (m *Map) LoadOrStore(key float64, value interface{}) (actual interface{}, loaded bool) {
+	list := m.skipList()
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	key = list.snap(key)
+	list.moveCursors(key)
+	column := list.levelCursors[0].next[0]
+	switch {
+	case column == nil || column.key != key:
+		list.insertAtCursors(key, value)
+	case list.expired(column):
+		list.put(key, value) //over the expired value, as Set would
+	default:
+		list.touch(column)
+		return column.Value, true
+	}
+	return value, false
+}
+
+// LoadAndDelete removes key, returning its value and whether it was present.
+func (m *Map) LoadAndDelete(key float64) (value interface{}, loaded bool) {
+	list := m.skipList()
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	key = list.snap(key)
+	column := list.seek(key)
+	live := column != nil && column.key == key && !list.expired(column)
+	column = list.del(key) //an expired column goes too, it would be swept anyway
+	list.trace("Del", key, column, live)
+	if !live {
+		return nil, false
+	}
+	return column.Value, true
+}
+
+// Delete removes key.
+func (m *Map) Delete(key float64) {
+	m.skipList().Del(key)
+}
+
+// Swap stores value at key and returns the value it replaced and whether there was one.
+func (m *Map) Swap(key float64, value interface{}) (previous interface{}, loaded bool) {
+	list := m.skipList()
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	key = list.snap(key)
+	column := list.seek(key)
+	live := column != nil && column.key == key && !list.expired(column)
+	_, previous, _ = list.put(key, value)
+	if !live {
+		return nil, false
+	}
+	return previous, true
+}
+
+// CompareAndSwap stores new at key if the value there equals old, see SkipList.CompareAndSwap.
+func (m *Map) CompareAndSwap(key float64, old, new interface{}) bool {
+	return m.skipList().CompareAndSwap(key, old, new)
+}
+
+// CompareAndDelete removes key if its value equals old and reports whether it did. Values are
+// compared with ==, which panics for uncomparable types as sync.Map does.
+func (m *Map) CompareAndDelete(key float64, old interface{}) bool {
+	list := m.skipList()
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	key = list.snap(key)
+	column := list.seek(key)
+	if column == nil || column.key != key || list.expired(column) || column.Value != old {
+		return false
+	}
+	list.del(key)
+	list.trace("Del", key, column, true)
+	return true
+}
+
+// Range calls f on each key and value in ascending key order until f returns false. As with sync.Map
+// the pairs are no consistent snapshot: f may change the map, and no key is visited twice, but keys
+// stored or deleted meanwhile may or may not be seen. It copies the pairs in batches under the read
+// lock, so a Range costs about one descent per 64 pairs.
+func (m *Map) Range(f func(key float64, value interface{}) bool) {
+	list := m.skipList()
+	batch := make([]KV, 0, mapRangeBatch)
+	from, past, front := 0.0, false, true
+	for {
+		batch = list.pairsFrom(batch[:0], from, past, front)
+		for _, kv := range batch {
+			if !f(kv.Key, kv.Value) {
+				return
+			}
+		}
+		if len(batch) < cap(batch) {
+			return
+		}
+		from, past, front = batch[len(batch)-1].Key, true, false
+	}
+}
+
+// pairsFrom fills pairs up to its capacity with the pairs from the first key not less than from, or
+// greater than from when past is set, or from the front with front set.
+func (list *SkipList) pairsFrom(pairs []KV, from float64, past, front bool) []KV {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	column := list.startPointers.next[0]
+	if !front {
+		column = list.seek(from)
+	}
+	if past && column != nil && column.key == from {
+		column = column.next[0]
+	}
+	for ; column != nil && len(pairs) < cap(pairs); column = column.next[0] {
+		if !list.expired(column) {
+			pairs = append(pairs, KV{column.key, column.Value})
+		}
+	}
+	return pairs
+}
+
+// Clear removes every key.
+func (m *Map) Clear() {
+	m.skipList().Clear()
+}
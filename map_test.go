@@ -0,0 +1,156 @@
+package jumplist
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMap(t *testing.T) {
+	var m Map //the zero value is ready, as with sync.Map
+
+	if _, ok := m.Load(1); ok {
+		t.Fatal("an empty map must load nothing")
+	}
+	m.Store(1, "a")
+	if v, ok := m.Load(1); !ok || v != "a" {
+		t.Fatal("Load(1) is", v, ok)
+	}
+	if actual, loaded := m.LoadOrStore(1, "b"); !loaded || actual != "a" {
+		t.Fatal("LoadOrStore must return the stored value, got", actual, loaded)
+	}
+	if actual, loaded := m.LoadOrStore(2, "b"); loaded || actual != "b" {
+		t.Fatal("LoadOrStore must store an absent key, got", actual, loaded)
+	}
+	if prev, loaded := m.Swap(2, "c"); !loaded || prev != "b" {
+		t.Fatal("Swap must return the replaced value, got", prev, loaded)
+	}
+	if prev, loaded := m.Swap(3, "d"); loaded || prev != nil {
+		t.Fatal("Swap of an absent key replaces nothing, got", prev, loaded)
+	}
+	if m.CompareAndSwap(3, "x", "e") || !m.CompareAndSwap(3, "d", "e") {
+		t.Fatal("CompareAndSwap must swap only the expected value")
+	}
+	if m.CompareAndDelete(3, "d") || !m.CompareAndDelete(3, "e") {
+		t.Fatal("CompareAndDelete must delete only the expected value")
+	}
+	if v, loaded := m.LoadAndDelete(2); !loaded || v != "c" {
+		t.Fatal("LoadAndDelete(2) is", v, loaded)
+	}
+	if _, loaded := m.LoadAndDelete(2); loaded {
+		t.Fatal("LoadAndDelete of a removed key must load nothing")
+	}
+	m.Delete(1)
+	if _, ok := m.Load(1); ok {
+		t.Fatal("Delete must remove the key")
+	}
+}
+
+func TestMapRange(t *testing.T) {
+	m := NewMap()
+	const n = 3*mapRangeBatch + 5
+	for i := n - 1; i >= 0; i-- {
+		m.Store(float64(i), i)
+	}
+
+	expected := 0.0
+	m.Range(func(key float64, value interface{}) bool {
+		if key != expected || value != int(key) {
+			t.Fatalf("Range yields %v=%v, expected %v", key, value, expected)
+		}
+		m.Store(key, "changed") //f may change the map
+		expected++
+		return true
+	})
+	if expected != n {
+		t.Fatal("Range stopped at", expected)
+	}
+
+	m.Range(func(key float64, value interface{}) bool {
+		m.Delete(key + mapRangeBatch) //in a batch not copied yet
+		if value != "changed" || int(key)%(2*mapRangeBatch) >= mapRangeBatch {
+			t.Fatalf("Range yields %v=%v", key, value)
+		}
+		return true
+	})
+
+	visited := 0
+	m.Range(func(float64, interface{}) bool {
+		visited++
+		return visited < 3
+	})
+	if visited != 3 {
+		t.Fatal("Range must stop when f returns false, visited", visited)
+	}
+
+	m.Clear()
+	m.Range(func(float64, interface{}) bool {
+		t.Fatal("a cleared map has nothing to range over")
+		return false
+	})
+}
+
+func TestMapConcurrent(t *testing.T) {
+	var m Map
+	wg := sync.WaitGroup{}
+	stored := make([]int, 8)
+	for g := range stored {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				if _, loaded := m.LoadOrStore(float64(i), g); !loaded {
+					stored[g]++
+				}
+				m.Range(func(key float64, value interface{}) bool { return key < 10 })
+			}
+		}()
+	}
+	wg.Wait()
+
+	total := 0
+	for _, n := range stored {
+		total += n
+	}
+	if total != 500 {
+		t.Fatal("each key must be stored by exactly one LoadOrStore, stored", total)
+	}
+}
+
+func TestMapExpired(t *testing.T) {
+	m := NewMap()
+	list := m.skipList()
+	now := time.Unix(1000, 0)
+	list.SetWithTTL(0, "setup", time.Second) //sets up the TTL state, so its clock can be replaced
+	list.ttl.now = func() time.Time { return now }
+	list.Del(0)
+	for key := 1.0; key <= 5; key++ {
+		list.SetWithTTL(key, "old", time.Second)
+	}
+	now = now.Add(time.Second) //every key is expired but not swept
+
+	if actual, loaded := m.LoadOrStore(1, "new"); loaded || actual != "new" {
+		t.Fatal("LoadOrStore must store over an expired key", actual, loaded)
+	}
+	if value, ok := m.Load(1); !ok || value != "new" {
+		t.Fatal("the stored value must not expire", value, ok)
+	}
+	if value, loaded := m.LoadAndDelete(2); loaded || value != nil || list.Contains(2) {
+		t.Fatal("LoadAndDelete must find no expired key", value, loaded)
+	}
+	if previous, loaded := m.Swap(3, "new"); loaded || previous != nil {
+		t.Fatal("Swap must replace no expired value", previous, loaded)
+	}
+	if m.CompareAndSwap(4, "old", "new") || m.CompareAndDelete(5, "old") {
+		t.Fatal("CompareAndSwap and CompareAndDelete must not match an expired value")
+	}
+	var keys []float64
+	m.Range(func(key float64, value interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+	if !slices.Equal(keys, []float64{1, 3}) {
+		t.Fatal("Range must skip expired keys, got", keys)
+	}
+}
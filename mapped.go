@@ -0,0 +1,268 @@
+package jumplist
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"math"
+	"reflect"
+)
+
+// The mapped format is little endian and 8 byte aligned. A header holds the magic, the column count,
+// the number of levels and the offset of the first column on each level. Every column follows with its
+// key, height, value length, the offsets of its successors, and the value padded to 8 bytes.
+// Offset 0 is the header, so it stands for the end of a level.
+const (
+	mappedMagic      = "JLMAP001"
+	mappedHeader     = 24 //magic, count, levels
+	mappedColumnHead = 16 //key, height, value length
+)
+
+var errMapped = errors.New("jumplist: malformed mapped file")
+
+// WriteMapped writes the list to w in the layout read by OpenMapped, every value turned into bytes by
+// encode, such as func(v interface{}) ([]byte, error) { return v.([]byte), nil }. A nil encode uses the
+// codec registered for the type of each value, see RegisterValueCodec, and stores byte slices and strings
+// as they are. The list is read locked while its values are encoded and written.
+func (list *SkipList) WriteMapped(w io.Writer, encode func(value interface{}) ([]byte, error)) error {
+	if encode == nil {
+		encode = encodeMapped
+	}
+	if list.less != nil {
+		panic("WriteMapped needs the numeric order of keys, not WithComparator")
+	}
+
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	entries := make([]mappedEntry, 0, list.length)
+	for column := list.startPointers.next[0]; column != nil; column = column.next[0] {
+		value, err := encode(column.Value)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, mappedEntry{column.key, len(column.next), value})
+	}
+	return writeMapped(w, entries, list.maxLevel)
+}
+
+// mappedEntry is a column as it is written to the mapped layout.
+type mappedEntry struct {
+	key    float64
+	height int
+	value  []byte
+}
+
+// writeMapped writes entries in key order, none of them taller than levels.
+func writeMapped(w io.Writer, entries []mappedEntry, levels int) error {
+	offsets := make([]uint64, len(entries))
+	offset := uint64(mappedHeader + 8*levels)
+	for i, e := range entries {
+		if uint64(len(e.value)) > math.MaxUint32 {
+			return errors.New("jumplist: value too large to map")
+		}
+		offsets[i] = offset
+		offset += mappedColumnHead + 8*uint64(e.height) + padded(len(e.value))
+	}
+
+	//the successors on each level are the next columns tall enough, found walking backwards
+	next := make([]uint64, levels)
+	nexts := make([][]uint64, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		nexts[i] = append([]uint64(nil), next[:entries[i].height]...)
+		for level := range entries[i].height {
+			next[level] = offsets[i]
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	buf := make([]byte, 0, mappedHeader+8*levels)
+	buf = append(buf, mappedMagic...)
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(len(entries)))
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(levels))
+	for _, first := range next {
+		buf = binary.LittleEndian.AppendUint64(buf, first)
+	}
+	bw.Write(buf)
+
+	var padding [8]byte
+	for i, e := range entries {
+		buf = binary.LittleEndian.AppendUint64(buf[:0], math.Float64bits(e.key))
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(e.height))
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(e.value)))
+		for _, offset := range nexts[i] {
+			buf = binary.LittleEndian.AppendUint64(buf, offset)
+		}
+		bw.Write(buf)
+		bw.Write(e.value)
+		if _, err := bw.Write(padding[:padded(len(e.value))-uint64(len(e.value))]); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// encodeMapped stores a value through its codec, without the name and version: a mapping holds raw bytes,
+// so its reader knows the type and decodes them with DecodeValue at the current version of the codec.
+func encodeMapped(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	}
+	codecs.RLock()
+	c := codecs.byType[reflect.TypeOf(value)]
+	codecs.RUnlock()
+	if c == nil {
+		return nil, fmt.Errorf("jumplist: no codec registered for %T", value)
+	}
+	return c.Encode(value)
+}
+
+func padded(n int) uint64 {
+	return (uint64(n) + 7) &^ 7
+}
+
+// Mapped is a read-only list served straight from a file written by WriteMapped. Opening maps the file
+// without reading it, so startup takes the same time for any size, and lookups descend the levels in
+// the mapping. Returned values alias the mapping: they must not be modified nor used after Close.
+// Offsets read from the file are bounds checked, so a corrupt file fails lookups rather than crashing.
+// Mapped is safe for concurrent use until Close.
+type Mapped struct {
+	data   []byte
+	length int
+	levels int
+	unmap  func() error
+}
+
+// OpenMapped maps the file at path, see Mapped.
+func OpenMapped(path string) (*Mapped, error) {
+	data, unmap, err := mapFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < mappedHeader || string(data[:8]) != mappedMagic {
+		unmap()
+		return nil, errMapped
+	}
+	m := &Mapped{
+		data:   data,
+		length: int(binary.LittleEndian.Uint64(data[8:])),
+		levels: int(binary.LittleEndian.Uint64(data[16:])),
+		unmap:  unmap,
+	}
+	if m.levels < 1 || m.levels > 64 || len(data) < mappedHeader+8*m.levels {
+		unmap()
+		return nil, errMapped
+	}
+	return m, nil
+}
+
+// mappedColumn is the column at an offset, or the header when off is 0.
+type mappedColumn struct {
+	key    float64
+	height int
+	off    uint64
+}
+
+// column decodes the column at off, ok is false when it does not fit in the file.
+func (m *Mapped) column(off uint64) (c mappedColumn, ok bool) {
+	if off < mappedHeader || off%8 != 0 || off > uint64(len(m.data))-mappedColumnHead {
+		return c, false
+	}
+	c = mappedColumn{
+		key:    math.Float64frombits(binary.LittleEndian.Uint64(m.data[off:])),
+		height: int(binary.LittleEndian.Uint32(m.data[off+8:])),
+		off:    off,
+	}
+	return c, c.height >= 1 && c.height <= m.levels && off+mappedColumnHead+8*uint64(c.height) <= uint64(len(m.data))
+}
+
+// next returns the offset following c on level, c.off being 0 for the header.
+func (m *Mapped) next(c mappedColumn, level int) uint64 {
+	if c.off == 0 {
+		return binary.LittleEndian.Uint64(m.data[mappedHeader+8*level:])
+	}
+	return binary.LittleEndian.Uint64(m.data[c.off+mappedColumnHead+8*uint64(level):])
+}
+
+// value returns the bytes of c in the mapping.
+func (m *Mapped) value(c mappedColumn) ([]byte, bool) {
+	n := uint64(binary.LittleEndian.Uint32(m.data[c.off+12:]))
+	start := c.off + mappedColumnHead + 8*uint64(c.height)
+	if start+n > uint64(len(m.data)) {
+		return nil, false
+	}
+	return m.data[start : start+n : start+n], true
+}
+
+// seek returns the first column whose key is not less than key.
+func (m *Mapped) seek(key float64) (mappedColumn, bool) {
+	at := mappedColumn{}
+	for level := m.levels - 1; level >= 0; level-- {
+		for {
+			off := m.next(at, level)
+			if off == 0 {
+				break
+			}
+			c, ok := m.column(off)
+			if !ok || c.height <= level || off <= at.off { //columns are laid out in key order, so a cycle is corrupt
+				return c, false
+			}
+			if c.key >= key {
+				if level == 0 {
+					return c, true
+				}
+				break
+			}
+			at = c
+		}
+	}
+	return mappedColumn{}, false
+}
+
+// Get returns the value of key, aliasing the mapping, and whether key is present.
+func (m *Mapped) Get(key float64) ([]byte, bool) {
+	c, ok := m.seek(key)
+	if !ok || c.key != key {
+		return nil, false
+	}
+	return m.value(c)
+}
+
+// Len returns the number of columns.
+func (m *Mapped) Len() int {
+	return m.length
+}
+
+// From iterates in key order starting at the first key not less than key, values aliasing the mapping.
+func (m *Mapped) From(key float64) iter.Seq2[float64, []byte] {
+	return func(yield func(float64, []byte) bool) {
+		c, ok := m.seek(key)
+		for ok {
+			value, valid := m.value(c)
+			if !valid || !yield(c.key, value) {
+				return
+			}
+			off := m.next(c, 0)
+			if off <= c.off {
+				return //the end, or corrupt
+			}
+			c, ok = m.column(off)
+		}
+	}
+}
+
+// All iterates over every pair in key order, values aliasing the mapping.
+func (m *Mapped) All() iter.Seq2[float64, []byte] {
+	return m.From(math.Inf(-1))
+}
+
+// Close unmaps the file. Values returned before must not be used afterwards.
+func (m *Mapped) Close() error {
+	return m.unmap()
+}
@@ -0,0 +1,14 @@
+//go:build !unix
+
+package jumplist
+
+import "os"
+
+// mapFile reads the whole file where mapping is not supported.
+func mapFile(path string) ([]byte, func() error, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func() error { return nil }, nil
+}
@@ -0,0 +1,78 @@
+package jumplist
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMapped(t *testing.T) {
+	list := New()
+	for i := 0; i < 10000; i++ {
+		list.Set(float64(i*2), fmt.Sprint("value ", i)) //lengths vary, so the padding is exercised
+	}
+	list.Set(-1, "")
+
+	path := filepath.Join(t.TempDir(), "list.map")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := list.WriteMapped(f, func(v interface{}) ([]byte, error) { return []byte(v.(string)), nil }); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	m, err := OpenMapped(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	if m.Len() != list.Len() {
+		t.Fatalf("mapped %v columns, expected %v", m.Len(), list.Len())
+	}
+	for _, key := range []float64{0, 2, 5000, 19998} {
+		if v, ok := m.Get(key); !ok || string(v) != list.Get(key).Value {
+			t.Fatalf("key %v maps to %q %v, expected %q", key, v, ok, list.Get(key).Value)
+		}
+	}
+	if v, ok := m.Get(-1); !ok || len(v) != 0 {
+		t.Fatal("an empty value must be found", v, ok)
+	}
+	for _, key := range []float64{1, -2, 20000} {
+		if _, ok := m.Get(key); ok {
+			t.Fatalf("key %v must be missing", key)
+		}
+	}
+
+	n := 0
+	for key, value := range m.From(10) {
+		if key != float64(10+n*2) || string(value) != fmt.Sprint("value ", 5+n) {
+			t.Fatalf("got %v=%q at position %v", key, value, n)
+		}
+		n++
+	}
+	if n != 10000-5 {
+		t.Fatalf("iterated over %v columns, expected %v", n, 10000-5)
+	}
+
+	//corrupt successor offsets fail lookups instead of crashing or looping
+	data, _ := os.ReadFile(path)
+	bad := bytes.Repeat([]byte{0xff}, 8)
+	copy(data[mappedHeader:], bad)
+	os.WriteFile(path, data, 0o644)
+	corrupt, err := OpenMapped(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	corrupt.Get(100)
+	corrupt.Close()
+
+	os.WriteFile(path, []byte("not a mapped list"), 0o644)
+	if _, err := OpenMapped(path); err == nil {
+		t.Fatal("a file of another format must be rejected")
+	}
+}
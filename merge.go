@@ -0,0 +1,121 @@
+package jumplist
+
+import "unsafe"
+
+// Merge returns a new list holding the columns of both lists, built in linear time by zipping
+// their level 0 chains. For a key present in both, the value is onConflict(ours, theirs),
+// or theirs when onConflict is nil. Both lists are read locked and left unchanged.
+func (list *SkipList) Merge(other *SkipList, onConflict func(a, b interface{}) interface{}) *SkipList {
+	unlock := readLockBoth(list, other)
+	defer unlock()
+
+	merged := NewWithLevel(list.levelCap)
+	merged.duplicates, merged.ties, merged.tieLess, merged.less = list.duplicates, list.ties, list.tieLess, list.less
+	merged.reserve(list.length + other.length)
+	t := merged.newTails()
+
+	a, b := list.startPointers.next[0], other.startPointers.next[0]
+	for a != nil || b != nil {
+		switch {
+		case b == nil || a != nil && (list.keyLess(a.key, b.key) || a.key == b.key && list.duplicates && list.tiesBefore(a, b)): //multisets keep both, ours first on a tie
+			merged.appendSorted(t, a.key, a.Value)
+			a = a.next[0]
+		case a == nil || list.keyLess(b.key, a.key) || list.duplicates:
+			merged.appendSorted(t, b.key, b.Value)
+			b = b.next[0]
+		default:
+			value := b.Value
+			if onConflict != nil {
+				value = onConflict(a.Value, b.Value)
+			}
+			merged.appendSorted(t, a.key, value)
+			a, b = a.next[0], b.next[0]
+		}
+	}
+	merged.resize(0)
+
+	return merged
+}
+
+// Union returns a new list holding the keys of either list, see Merge. For ZUNIONSTORE style
+// aggregation combine gets both values of a shared key, such as the two scores to add up.
+func Union(a, b *SkipList, combine func(x, y interface{}) interface{}) *SkipList {
+	return a.Merge(b, combine)
+}
+
+// Intersect returns a new list holding the keys present in both lists with the value combine(x, y),
+// or b's value when combine is nil. It zips the level 0 chains in linear time. In multisets equal
+// columns pair up one to one, so a key kept n times is the smaller of its counts in a and b.
+func Intersect(a, b *SkipList, combine func(x, y interface{}) interface{}) *SkipList {
+	unlock := readLockBoth(a, b)
+	defer unlock()
+
+	out := NewWithLevel(a.levelCap)
+	out.duplicates, out.ties, out.tieLess, out.less = a.duplicates, a.ties, a.tieLess, a.less
+	out.reserve(min(a.length, b.length))
+	t := out.newTails()
+	x, y := a.startPointers.next[0], b.startPointers.next[0]
+	for x != nil && y != nil {
+		switch {
+		case a.keyLess(x.key, y.key):
+			x = x.next[0]
+		case a.keyLess(y.key, x.key):
+			y = y.next[0]
+		default:
+			value := y.Value
+			if combine != nil {
+				value = combine(x.Value, y.Value)
+			}
+			out.appendSorted(t, x.key, value)
+			x, y = x.next[0], y.next[0]
+		}
+	}
+	out.resize(0)
+
+	return out
+}
+
+// Difference returns a new list holding the keys of a that are not in b, with a's values, in linear time.
+// In multisets every column of b cancels one equal column of a.
+func Difference(a, b *SkipList) *SkipList {
+	unlock := readLockBoth(a, b)
+	defer unlock()
+
+	out := NewWithLevel(a.levelCap)
+	out.duplicates, out.ties, out.tieLess, out.less = a.duplicates, a.ties, a.tieLess, a.less
+	out.reserve(a.length)
+	t := out.newTails()
+	x, y := a.startPointers.next[0], b.startPointers.next[0]
+	for x != nil {
+		switch {
+		case y == nil || a.keyLess(x.key, y.key):
+			out.appendSorted(t, x.key, x.Value)
+			x = x.next[0]
+		case a.keyLess(y.key, x.key):
+			y = y.next[0]
+		default:
+			x, y = x.next[0], y.next[0]
+		}
+	}
+	out.resize(0)
+
+	return out
+}
+
+// readLockBoth takes the read locks of both lists in address order, so two goroutines
+// locking the same pair the other way round cannot deadlock behind a waiting writer.
+func readLockBoth(a, b *SkipList) (unlock func()) {
+	if a == b {
+		a.mutex.RLock()
+		return a.mutex.RUnlock
+	}
+	if uintptr(unsafe.Pointer(b)) < uintptr(unsafe.Pointer(a)) {
+		a, b = b, a
+	}
+	a.mutex.RLock()
+	b.mutex.RLock()
+	return func() {
+		b.mutex.RUnlock()
+		a.mutex.RUnlock()
+	}
+}
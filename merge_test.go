@@ -0,0 +1,87 @@
+package jumplist
+
+import "testing"
+
+func TestMerge(t *testing.T) {
+	a, b := New(), New()
+	for i := 0; i < 1000; i++ {
+		a.Set(float64(i*2), i) //even keys
+		b.Set(float64(i*3), -i)
+	}
+
+	merged := a.Merge(b, func(x, y interface{}) interface{} { return x.(int) - y.(int) })
+	checkSanity(merged, t)
+	if merged.Len() != 1000+1000-334 { //multiples of 6 below 2000 are in both
+		t.Fatalf("wrong length %v", merged.Len())
+	}
+	if c := merged.Get(6); c == nil || c.Value.(int) != 3+2 {
+		t.Fatal("conflicting key must be resolved", c)
+	}
+	if c := merged.Get(2997); c == nil || c.Value.(int) != -999 {
+		t.Fatal("keys only in the other list must be kept", c)
+	}
+	if a.Len() != 1000 || b.Len() != 1000 {
+		t.Fatal("merged lists must be left unchanged")
+	}
+
+	if c := a.Merge(b, nil).Get(0); c == nil || c.Value.(int) != 0 {
+		t.Fatal("merge without a resolver must keep the other value", c)
+	}
+	if self := a.Merge(a, nil); self.Len() != a.Len() {
+		t.Fatal("merging a list with itself must not duplicate keys", self.Len())
+	}
+
+	multi := New(AllowDuplicates())
+	multi.Set(1, "ours")
+	other := New()
+	other.Set(1, "theirs")
+	if all := multi.Merge(other, nil).GetAll(1); len(all) != 2 || all[0].Value != "ours" {
+		t.Fatal("multisets must keep both columns, ours first", all)
+	}
+}
+
+func TestSetAlgebra(t *testing.T) {
+	a, b := New(), New()
+	for i := 0; i < 1000; i++ {
+		a.Set(float64(i*2), 1)
+		b.Set(float64(i*3), 10)
+	}
+	sum := func(x, y interface{}) interface{} { return x.(int) + y.(int) }
+
+	union := Union(a, b, sum)
+	checkSanity(union, t)
+	if union.Len() != 1000+1000-334 || union.Get(6).Value != 11 || union.Get(3).Value != 10 {
+		t.Fatal("wrong union", union.Len())
+	}
+
+	both := Intersect(a, b, sum)
+	checkSanity(both, t)
+	if both.Len() != 334 || both.Get(6).Value != 11 || both.Get(2) != nil || both.Get(3) != nil {
+		t.Fatal("intersection must hold the multiples of 6 only", both.Len())
+	}
+	if c := Intersect(a, b, nil).Get(0); c == nil || c.Value != 10 {
+		t.Fatal("intersection without a combiner must keep the other value", c)
+	}
+
+	diff := Difference(a, b)
+	checkSanity(diff, t)
+	if diff.Len() != 1000-334 || diff.Get(6) != nil || diff.Get(2).Value != 1 {
+		t.Fatal("difference must drop the keys of the other list", diff.Len())
+	}
+	if Difference(a, a).Len() != 0 || Intersect(a, New(), nil).Len() != 0 {
+		t.Fatal("empty results must be empty lists")
+	}
+	if a.Len() != 1000 || b.Len() != 1000 {
+		t.Fatal("operands must be left unchanged")
+	}
+
+	x, y := New(AllowDuplicates()), New(AllowDuplicates())
+	for _, k := range []float64{1, 1, 1, 2} {
+		x.Set(k, nil)
+	}
+	y.Set(1, nil)
+	y.Set(1, nil)
+	if Intersect(x, y, nil).Len() != 2 || Difference(x, y).Len() != 2 {
+		t.Fatal("multiset columns must pair up one to one")
+	}
+}
@@ -0,0 +1,91 @@
+package jumplist
+
+import (
+	"container/heap"
+	"iter"
+	"sort"
+	"unsafe"
+)
+
+// MergeIter iterates over the columns of every list in global key order without building a merged list,
+// such as for a scan over the shards of a federated index. Equal keys are yielded once per column, in
+// the order the lists were passed. The lists must share one order of keys, that of the first list.
+// Every list is read locked until the loop ends, in address order so
+// concurrent merges cannot deadlock, and a list passed twice is locked once and yielded twice.
+func MergeIter(lists ...*SkipList) iter.Seq2[float64, interface{}] {
+	return func(yield func(float64, interface{}) bool) {
+		unlock := readLockAll(lists)
+		defer unlock()
+
+		heads := &mergeHeap{heads: make([]mergeHead, 0, len(lists))}
+		for i, list := range lists {
+			if column := list.startPointers.next[0]; column != nil {
+				heads.heads = append(heads.heads, mergeHead{column, i})
+			}
+		}
+		if len(lists) > 0 {
+			heads.order = lists[0]
+		}
+		heap.Init(heads)
+
+		for heads.Len() > 0 {
+			column := heads.heads[0].column
+			if !yield(column.key, column.Value) {
+				return
+			}
+			if heads.heads[0].column = column.next[0]; heads.heads[0].column == nil {
+				heap.Pop(heads)
+			} else {
+				heap.Fix(heads, 0)
+			}
+		}
+	}
+}
+
+type mergeHead struct {
+	column *Column
+	list   int //position of the list among the arguments, breaking ties between equal keys
+}
+
+type mergeHeap struct {
+	heads []mergeHead
+	order *SkipList //whose keyLess orders the keys
+}
+
+func (h *mergeHeap) Len() int      { return len(h.heads) }
+func (h *mergeHeap) Swap(i, j int) { h.heads[i], h.heads[j] = h.heads[j], h.heads[i] }
+func (h *mergeHeap) Push(x any)    { h.heads = append(h.heads, x.(mergeHead)) }
+
+func (h *mergeHeap) Less(i, j int) bool {
+	a, b := h.heads[i], h.heads[j]
+	if a.column.key != b.column.key {
+		return h.order.keyLess(a.column.key, b.column.key)
+	}
+	return a.list < b.list
+}
+
+func (h *mergeHeap) Pop() any {
+	last := h.heads[len(h.heads)-1]
+	h.heads = h.heads[:len(h.heads)-1]
+	return last
+}
+
+// readLockAll takes the read locks of lists once each, in address order like readLockBoth.
+func readLockAll(lists []*SkipList) (unlock func()) {
+	locked := append([]*SkipList(nil), lists...)
+	sort.Slice(locked, func(i, j int) bool {
+		return uintptr(unsafe.Pointer(locked[i])) < uintptr(unsafe.Pointer(locked[j]))
+	})
+	for i, list := range locked {
+		if i == 0 || list != locked[i-1] {
+			list.mutex.RLock()
+		}
+	}
+	return func() {
+		for i := len(locked) - 1; i >= 0; i-- {
+			if i == 0 || locked[i] != locked[i-1] {
+				locked[i].mutex.RUnlock()
+			}
+		}
+	}
+}
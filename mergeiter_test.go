@@ -0,0 +1,47 @@
+package jumplist
+
+import "testing"
+
+func TestMergeIter(t *testing.T) {
+	shards := []*SkipList{New(), New(), New()}
+	for i := 0; i < 300; i++ {
+		shards[i%3].Set(float64(i), i)
+	}
+	shards[2].Set(0, "tie") //shard 0 holds 0 too
+	empty := New()
+
+	expected := 0
+	previous := -1.0
+	for key, value := range MergeIter(shards[0], empty, shards[1], shards[2]) {
+		if key < previous {
+			t.Fatalf("%v came after %v", key, previous)
+		}
+		if key == 0 && previous == 0 && value != "tie" {
+			t.Fatal("equal keys must follow the order of the lists", value)
+		}
+		previous = key
+		expected++
+	}
+	if expected != 301 {
+		t.Fatalf("merged %v columns, expected 301", expected)
+	}
+
+	n := 0
+	for range MergeIter(shards[1], shards[1]) {
+		n++
+	}
+	if n != 200 {
+		t.Fatalf("a list passed twice must be yielded twice, got %v columns", n)
+	}
+
+	for key := range MergeIter(shards...) {
+		if key == 10 {
+			break
+		}
+	}
+	shards[0].Set(1000, nil) //the locks are released after a break
+
+	for range MergeIter() {
+		t.Fatal("no lists yield nothing")
+	}
+}
@@ -0,0 +1,49 @@
+package jumplist
+
+import "time"
+
+// Metadata is what WithMetadata records about a column.
+type Metadata struct {
+	Created time.Time //when the key was inserted
+	Updated time.Time //when its value last changed, Created until then
+	Updates int       //value changes since the insert
+}
+
+// WithMetadata records when each column was created and last updated and how often it was updated,
+// for auditing and debugging without wrapping every value in a struct of its own, see Metadata. Every
+// insert and update reads the clock and keeps an entry per column under the write lock. Lists made by
+// Split, Freeze and the other builders of new lists do not carry the records over.
+func WithMetadata() Option {
+	return func(list *SkipList) {
+		list.metadata = map[*Column]Metadata{}
+	}
+}
+
+// Metadata returns the record of column and whether there is one, which needs WithMetadata and
+// column still being in the list.
+func (list *SkipList) Metadata(column *Column) (Metadata, bool) {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	m, ok := list.metadata[column]
+	return m, ok
+}
+
+// stamp updates the record of column for a change.
+func (list *SkipList) stamp(kind EventKind, column *Column) {
+	if list.metadata == nil {
+		return
+	}
+	switch kind {
+	case EventInsert:
+		now := time.Now()
+		list.metadata[column] = Metadata{Created: now, Updated: now}
+	case EventUpdate:
+		m := list.metadata[column]
+		m.Updated = time.Now()
+		m.Updates++
+		list.metadata[column] = m
+	case EventDelete:
+		delete(list.metadata, column)
+	}
+}
@@ -0,0 +1,53 @@
+package jumplist
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithMetadata(t *testing.T) {
+	list := New(WithMetadata())
+	before := time.Now()
+	column := list.Set(1, "a")
+
+	m, ok := list.Metadata(column)
+	if !ok || m.Created.Before(before) || !m.Updated.Equal(m.Created) || m.Updates != 0 {
+		t.Fatal("a new column must be recorded as created and never updated, got", m, ok)
+	}
+
+	time.Sleep(time.Millisecond)
+	list.Set(1, "b")
+	list.Upsert(1, func(old interface{}, exists bool) interface{} { return "c" })
+	updated, _ := list.Metadata(column)
+	if !updated.Created.Equal(m.Created) || !updated.Updated.After(m.Created) || updated.Updates != 2 {
+		t.Fatal("updates must be counted and keep the creation time, got", updated)
+	}
+
+	swapped := list.ReplaceOrInsert(1, "d")
+	if _, ok := list.Metadata(swapped); ok {
+		t.Fatal("a column swapped out must lose its record")
+	}
+	if m, ok := list.Metadata(list.Get(1)); !ok || !m.Created.Equal(updated.Created) || m.Updates != 3 {
+		t.Fatal("the replacing column must take the record over, got", m, ok)
+	}
+
+	list.Del(1)
+	if _, ok := list.Metadata(column); ok || len(list.metadata) != 0 {
+		t.Fatal("a removed column must lose its record")
+	}
+
+	list.LoadParallel([]KV{{1, nil}, {2, nil}, {3, nil}}, 2)
+	for c := list.Front(); c != nil; c = c.Next() {
+		if _, ok := list.Metadata(c); !ok {
+			t.Fatal("a parallel load must record its columns,", c.key, "has no record")
+		}
+	}
+	list.Clear()
+	if len(list.metadata) != 0 {
+		t.Fatal("Clear must drop the records")
+	}
+
+	if _, ok := New().Metadata(New().Set(1, nil)); ok {
+		t.Fatal("a list without WithMetadata records nothing")
+	}
+}
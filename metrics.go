@@ -0,0 +1,70 @@
+package jumplist
+
+import (
+	"expvar"
+	"sync/atomic"
+)
+
+// Metrics are the operation counters of a list created WithMetrics, read without taking the lock.
+type Metrics struct {
+	Len     int64
+	Sets    int64 //inserts and value updates, from Set and every other writer
+	Gets    int64 //lookups by Get, GetValue and Contains
+	Hits    int64 //of those, the ones that found their key
+	Misses  int64
+	Removes int64 //columns that left the list, evictions and expiries included
+}
+
+// metrics holds the live counters, kept apart so lists without WithMetrics pay one nil check.
+type metrics struct {
+	sets, gets, hits, removes atomic.Int64
+}
+
+// WithMetrics counts the operations on the list for Metrics, at the cost of an atomic add each.
+func WithMetrics() Option {
+	return func(list *SkipList) {
+		list.metrics = &metrics{}
+	}
+}
+
+// Metrics returns the counters so far, only Len is kept when the list was not created WithMetrics.
+// The counters are read one by one while the list may change, so they are not a consistent snapshot.
+// A Prometheus collector can report them from a GaugeFunc or CounterFunc each.
+func (list *SkipList) Metrics() Metrics {
+	m := Metrics{Len: atomic.LoadInt64(&list.approxLen)}
+	if list.metrics != nil {
+		m.Sets = list.metrics.sets.Load()
+		m.Gets = list.metrics.gets.Load()
+		m.Hits = list.metrics.hits.Load()
+		m.Misses = m.Gets - m.Hits
+		m.Removes = list.metrics.removes.Load()
+	}
+	return m
+}
+
+// PublishExpvar publishes Metrics as the expvar name, served as JSON on /debug/vars. Like expvar.Publish
+// it panics if the name is already taken.
+func (list *SkipList) PublishExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() any { return list.Metrics() }))
+}
+
+func (list *SkipList) countGet(hit bool) {
+	if list.metrics != nil {
+		list.metrics.gets.Add(1)
+		if hit {
+			list.metrics.hits.Add(1)
+		}
+	}
+}
+
+// countChange is called by notify for every change.
+func (list *SkipList) countChange(kind EventKind) {
+	if list.metrics == nil {
+		return
+	}
+	if kind == EventDelete {
+		list.metrics.removes.Add(1)
+	} else {
+		list.metrics.sets.Add(1)
+	}
+}
@@ -0,0 +1,39 @@
+package jumplist
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	list := New(WithMetrics())
+	for i := 0; i < 10; i++ {
+		list.Set(float64(i), i)
+	}
+	list.Set(3, "again")
+	list.Get(3)
+	list.Get(30)
+	list.GetValue(4)
+	list.Contains(40)
+	list.Del(5)
+	list.RemoveRange(7, 8)
+
+	expected := Metrics{Len: 7, Sets: 11, Gets: 4, Hits: 2, Misses: 2, Removes: 3}
+	if m := list.Metrics(); m != expected {
+		t.Fatalf("got %+v, expected %+v", m, expected)
+	}
+
+	list.PublishExpvar("jumplist_test")
+	var published Metrics
+	if err := json.Unmarshal([]byte(expvar.Get("jumplist_test").String()), &published); err != nil || published != expected {
+		t.Fatal("expvar must publish the metrics", published, err)
+	}
+
+	plain := New()
+	plain.Set(1, nil)
+	plain.Get(1)
+	if m := plain.Metrics(); m != (Metrics{Len: 1}) {
+		t.Fatal("only Len is kept without WithMetrics", m)
+	}
+}
@@ -0,0 +1,107 @@
+package jumplist
+
+// AllowDuplicates lets Set add a column for a key that is already present instead of overwriting it.
+// Equal keys keep their insertion order unless WithTieOrder or WithTieBreaker say otherwise, and
+// iteration always visits them in that order. Get returns the first one and Del removes the first one.
+func AllowDuplicates() Option {
+	return func(list *SkipList) {
+		list.duplicates = true
+	}
+}
+
+// TieOrder orders the columns of equal keys in a multiset.
+type TieOrder int
+
+const (
+	TiesFIFO TieOrder = iota //oldest first, the default, so a scheduler runs tasks of one deadline in arrival order
+	TiesLIFO                 //newest first
+)
+
+// WithTieOrder makes the list a multiset keeping equal keys in order. With TiesLIFO, Get and Del
+// find the column inserted last.
+func WithTieOrder(order TieOrder) Option {
+	return func(list *SkipList) {
+		list.duplicates, list.ties = true, order
+	}
+}
+
+// WithTieBreaker makes the list a multiset ordering equal keys by value, less reporting whether a goes
+// before b. Values equal under less keep their insertion order. An insert steps over the equal keys
+// before its place, which costs O(ties) on top of the descent.
+func WithTieBreaker(less func(a, b interface{}) bool) Option {
+	return func(list *SkipList) {
+		list.duplicates, list.tieLess = true, less
+	}
+}
+
+// tiesLast reports whether an insert goes after the columns of an equal key right away, as in a FIFO
+// multiset. Otherwise it goes before them, and stepTies moves it on for a tie breaker.
+func (list *SkipList) tiesLast() bool {
+	return list.duplicates && list.ties == TiesFIFO && list.tieLess == nil
+}
+
+// stepTies moves the cursors, standing before the columns of key, past those value does not go before.
+func (list *SkipList) stepTies(key float64, value interface{}) {
+	if list.tieLess == nil {
+		return
+	}
+	for next := list.levelCursors[0].next[0]; next != nil && next.key == key && !list.tieLess(value, next.Value); next = list.levelCursors[0].next[0] {
+		list.stepCursors()
+	}
+}
+
+// tiesBefore reports whether a, from the receiver, goes before b from another list when merging equal keys.
+func (list *SkipList) tiesBefore(a, b *Column) bool {
+	return list.tieLess == nil || !list.tieLess(b.Value, a.Value)
+}
+
+// GetAll returns every column holding key, in tie order.
+func (list *SkipList) GetAll(key float64) []*Column {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	key = list.snap(key)
+	columns := []*Column{}
+	for column := list.seek(key); column != nil && column.key == key; column = column.next[0] {
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+// RemoveOne removes the first column holding key and returns it, nil if there is none.
+func (list *SkipList) RemoveOne(key float64) *Column {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	return list.del(list.snap(key))
+}
+
+// RemoveElement unlinks column itself, as returned earlier by Set or Get, and reports whether it was
+// still in the list. Among equal keys in multiset mode it removes exactly that column rather than the
+// first. It descends to the key and then steps over the equal columns before it. With WithPooling a
+// removed column may be reused by a later insert, so do not pass one that was removed before.
+func (list *SkipList) RemoveElement(column *Column) bool {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	removed := list.removeColumn(column)
+	if removed {
+		list.trace("Del", column.key, column, true)
+	} else {
+		list.trace("Del", column.key, nil, false)
+	}
+	return removed
+}
+
+// removeColumn unlinks column itself, not just a column with its key, and reports whether it was in the list.
+func (list *SkipList) removeColumn(column *Column) bool {
+	list.moveCursors(column.key)
+	for next := list.levelCursors[0].next[0]; next != nil && next.key == column.key; next = list.levelCursors[0].next[0] {
+		if next == column {
+			list.unlinkAtCursors()
+			return true
+		}
+		list.stepCursors()
+	}
+	return false
+}
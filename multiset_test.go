@@ -0,0 +1,131 @@
+package jumplist
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAllowDuplicates(t *testing.T) {
+	list := New(AllowDuplicates())
+	list.Set(10, "a")
+	list.Set(20, "x")
+	list.Set(10, "b")
+	list.Set(5, "y")
+	list.Set(10, "c")
+	checkSanity(list, t)
+
+	if list.Len() != 5 {
+		t.Fatalf("wrong length %v (expected 5)", list.Len())
+	}
+
+	tied := list.GetAll(10)
+	if len(tied) != 3 || tied[0].Value != "a" || tied[1].Value != "b" || tied[2].Value != "c" {
+		t.Fatal("equal keys must be kept in insertion order", tied)
+	}
+	if c := list.Get(10); c != tied[0] {
+		t.Fatal("Get must return the oldest of the equal keys")
+	}
+	if list.Count(10, 10) != 3 || list.Rank(20) != 4 {
+		t.Fatal("ranks must count every duplicate")
+	}
+
+	if c := list.RemoveOne(10); c == nil || c.Value != "a" {
+		t.Fatal("RemoveOne must remove the oldest of the equal keys", c)
+	}
+	if tied := list.GetAll(10); len(tied) != 2 || tied[0].Value != "b" {
+		t.Fatal("the other duplicates must stay", tied)
+	}
+	checkSanity(list, t)
+
+	if len(list.GetAll(15)) != 0 || list.RemoveOne(15) != nil {
+		t.Fatal("absent key has no columns")
+	}
+}
+
+func TestRemoveElement(t *testing.T) {
+	list := New(AllowDuplicates())
+	columns := []*Column{}
+	for i := 0; i < 200; i++ {
+		columns = append(columns, list.Set(float64(i%4), i))
+	}
+
+	for i := 150; i >= 5; i -= 7 { //from the middle of every run of equal keys
+		if !list.RemoveElement(columns[i]) {
+			t.Fatalf("column %v must be removed", i)
+		}
+		if list.RemoveElement(columns[i]) {
+			t.Fatalf("column %v was already removed", i)
+		}
+		checkSanity(list, t)
+		for _, c := range list.GetAll(columns[i].key) {
+			if c == columns[i] {
+				t.Fatalf("column %v is still linked", i)
+			}
+		}
+	}
+	if list.Len() != 200-21 {
+		t.Fatalf("wrong length %v (expected %v)", list.Len(), 200-21)
+	}
+
+	set := New()
+	old := set.Set(1, "old")
+	set.Del(1)
+	set.Set(1, "new")
+	if set.RemoveElement(old) || set.Get(1).Value != "new" {
+		t.Fatal("a removed column must not take the new one with the same key along")
+	}
+}
+
+func TestTieOrder(t *testing.T) {
+	values := func(columns []*Column) []interface{} {
+		out := []interface{}{}
+		for _, c := range columns {
+			out = append(out, c.Value)
+		}
+		return out
+	}
+	byValue := WithTieBreaker(func(a, b interface{}) bool { return a.(int)/10 < b.(int)/10 })
+	for _, c := range []struct {
+		name     string
+		opt      Option
+		expected []interface{}
+	}{
+		{"fifo", WithTieOrder(TiesFIFO), []interface{}{31, 12, 20, 11, 35, 13}},
+		{"lifo", WithTieOrder(TiesLIFO), []interface{}{13, 35, 11, 20, 12, 31}},
+		{"tie breaker", byValue, []interface{}{12, 11, 13, 20, 31, 35}}, //equal tens keep insertion order
+	} {
+		list := New(c.opt)
+		list.Set(5, "before")
+		list.Append(9, "after")
+		list.Set(7, 31)
+		list.Set(7, 12)
+		list.SetBatch([]KV{{7, 20}, {7, 11}})
+		list.Append(7, 35) //not past the end, falls back to Set
+		list.Set(7, 13)
+		if err := list.Validate(); err != nil {
+			t.Fatal(c.name, err)
+		}
+
+		if got := values(list.GetAll(7)); !slices.Equal(got, c.expected) {
+			t.Errorf("%v: ties are %v, expected %v", c.name, got, c.expected)
+		}
+		if list.Get(7).Value != c.expected[0] || list.RemoveOne(7).Value != c.expected[0] {
+			t.Errorf("%v: Get and RemoveOne must find the first tie", c.name)
+		}
+		clone := list.Clone()
+		clone.Set(7, 19)
+		if err := clone.Validate(); err != nil || clone.Len() != list.Len()+1 {
+			t.Errorf("%v: a clone must keep the tie order, %v", c.name, err)
+		}
+	}
+
+	a, b := New(byValue), New(byValue)
+	for i := 0; i < 40; i += 2 {
+		a.Set(1, i)
+		b.Set(1, i+1)
+	}
+	merged := a.Merge(b, nil)
+	if err := merged.Validate(); err != nil || merged.Len() != 40 {
+		t.Fatal("Merge must keep the tie breaker order,", err)
+	}
+}
@@ -0,0 +1,156 @@
+package jumplist
+
+import (
+	"iter"
+	"math"
+	"sort"
+	"sync"
+)
+
+// MVCC is a list that keeps every version of its values, for point-in-time reads such as a time
+// travel cache. Each Set or Del is numbered by a global version counter and appended to the history
+// of its key, GetAt reads the value a key had as of any version and Vacuum drops the history no
+// reader needs anymore. Reads run in parallel, writes are serialized.
+type MVCC struct {
+	mutex   sync.RWMutex
+	list    *SkipList //values are *history, locked by mutex
+	version uint64
+}
+
+// history holds the versions of one key in ascending order.
+type history []revision
+
+type revision struct {
+	version uint64
+	value   interface{}
+	deleted bool
+}
+
+// NewMVCC returns an empty MVCC list, options are applied to the list holding the histories.
+func NewMVCC(opts ...Option) *MVCC {
+	return &MVCC{list: New(append(opts, WithoutLocking())...)}
+}
+
+// Version returns the number of the last write, 0 before the first one.
+func (m *MVCC) Version() uint64 {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.version
+}
+
+// Set appends value as a new version of key and returns its number.
+func (m *MVCC) Set(key float64, value interface{}) uint64 {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.append(key, revision{value: value})
+}
+
+// Del records that key was removed and returns the version of the removal. Reads at an earlier
+// version still see the value. Deleting an absent key records nothing and returns 0.
+func (m *MVCC) Del(key float64) uint64 {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, ok := m.at(key, math.MaxUint64); !ok {
+		return 0
+	}
+	return m.append(key, revision{deleted: true})
+}
+
+func (m *MVCC) append(key float64, rev revision) uint64 {
+	m.version++
+	rev.version = m.version
+	m.list.Upsert(key, func(old interface{}, exists bool) interface{} {
+		if !exists {
+			return &history{rev}
+		}
+		h := old.(*history)
+		*h = append(*h, rev)
+		return h
+	})
+	return m.version
+}
+
+// Get returns the latest value of key and whether it is present.
+func (m *MVCC) Get(key float64) (interface{}, bool) {
+	return m.GetAt(key, math.MaxUint64)
+}
+
+// GetAt returns the value key had as of version, that is after the writes numbered up to version,
+// and whether it was present then. Versions dropped by Vacuum read as the oldest one kept.
+func (m *MVCC) GetAt(key float64, version uint64) (interface{}, bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.at(key, version)
+}
+
+func (m *MVCC) at(key float64, version uint64) (interface{}, bool) {
+	column := m.list.seek(key) //not Get, which may write to an LRU list
+	if column == nil || column.key != key {
+		return nil, false
+	}
+	return column.Value.(*history).at(version)
+}
+
+// at finds the last revision not newer than version by binary search.
+func (h history) at(version uint64) (interface{}, bool) {
+	i := sort.Search(len(h), func(i int) bool { return h[i].version > version }) - 1
+	if i < 0 || h[i].deleted {
+		return nil, false
+	}
+	return h[i].value, true
+}
+
+// AllAt iterates in key order over the keys present as of version with the values they had then.
+// The read lock is held until the loop ends, so the loop body must not write to the list.
+func (m *MVCC) AllAt(version uint64) iter.Seq2[float64, interface{}] {
+	return func(yield func(float64, interface{}) bool) {
+		m.mutex.RLock()
+		defer m.mutex.RUnlock()
+
+		for key, h := range m.list.All() {
+			if value, ok := h.(*history).at(version); ok && !yield(key, value) {
+				return
+			}
+		}
+	}
+}
+
+// Vacuum drops the history older than before and returns how many revisions it dropped. The revision
+// each key had as of before is kept, so reads at before or later are unchanged, and keys whose
+// latest revision is a removal older than before leave the list.
+func (m *MVCC) Vacuum(before uint64) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	dropped := 0
+	m.list.RemoveIf(func(key float64, value interface{}) bool {
+		h := value.(*history)
+		keep := sort.Search(len(*h), func(i int) bool { return (*h)[i].version > before }) - 1 //the one as of before
+		if keep < 0 {
+			return false
+		}
+		if keep == len(*h)-1 && (*h)[keep].deleted {
+			dropped += len(*h)
+			return true //removed for good
+		}
+		if keep > 0 {
+			dropped += keep
+			*h = append((*h)[:0], (*h)[keep:]...)
+			clear((*h)[len(*h) : len(*h)+keep])
+		}
+		return false
+	})
+	return dropped
+}
+
+// Len returns the number of keys with a history, removed ones included until Vacuum drops them.
+func (m *MVCC) Len() int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.list.Len()
+}
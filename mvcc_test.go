@@ -0,0 +1,51 @@
+package jumplist
+
+import "testing"
+
+func TestMVCC(t *testing.T) {
+	m := NewMVCC()
+	v1 := m.Set(1, "a")
+	v2 := m.Set(1, "b")
+	m.Set(2, "x")
+	v4 := m.Del(1)
+	m.Set(1, "c")
+
+	for version, expected := range map[uint64]interface{}{0: nil, v1: "a", v2: "b", v2 + 1: "b", v4: nil, m.Version(): "c"} {
+		if value, ok := m.GetAt(1, version); value != expected || ok != (expected != nil) {
+			t.Fatalf("key 1 at version %v is %v, expected %v", version, value, expected)
+		}
+	}
+	if m.Del(3) != 0 || m.Version() != 5 {
+		t.Fatal("deleting an absent key must not be recorded")
+	}
+
+	keys := map[float64]interface{}{}
+	for k, v := range m.AllAt(v4) {
+		keys[k] = v
+	}
+	if len(keys) != 1 || keys[2] != "x" {
+		t.Fatal("wrong point-in-time scan", keys)
+	}
+
+	m.Del(2)
+	if dropped := m.Vacuum(v4); dropped != 2 {
+		t.Fatalf("dropped %v revisions, expected the two values of key 1 before its removal", dropped)
+	}
+	if v, ok := m.GetAt(1, v4); ok || v != nil {
+		t.Fatal("reads at the vacuum version must not change")
+	}
+	if v, _ := m.GetAt(1, v1); v != nil {
+		t.Fatal("vacuumed versions are gone", v)
+	}
+	if v, _ := m.GetAt(2, v4); v != "x" {
+		t.Fatal("the revision as of the vacuum version must be kept", v)
+	}
+
+	m.Vacuum(m.Version())
+	if m.Len() != 1 {
+		t.Fatalf("removed keys must leave the list, %v left", m.Len())
+	}
+	if v, ok := m.Get(1); !ok || v != "c" {
+		t.Fatal("the latest value must survive", v)
+	}
+}
@@ -0,0 +1,257 @@
+package jumplist
+
+// Front returns the column with the smallest key, nil if the list is empty.
+func (list *SkipList) Front() *Column {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.startPointers.next[0]
+}
+
+// Back returns the column with the largest key, nil if the list is empty.
+// It runs right along the top levels, so it is O(log n).
+func (list *SkipList) Back() *Column {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.back()
+}
+
+func (list *SkipList) back() *Column {
+	pointers := &list.startPointers
+	var column *Column
+
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		for pointers.next[i] != nil {
+			column = pointers.next[i]
+			pointers = &column.pointerColumn
+		}
+	}
+	return column
+}
+
+// Min returns the column with the smallest key in O(1), the same as Front.
+func (list *SkipList) Min() *Column {
+	return list.Front()
+}
+
+// Max returns the column with the largest key in O(log n), the same as Back.
+func (list *SkipList) Max() *Column {
+	return list.Back()
+}
+
+// NextAtLevel returns the column after c on the given level, or nil if c is not that tall.
+// It reads the pointers without taking the list lock, so callers must not run it alongside writers.
+func (list *SkipList) NextAtLevel(c *Column, level int) *Column {
+	if c == nil || level < 0 || level >= c.Level() {
+		return nil
+	}
+	return c.next[level]
+}
+
+// NthFromEnd returns the column n positions before the last one, so 0 is the largest key.
+// It looks the position up by rank in O(log n), nil if n is out of range.
+func (list *SkipList) NthFromEnd(n int) *Column {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	if n < 0 {
+		return nil
+	}
+	return list.byRank(list.length - 1 - n)
+}
+
+// TopK returns the k columns with the largest keys, largest first, walking back from the end in O(log n + k).
+func (list *SkipList) TopK(k int) []*Column {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	columns := make([]*Column, 0, max(0, min(k, list.length)))
+	for column := list.back(); column != nil && len(columns) < k; column = column.prev {
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+// BottomK returns the k columns with the smallest keys, smallest first, in O(k).
+func (list *SkipList) BottomK(k int) []*Column {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	columns := make([]*Column, 0, max(0, min(k, list.length)))
+	for column := list.startPointers.next[0]; column != nil && len(columns) < k; column = column.next[0] {
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+// seek returns the first column whose key is not less than key. Unlike moveCursors it
+// leaves the cursors alone, so it is safe under the read lock.
+func (list *SkipList) seek(key float64) *Column {
+	pointers := &list.startPointers
+	var next *Column
+
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		next = pointers.next[i]
+		for next != nil && list.keyLess(next.key, key) {
+			pointers = &next.pointerColumn
+			next = next.next[i]
+		}
+	}
+	return next
+}
+
+// seekRank is seek also returning the rank of the column found.
+func (list *SkipList) seekRank(key float64) (*Column, int) {
+	pointers := &list.startPointers
+	var next *Column
+	rank := 0
+
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		next = pointers.next[i]
+		for next != nil && list.keyLess(next.key, key) {
+			rank += pointers.span[i]
+			pointers = &next.pointerColumn
+			next = next.next[i]
+		}
+	}
+	return next, rank
+}
+
+// AnyInRange reports whether any key lies within [lo, hi], in O(log n).
+func (list *SkipList) AnyInRange(lo, hi float64) bool {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	column := list.seek(lo)
+	return column != nil && !list.keyLess(hi, column.key)
+}
+
+// RemainingFrom counts the columns whose key is not less than key, as Len minus Rank in O(log n).
+func (list *SkipList) RemainingFrom(key float64) int {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.length - list.rank(key)
+}
+
+// Range returns the columns with keys within [min, max] in ascending order. It seeks to min
+// in O(log n) and then walks level 0.
+func (list *SkipList) Range(min, max float64) []*Column {
+	list.rlockKeys()
+	defer list.runlockKeys()
+
+	columns := []*Column{}
+	for column := list.seek(min); column != nil && !list.keyLess(max, column.key); column = column.next[0] {
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+// RangeAppend appends the pairs with keys within [min, max] to dst in ascending order and returns the
+// extended slice, like strconv.AppendInt. Queries reusing one buffer with dst[:0] allocate nothing once
+// it is large enough.
+func (list *SkipList) RangeAppend(dst []KV, min, max float64) []KV {
+	if l := list.striped(); l != nil {
+		return list.rangeAppendStriped(l, dst, min, max)
+	}
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	for column := list.seek(min); column != nil && !list.keyLess(max, column.key); column = column.next[0] {
+		dst = append(dst, KV{column.key, column.Value})
+	}
+	return dst
+}
+
+// RangeWithLimit is Range skipping the first offset columns and returning at most limit, like the LIMIT
+// clause of ZRANGEBYSCORE, for paged score queries. The offset is skipped by rank in O(log n) rather
+// than walked over. A negative limit returns every column after the offset, a negative offset none.
+func (list *SkipList) RangeWithLimit(min, max float64, offset, limit int) []*Column {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	columns := []*Column{}
+	if offset < 0 || limit == 0 {
+		return columns
+	}
+	for column := list.byRank(list.rank(min) + offset); column != nil && !list.keyLess(max, column.key); column = column.next[0] {
+		columns = append(columns, column)
+		if len(columns) == limit {
+			break
+		}
+	}
+	return columns
+}
+
+// before returns the column preceding the position of c in the list, c being nil for the end.
+func (list *SkipList) before(c *Column) *Column {
+	if c == nil {
+		return list.back()
+	}
+	return c.prev
+}
+
+// Floor returns the column with the largest key less than or equal to key, or nil.
+func (list *SkipList) Floor(key float64) *Column {
+	list.rlockKeys()
+	defer list.runlockKeys()
+
+	column := list.seek(key)
+	if column != nil && column.key == key {
+		return column
+	}
+	return list.before(column)
+}
+
+// Ceiling returns the column with the smallest key greater than or equal to key, or nil.
+func (list *SkipList) Ceiling(key float64) *Column {
+	list.rlockKeys()
+	defer list.runlockKeys()
+
+	return list.seek(key)
+}
+
+// Lower returns the column with the largest key strictly less than key, or nil.
+func (list *SkipList) Lower(key float64) *Column {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.before(list.seek(key))
+}
+
+// Higher returns the column with the smallest key strictly greater than key, or nil.
+func (list *SkipList) Higher(key float64) *Column {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	column := list.seek(key)
+	if column != nil && column.key == key {
+		return column.next[0]
+	}
+	return column
+}
+
+// Nearest returns the column whose key is closest to key, such as the sample recorded nearest to a
+// timestamp. It compares the floor and the ceiling of key in O(log n), preferring the smaller key
+// on a tie. It returns nil only for an empty list.
+func (list *SkipList) Nearest(key float64) *Column {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	ceiling := list.seek(key)
+	if ceiling != nil && ceiling.key == key {
+		return ceiling
+	}
+	floor := list.before(ceiling)
+	switch {
+	case floor == nil:
+		return ceiling
+	case ceiling == nil:
+		return floor
+	case ceiling.key-key < key-floor.key:
+		return ceiling
+	default:
+		return floor
+	}
+}
@@ -0,0 +1,374 @@
+package jumplist
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestNextAtLevel(t *testing.T) {
+	list := New()
+	for i := 0; i < 1000; i++ {
+		list.Set(float64(i), i)
+	}
+
+	//level 1 chain is every column taller than one level, in key order
+	expected := []*Column{}
+	for c := list.startPointers.next[0]; c != nil; c = c.next[0] {
+		if c.Level() > 1 {
+			expected = append(expected, c)
+		}
+	}
+	if len(expected) == 0 {
+		t.Fatal("no column reached level 1")
+	}
+
+	got := []*Column{}
+	for c := expected[0]; c != nil; c = list.NextAtLevel(c, 1) {
+		got = append(got, c)
+	}
+
+	if len(got) != len(expected) {
+		t.Fatalf("walked %v columns on level 1, expected %v", len(got), len(expected))
+	}
+	for i := range got {
+		if got[i] != expected[i] {
+			t.Fatalf("column %v on level 1 is %v, expected %v", i, got[i].key, expected[i].key)
+		}
+	}
+
+	short := list.startPointers.next[0]
+	if list.NextAtLevel(short, short.Level()) != nil || list.NextAtLevel(short, -1) != nil || list.NextAtLevel(nil, 0) != nil {
+		t.Fatal("out of range levels must return nil")
+	}
+}
+
+func TestNthFromEnd(t *testing.T) {
+	list := New()
+	if list.NthFromEnd(0) != nil {
+		t.Fatal("empty list has no last column")
+	}
+
+	for i := 1; i <= 10; i++ {
+		list.Set(float64(i*10), i)
+	}
+
+	if c := list.NthFromEnd(0); c == nil || c.key != 100 {
+		t.Fatal(`0 from the end must be the max "100"`, c)
+	}
+	if c := list.NthFromEnd(3); c == nil || c.key != 70 {
+		t.Fatal(`3 from the end must be "70"`, c)
+	}
+	if c := list.NthFromEnd(9); c == nil || c.key != 10 {
+		t.Fatal(`9 from the end must be the min "10"`, c)
+	}
+	if list.NthFromEnd(10) != nil || list.NthFromEnd(-1) != nil {
+		t.Fatal("out of range n must return nil")
+	}
+}
+
+func TestAnyInRange(t *testing.T) {
+	list := New()
+	if list.AnyInRange(-1, 1) {
+		t.Fatal("empty list has nothing in range")
+	}
+
+	for _, k := range []float64{10, 20, 30} {
+		list.Set(k, nil)
+	}
+
+	if !list.AnyInRange(15, 25) || !list.AnyInRange(20, 20) || !list.AnyInRange(0, 100) {
+		t.Fatal("ranges holding keys must report true")
+	}
+	if list.AnyInRange(21, 29) {
+		t.Fatal("gap between 20 and 30 must report false")
+	}
+	if list.AnyInRange(-10, 9) || list.AnyInRange(31, 40) {
+		t.Fatal("ranges outside the keys must report false")
+	}
+	if list.AnyInRange(30, 10) {
+		t.Fatal("inverted range must report false")
+	}
+}
+
+func TestRemainingFrom(t *testing.T) {
+	list := New()
+	if list.RemainingFrom(0) != 0 {
+		t.Fatal("empty list has nothing remaining")
+	}
+
+	for i := 0; i < 100; i++ {
+		list.Set(float64(i), nil)
+	}
+
+	if n := list.RemainingFrom(-5); n != 100 {
+		t.Fatalf("from before the start %v remain, expected 100", n)
+	}
+	if n := list.RemainingFrom(0); n != 100 {
+		t.Fatalf("from the first key %v remain, expected 100", n)
+	}
+	if n := list.RemainingFrom(40); n != 60 {
+		t.Fatalf("from 40 %v remain, expected 60", n)
+	}
+	if n := list.RemainingFrom(40.5); n != 59 {
+		t.Fatalf("from 40.5 %v remain, expected 59", n)
+	}
+	if n := list.RemainingFrom(100); n != 0 {
+		t.Fatalf("past the end %v remain, expected 0", n)
+	}
+}
+
+func TestFrontNext(t *testing.T) {
+	list := New()
+	if list.Front() != nil {
+		t.Fatal("empty list has no front")
+	}
+
+	for _, k := range []float64{5, 3, 9, 1, 7} {
+		list.Set(k, nil)
+	}
+
+	expected := []float64{1, 3, 5, 7, 9}
+	i := 0
+	for c := list.Front(); c != nil; c = c.Next() {
+		if c.key != expected[i] {
+			t.Fatalf("column %v is %v, expected %v", i, c.key, expected[i])
+		}
+		i++
+	}
+	if i != len(expected) {
+		t.Fatalf("walked %v columns, expected %v", i, len(expected))
+	}
+}
+
+func TestBackPrev(t *testing.T) {
+	list := New()
+	if list.Back() != nil {
+		t.Fatal("empty list has no back")
+	}
+
+	for _, k := range []float64{5, 3, 9, 1, 7, 4} {
+		list.Set(k, nil)
+	}
+	list.Del(4)
+	list.ReplaceOrInsert(5, "replaced")
+	checkSanity(list, t)
+
+	expected := []float64{9, 7, 5, 3, 1}
+	i := 0
+	for c := list.Back(); c != nil; c = c.Prev() {
+		if c.key != expected[i] {
+			t.Fatalf("column %v from the back is %v, expected %v", i, c.key, expected[i])
+		}
+		i++
+	}
+	if i != len(expected) {
+		t.Fatalf("walked %v columns backwards, expected %v", i, len(expected))
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	list := New()
+	for i := 0; i < 10; i++ {
+		list.Set(float64(i), i)
+	}
+
+	keys := func(columns []*Column) []float64 {
+		out := []float64{}
+		for _, c := range columns {
+			out = append(out, c.key)
+		}
+		return out
+	}
+	for _, tc := range []struct {
+		key      float64
+		radius   int
+		expected []float64
+	}{
+		{5, 2, []float64{3, 4, 5, 6, 7}},
+		{5, 0, []float64{5}},
+		{1, 3, []float64{0, 1, 2, 3, 4}},
+		{8, 2, []float64{6, 7, 8, 9}},
+		{4, 20, []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}},
+	} {
+		if got := keys(list.Get(tc.key).Neighbors(tc.radius)); !slices.Equal(got, tc.expected) {
+			t.Errorf("neighbors of %v within %v are %v, expected %v", tc.key, tc.radius, got, tc.expected)
+		}
+	}
+}
+
+func TestRange(t *testing.T) {
+	list := New()
+	for i := 0; i < 100; i++ {
+		list.Set(float64(i*10), i)
+	}
+
+	check := func(min, max float64, expected ...float64) {
+		t.Helper()
+		got := list.Range(min, max)
+		if len(got) != len(expected) {
+			t.Fatalf("Range(%v, %v) returned %v columns, expected %v", min, max, len(got), len(expected))
+		}
+		for i, c := range got {
+			if c.key != expected[i] {
+				t.Fatalf("Range(%v, %v)[%v] is %v, expected %v", min, max, i, c.key, expected[i])
+			}
+		}
+	}
+
+	check(25, 61, 30, 40, 50, 60)
+	check(30, 60, 30, 40, 50, 60)
+	check(-100, 15, 0, 10)
+	check(975, 2000, 980, 990)
+	check(41, 49)
+	check(60, 30)
+	check(2000, 3000)
+}
+
+func TestRangeAppend(t *testing.T) {
+	list := New()
+	for i := 0; i < 100; i++ {
+		list.Set(float64(i*10), i)
+	}
+
+	buf := list.RangeAppend([]KV{{-1, "kept"}}, 25, 61)
+	if !slices.Equal(buf, []KV{{-1, "kept"}, {30, 3}, {40, 4}, {50, 5}, {60, 6}}) {
+		t.Fatal("RangeAppend must append to dst, got", buf)
+	}
+	if got := list.RangeAppend(nil, 60, 30); len(got) != 0 {
+		t.Fatal("an empty range appends nothing, got", got)
+	}
+
+	buf = make([]KV, 0, 16)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = list.RangeAppend(buf[:0], 100, 250)
+	})
+	if allocs != 0 || len(buf) != 16 {
+		t.Fatalf("a reused buffer must not allocate, got %v allocations and %v pairs", allocs, len(buf))
+	}
+}
+
+func TestFloorCeilingLowerHigher(t *testing.T) {
+	list := New()
+	for _, k := range []float64{10, 20, 30} {
+		list.Set(k, nil)
+	}
+
+	keyOf := func(c *Column) interface{} {
+		if c == nil {
+			return nil
+		}
+		return c.key
+	}
+	cases := []struct {
+		key                           float64
+		floor, ceiling, lower, higher interface{}
+	}{
+		{5, nil, 10.0, nil, 10.0},
+		{10, 10.0, 10.0, nil, 20.0},
+		{15, 10.0, 20.0, 10.0, 20.0},
+		{20, 20.0, 20.0, 10.0, 30.0},
+		{30, 30.0, 30.0, 20.0, nil},
+		{35, 30.0, nil, 30.0, nil},
+	}
+	for _, c := range cases {
+		if got := keyOf(list.Floor(c.key)); got != c.floor {
+			t.Fatalf("Floor(%v) is %v, expected %v", c.key, got, c.floor)
+		}
+		if got := keyOf(list.Ceiling(c.key)); got != c.ceiling {
+			t.Fatalf("Ceiling(%v) is %v, expected %v", c.key, got, c.ceiling)
+		}
+		if got := keyOf(list.Lower(c.key)); got != c.lower {
+			t.Fatalf("Lower(%v) is %v, expected %v", c.key, got, c.lower)
+		}
+		if got := keyOf(list.Higher(c.key)); got != c.higher {
+			t.Fatalf("Higher(%v) is %v, expected %v", c.key, got, c.higher)
+		}
+	}
+
+	empty := New()
+	if empty.Floor(1) != nil || empty.Ceiling(1) != nil || empty.Lower(1) != nil || empty.Higher(1) != nil {
+		t.Fatal("empty list has no neighbours")
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	list := New()
+	if list.Min() != nil || list.Max() != nil {
+		t.Fatal("empty list has no extremes")
+	}
+
+	for _, k := range []float64{5, -3, 9, 1, 7} {
+		list.Set(k, nil)
+	}
+	if c := list.Min(); c == nil || c.key != -3 {
+		t.Fatal(`wrong min (expected "-3")`, c)
+	}
+	if c := list.Max(); c == nil || c.key != 9 {
+		t.Fatal(`wrong max (expected "9")`, c)
+	}
+
+	list.Del(-3)
+	list.Del(9)
+	if list.Min().key != 1 || list.Max().key != 7 {
+		t.Fatal("extremes must follow deletions", list.Min().key, list.Max().key)
+	}
+}
+
+func TestTopKBottomK(t *testing.T) {
+	list := New()
+	if len(list.TopK(3)) != 0 || len(list.BottomK(3)) != 0 {
+		t.Fatal("an empty list has no top")
+	}
+	for i := 0; i < 100; i++ {
+		list.Set(float64(i), nil)
+	}
+
+	top, bottom := list.TopK(3), list.BottomK(3)
+	if len(top) != 3 || top[0].Key() != 99 || top[2].Key() != 97 {
+		t.Fatal("wrong top", top)
+	}
+	if len(bottom) != 3 || bottom[0].Key() != 0 || bottom[2].Key() != 2 {
+		t.Fatal("wrong bottom", bottom)
+	}
+	if len(list.TopK(1000)) != 100 || len(list.BottomK(-1)) != 0 {
+		t.Fatal("k must be clamped to the length")
+	}
+}
+
+func TestNearest(t *testing.T) {
+	list := New()
+	if list.Nearest(1) != nil {
+		t.Fatal("an empty list has no nearest key")
+	}
+	for _, k := range []float64{10, 20, 40} {
+		list.Set(k, nil)
+	}
+
+	for key, expected := range map[float64]float64{-5: 10, 10: 10, 14: 10, 15: 10, 16: 20, 29: 20, 31: 40, 100: 40, math.Inf(1): 40} {
+		if c := list.Nearest(key); c == nil || c.Key() != expected {
+			t.Fatalf("nearest to %v is %v, expected %v", key, c, expected)
+		}
+	}
+}
+
+func TestRangeWithLimit(t *testing.T) {
+	list := New()
+	for i := 0; i < 100; i++ {
+		list.Set(float64(i), nil)
+	}
+
+	page := list.RangeWithLimit(10, 50, 5, 3)
+	if len(page) != 3 || page[0].Key() != 15 || page[2].Key() != 17 {
+		t.Fatal("wrong page", page)
+	}
+	if page := list.RangeWithLimit(10, 50, 39, 10); len(page) != 2 || page[1].Key() != 50 {
+		t.Fatal("the page must stop at max", page)
+	}
+	if len(list.RangeWithLimit(10, 50, 0, -1)) != 41 || len(list.RangeWithLimit(10, 50, 41, 5)) != 0 {
+		t.Fatal("a negative limit takes everything after the offset")
+	}
+	if len(list.RangeWithLimit(10, 50, -1, 5)) != 0 || len(list.RangeWithLimit(10, 50, 0, 0)) != 0 || len(list.RangeWithLimit(1000, 2000, 0, 5)) != 0 {
+		t.Fatal("empty pages")
+	}
+}
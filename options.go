@@ -0,0 +1,95 @@
+package jumplist
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// Option configures a SkipList at construction.
+type Option func(list *SkipList)
+
+// WithTracer calls fn after every Set, Get and Del with the operation name, the key,
+// the level of the column involved (0 if there is none) and whether the key was found.
+// fn runs while the list is locked, so it must not call back into the list, and concurrent
+// Gets share the read lock, so fn has to be safe for concurrent use.
+func WithTracer(fn func(op string, key float64, level int, found bool)) Option {
+	return func(list *SkipList) {
+		list.tracer = fn
+	}
+}
+
+// WithMaxLevel sets the most levels a list grows to, 1 to 64, 18 by default.
+// A list stays fast up to about (1/p)^level columns for the probability p between levels.
+// It starts lower and adds a level each time its length passes (1/p)^height.
+func WithMaxLevel(level int) Option {
+	return func(list *SkipList) {
+		list.setLevels(level, list.probability)
+	}
+}
+
+// WithProbability sets the chance of a column to reach each further level, 1/e by default.
+// Lower values use less memory per column and take longer walks on each level.
+func WithProbability(p float64) Option {
+	return func(list *SkipList) {
+		list.setLevels(list.levelCap, p)
+	}
+}
+
+// WithRandSource draws the column levels from src instead of a time seeded source.
+// The list only uses src while locked, but src must not be used elsewhere concurrently.
+func WithRandSource(src rand.Source) Option {
+	return func(list *SkipList) {
+		list.randomSeed = src
+	}
+}
+
+// WithSeed draws the column levels from a source seeded with seed, so lists built by the same
+// sequence of calls get the same structure, which makes tests and benchmarks reproducible.
+func WithSeed(seed int64) Option {
+	return WithRandSource(rand.NewSource(seed))
+}
+
+// WithLocking(false) drops the mutex for a list used by one goroutine at a time, saving the
+// locking cost of every call. Such a list must not be shared without outside synchronization.
+func WithLocking(enabled bool) Option {
+	return func(list *SkipList) {
+		if enabled {
+			list.mutex = &sync.RWMutex{}
+		} else {
+			list.mutex = noLock{}
+		}
+	}
+}
+
+// WithLocker synchronizes the list with l, such as a lock shared with data kept in step with the list.
+// The list never takes l recursively, so l need not be reentrant.
+func WithLocker(l Locker) Option {
+	return func(list *SkipList) {
+		list.mutex = l
+	}
+}
+
+// WithoutLocking is WithLocking(false), for lists confined to one goroutine or synchronized outside.
+func WithoutLocking() Option {
+	return WithLocking(false)
+}
+
+// Locker is the synchronization strategy of a list, a *sync.RWMutex unless set with WithLocker or
+// turned off with WithoutLocking. A *sync.RWMutex satisfies it, and so does any reader-writer lock
+// with a TryLock, such as one instrumented for contention profiling. Lists wanting parallel writers
+// are better split with NewSharded, as a lock only decides who waits for the whole list.
+type Locker interface {
+	Lock()
+	Unlock()
+	RLock()
+	RUnlock()
+	TryLock() bool
+}
+
+type noLock struct{}
+
+func (noLock) Lock()         {}
+func (noLock) Unlock()       {}
+func (noLock) RLock()        {}
+func (noLock) RUnlock()      {}
+func (noLock) TryLock() bool { return true }
@@ -0,0 +1,190 @@
+package jumplist
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type traceRecord struct {
+	op    string
+	key   float64
+	level int
+	found bool
+}
+
+func TestWithTracer(t *testing.T) {
+	records := []traceRecord{}
+	list := New(WithTracer(func(op string, key float64, level int, found bool) {
+		records = append(records, traceRecord{op, key, level, found})
+	}))
+
+	c := list.Set(1, "a")
+	list.Set(1, "b")
+	list.Get(1)
+	list.Get(2)
+	list.Del(1)
+	list.Del(1)
+
+	expected := []traceRecord{
+		{"Set", 1, c.Level(), false},
+		{"Set", 1, c.Level(), true},
+		{"Get", 1, c.Level(), true},
+		{"Get", 2, 0, false},
+		{"Del", 1, c.Level(), true},
+		{"Del", 1, 0, false},
+	}
+	if len(records) != len(expected) {
+		t.Fatalf("got %v trace records, expected %v: %v", len(records), len(expected), records)
+	}
+	for i := range expected {
+		if records[i] != expected[i] {
+			t.Fatalf("trace record %v is %+v, expected %+v", i, records[i], expected[i])
+		}
+	}
+}
+
+func TestLevelOptions(t *testing.T) {
+	list := New(WithMaxLevel(4), WithProbability(0.5))
+	if list.maxLevel != 4 || len(list.startPointers.next) != 4 || list.levels.thresholds[3] != 1<<59 {
+		t.Fatal("options must size the list", list.maxLevel, list.levels)
+	}
+
+	perLevel := make([]int, 4)
+	for i := 0; i < 20000; i++ {
+		c := list.Set(float64(i), nil)
+		for l := 0; l < c.Level(); l++ {
+			perLevel[l]++
+		}
+	}
+	checkSanity(list, t)
+	if ratio := float64(perLevel[1]) / float64(perLevel[0]); ratio < 0.45 || ratio > 0.55 {
+		t.Fatal("half of the columns must reach level 2", ratio)
+	}
+
+	//options apply in order, so the level count can come after the probability
+	if list := New(WithProbability(0.25), WithMaxLevel(2)); list.maxLevel != 2 || list.probability != 0.25 {
+		t.Fatal("options must combine", list.maxLevel, list.probability)
+	}
+	if list := NewWithLevel(3, WithProbability(0.25)); list.maxLevel != 3 || list.levels.thresholds[0] != 1<<61 {
+		t.Fatal("NewWithLevel must take options", list.maxLevel)
+	}
+}
+
+func TestNewWithProbability(t *testing.T) {
+	for _, c := range []struct {
+		p        float64
+		n, level int
+	}{{1 / math.E, 65659969, 18}, {0.5, 1024, 10}, {0.5, 1025, 11}, {0.25, 1000000, 10}, {0.5, 0, 1}, {0.5, 1 << 62, 62}} {
+		list := NewWithProbability(c.p, c.n)
+		checkSanity(list, t)
+		if list.levelCap != c.level || list.probability != c.p {
+			t.Fatalf("p=%v n=%v: got %v levels, expected %v", c.p, c.n, list.levelCap, c.level)
+		}
+	}
+	if list := NewWithProbability(0.5, 100, WithMaxLevel(3)); list.levelCap != 3 {
+		t.Fatal("options must apply afterwards", list.levelCap)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("a bad probability must panic")
+		}
+	}()
+	NewWithProbability(1.5, 100)
+}
+
+func TestWithLocking(t *testing.T) {
+	list := New(WithLocking(false))
+	if _, ok := list.mutex.(noLock); !ok {
+		t.Fatal("locking must be off")
+	}
+	for i := 0; i < 1000; i++ {
+		list.Set(float64(i), i)
+	}
+	list.Del(5)
+	if c, err := list.TrySet(5, nil); err != nil || c == nil {
+		t.Fatal("TrySet must succeed without a lock")
+	}
+	checkSanity(list, t)
+
+	left, _ := list.Split(500)
+	if _, ok := left.mutex.(noLock); !ok {
+		t.Fatal("split halves must keep locking off")
+	}
+	if _, ok := New(WithLocking(false), WithLocking(true)).mutex.(*sync.RWMutex); !ok {
+		t.Fatal("locking must be back on")
+	}
+	if _, ok := New(WithoutLocking()).mutex.(noLock); !ok {
+		t.Fatal("WithoutLocking must turn locking off")
+	}
+}
+
+func TestWithSeed(t *testing.T) {
+	levels := func(list *SkipList) []int {
+		for i := 0; i < 1000; i++ {
+			list.Set(float64(i*7%1000), nil)
+		}
+		out := []int{}
+		for c := list.Front(); c != nil; c = c.Next() {
+			out = append(out, c.Level())
+		}
+		return out
+	}
+
+	a, b, c := levels(New(WithSeed(42))), levels(New(WithSeed(42))), levels(New(WithSeed(43)))
+	same := func(x, y []int) bool {
+		for i := range x {
+			if x[i] != y[i] {
+				return false
+			}
+		}
+		return true
+	}
+	if !same(a, b) {
+		t.Fatal("lists built with the same seed must have the same levels")
+	}
+	if same(a, c) {
+		t.Fatal("lists built with other seeds should differ")
+	}
+
+	keys := make([]float64, 10000)
+	for i := range keys {
+		keys[i] = float64(i)
+	}
+	p1 := NewParallel(18, 4, keys, make([]interface{}, len(keys)), WithSeed(1))
+	p2 := NewParallel(18, 4, keys, make([]interface{}, len(keys)), WithSeed(1))
+	for x, y := p1.Front(), p2.Front(); x != nil; x, y = x.Next(), y.Next() {
+		if x.Level() != y.Level() {
+			t.Fatal("parallel builds with the same seed must have the same levels")
+		}
+	}
+}
+
+// countingLocker counts the acquisitions of the lock it wraps
+type countingLocker struct {
+	sync.RWMutex
+	writes, reads atomic.Int64
+}
+
+func (l *countingLocker) Lock()  { l.writes.Add(1); l.RWMutex.Lock() }
+func (l *countingLocker) RLock() { l.reads.Add(1); l.RWMutex.RLock() }
+
+func TestWithLocker(t *testing.T) {
+	l := &countingLocker{}
+	list := New(WithLocker(l))
+	list.Set(1, nil)
+	list.Set(2, nil)
+	list.Get(1)
+	list.Len()
+	if l.writes.Load() != 2 || l.reads.Load() != 2 {
+		t.Fatalf("got %v writes and %v reads through the locker, expected 2 and 2", l.writes.Load(), l.reads.Load())
+	}
+
+	hooked := New(WithLocker(l), OnInsert(func(*Column) {}))
+	hooked.Set(3, nil)
+	if l.writes.Load() != 3 {
+		t.Fatal("hooks must wrap the locker, not replace it")
+	}
+}
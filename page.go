@@ -0,0 +1,99 @@
+package jumplist
+
+import (
+	"errors"
+	"math"
+	"strconv"
+)
+
+var errPageCursor = errors.New("jumplist: malformed page cursor")
+
+// PageCursor marks where a page ended by the last key seen, so paging holds no lock or rank between
+// calls and keys changing meanwhile do not shift later pages. The zero PageCursor starts at the front.
+type PageCursor struct {
+	after float64
+	state byte //pageStart, pageAfter or pageEnd
+}
+
+const (
+	pageStart byte = iota
+	pageAfter
+	pageEnd
+)
+
+// End reports whether the page returning the cursor was the last one.
+func (cursor PageCursor) End() bool {
+	return cursor.state == pageEnd
+}
+
+// String encodes the cursor for a URL or a response body: empty at the front, "end" past the end
+// and otherwise the bits of the last key in hexadecimal.
+func (cursor PageCursor) String() string {
+	switch cursor.state {
+	case pageStart:
+		return ""
+	case pageEnd:
+		return "end"
+	}
+	return strconv.FormatUint(math.Float64bits(cursor.after), 16)
+}
+
+// ParsePageCursor decodes a cursor produced by PageCursor.String.
+func ParsePageCursor(s string) (PageCursor, error) {
+	switch s {
+	case "":
+		return PageCursor{}, nil
+	case "end":
+		return PageCursor{state: pageEnd}, nil
+	}
+	bits, err := strconv.ParseUint(s, 16, 64)
+	if err != nil || math.IsNaN(math.Float64frombits(bits)) {
+		return PageCursor{}, errPageCursor
+	}
+	return PageCursor{after: math.Float64frombits(bits), state: pageAfter}, nil
+}
+
+// Page returns up to limit columns following cursor in key order and the cursor of the next page.
+// Each call takes the read lock once and seeks in O(log n). A multiset page may run past limit
+// rather than end inside a run of equal keys, which the next cursor could not tell apart.
+func (list *SkipList) Page(cursor PageCursor, limit int) ([]*Column, PageCursor) {
+	if limit < 1 {
+		panic("limit must be positive")
+	}
+	if cursor.End() {
+		return nil, cursor
+	}
+
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	max := math.Inf(1)
+	if back := list.back(); back != nil {
+		max = back.key //the greatest key, which need not be +Inf with WithComparator
+	}
+	return list.page(list.resume(cursor), limit, max)
+}
+
+// resume returns the first column past cursor.
+func (list *SkipList) resume(cursor PageCursor) *Column {
+	if cursor.state != pageAfter {
+		return list.startPointers.next[0]
+	}
+	column := list.seek(cursor.after)
+	for column != nil && column.key == cursor.after {
+		column = column.next[0]
+	}
+	return column
+}
+
+// page collects up to limit columns from column on with keys not greater than max.
+func (list *SkipList) page(column *Column, limit int, max float64) ([]*Column, PageCursor) {
+	columns := make([]*Column, 0, limit)
+	for ; column != nil && !list.keyLess(max, column.key) && (len(columns) < limit || column.key == columns[len(columns)-1].key); column = column.next[0] {
+		columns = append(columns, column)
+	}
+	if column == nil || list.keyLess(max, column.key) {
+		return columns, PageCursor{state: pageEnd}
+	}
+	return columns, PageCursor{after: columns[len(columns)-1].key, state: pageAfter}
+}
@@ -0,0 +1,58 @@
+package jumplist
+
+import "testing"
+
+func TestPage(t *testing.T) {
+	list := New()
+	for i := 0; i < 25; i++ {
+		list.Set(float64(i), i)
+	}
+
+	var cursor PageCursor
+	seen := 0
+	for pages := 0; !cursor.End(); pages++ {
+		if pages > 3 {
+			t.Fatal("paging must end after 3 pages")
+		}
+		var columns []*Column
+		columns, cursor = list.Page(cursor, 10)
+		for _, c := range columns {
+			if c.key != float64(seen) {
+				t.Fatalf("page %v holds %v, expected %v", pages, c.key, seen)
+			}
+			seen++
+		}
+
+		//the cursor survives a round trip through its string form
+		parsed, err := ParsePageCursor(cursor.String())
+		if err != nil || parsed != cursor {
+			t.Fatalf("cursor %q parsed to %v, %v", cursor.String(), parsed, err)
+		}
+
+		list.Del(float64(seen)) //changes between pages do not shift what comes later
+		list.Set(-1, nil)
+		seen++
+	}
+	if seen != 26 {
+		t.Fatalf("paged through %v keys, expected 26", seen)
+	}
+	if columns, next := list.Page(cursor, 10); columns != nil || !next.End() {
+		t.Fatal("paging past the end must stay at the end")
+	}
+
+	multi := New(AllowDuplicates())
+	for _, k := range []float64{1, 2, 2, 2, 3} {
+		multi.Set(k, nil)
+	}
+	columns, cursor := multi.Page(PageCursor{}, 2)
+	if len(columns) != 4 || columns[3].key != 2 {
+		t.Fatal("a page must not end inside a run of equal keys", len(columns))
+	}
+	if columns, cursor = multi.Page(cursor, 2); len(columns) != 1 || columns[0].key != 3 || !cursor.End() {
+		t.Fatal("the next page must start after the run", len(columns))
+	}
+
+	if _, err := ParsePageCursor("not hex"); err == nil {
+		t.Fatal("a malformed cursor must fail to parse")
+	}
+}
@@ -0,0 +1,128 @@
+package jumplist
+
+import (
+	"encoding/gob"
+	"errors"
+	"io"
+)
+
+var (
+	errUnsorted = errors.New("jumplist: stored keys are not in ascending order")
+	errNaNKey   = errors.New("jumplist: NaN key")
+)
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+// WriteTo checkpoints the list to w as a gob stream: the column count followed by every pair in key order.
+// Every gob message is framed with its CRC-32 and the checkpoint ends with the CRC-32 of all of it, so
+// ReadFrom and Verify detect a checkpoint that was truncated or damaged.
+// Values are gob encoded as interface values, so their concrete types must be registered with gob.Register
+// unless they are basic types, or go through the codec registered for their type with RegisterCodec.
+func (list *SkipList) WriteTo(w io.Writer) (int64, error) {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	cw := &countingWriter{w: w}
+	fw, err := newFrameWriter(cw)
+	if err != nil {
+		return cw.n, err
+	}
+	encoder := gob.NewEncoder(fw)
+	if err := encoder.Encode(list.length); err != nil {
+		return cw.n, err
+	}
+	if err := fw.flush(); err != nil {
+		return cw.n, err
+	}
+	for column := list.startPointers.next[0]; column != nil; column = column.next[0] {
+		value, err := encodeValue(column.Value)
+		if err != nil {
+			return cw.n, err
+		}
+		if err := encoder.Encode(KV{column.key, value}); err != nil {
+			return cw.n, err
+		}
+		if err := fw.flush(); err != nil {
+			return cw.n, err
+		}
+	}
+	return cw.n, fw.close()
+}
+
+// ReadFrom replaces the content of the list with a checkpoint written by WriteTo. Columns are appended
+// in order with the evenly spaced levels of NewFromSorted, so the same checkpoint always loads the same way.
+// A checkpoint failing its checksums returns an error wrapping ErrCorruptCheckpoint, and checkpoints
+// written before they had checksums load unchecked. r may be read past the end of the checkpoint.
+// On error the list is left unchanged.
+func (list *SkipList) ReadFrom(r io.Reader) (int64, error) {
+	cr := &countingReader{r: r}
+	decoder, fr, err := openCheckpoint(cr)
+	if err != nil {
+		return cr.n, err
+	}
+
+	var n int
+	if err := decoder.Decode(&n); err != nil {
+		return cr.n, err
+	}
+	items := make([]KV, 0, min(n, 1<<16)) //the count is only trusted once the pairs are there
+	for i := 0; i < n; i++ {
+		var item KV
+		err := decoder.Decode(&item)
+		if err == nil {
+			item.Value, err = decodeValue(item.Value)
+		}
+		if err != nil {
+			return cr.n, err
+		}
+		if item.Key != item.Key {
+			return cr.n, errNaNKey
+		}
+		if i > 0 && (list.keyLess(item.Key, items[i-1].Key) || item.Key == items[i-1].Key && !list.duplicates) {
+			return cr.n, errUnsorted
+		}
+		items = append(items, item)
+	}
+	if fr != nil {
+		if err := fr.finish(); err != nil {
+			return cr.n, err
+		}
+	}
+
+	list.initZero()
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	list.clear()
+	list.reserve(len(items))
+	levels := make(evenLevels, list.maxLevel)
+	t := list.newTails()
+	for _, item := range items {
+		list.touch(list.appendColumn(t, levels.next(), item.Key, item.Value))
+	}
+	list.reweighAll()
+	list.resize(0)
+	list.evict()
+
+	return cr.n, nil
+}
@@ -0,0 +1,76 @@
+package jumplist
+
+import (
+	"bytes"
+	"encoding/gob"
+	"math"
+	"testing"
+)
+
+type persistedValue struct {
+	Name string
+}
+
+func TestWriteToReadFrom(t *testing.T) {
+	gob.Register(persistedValue{})
+
+	list := New()
+	for i := 0; i < 1000; i++ {
+		list.Set(float64(i), i)
+	}
+	list.Set(-1, persistedValue{"custom"})
+	list.Set(-2, nil)
+
+	buf := &bytes.Buffer{}
+	written, err := list.WriteTo(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written != int64(buf.Len()) {
+		t.Fatalf("WriteTo reported %v bytes, wrote %v", written, buf.Len())
+	}
+	checkpoint := buf.Bytes()
+
+	loaded := New()
+	loaded.Set(5000, nil)
+	if _, err := loaded.ReadFrom(bytes.NewReader(checkpoint)); err != nil {
+		t.Fatal(err)
+	}
+	checkSanity(loaded, t)
+	if loaded.ContentHash() != list.ContentHash() {
+		t.Fatal("loaded list must hold the same pairs")
+	}
+	if loaded.Get(-1).Value.(persistedValue).Name != "custom" || loaded.Get(-2).Value != nil {
+		t.Fatal("values must round trip", loaded.Get(-1).Value, loaded.Get(-2).Value)
+	}
+
+	//levels are rebuilt the same way on every load
+	again := New()
+	again.ReadFrom(bytes.NewReader(checkpoint))
+	for a, b := loaded.Front(), again.Front(); a != nil; a, b = a.Next(), b.Next() {
+		if a.Level() != b.Level() {
+			t.Fatalf("key %v loaded with level %v and %v", a.key, a.Level(), b.Level())
+		}
+	}
+
+	if _, err := loaded.ReadFrom(bytes.NewReader(checkpoint[:len(checkpoint)/2])); err == nil {
+		t.Fatal("a truncated checkpoint must fail")
+	}
+	if loaded.Len() != list.Len() {
+		t.Fatal("a failed load must leave the list alone")
+	}
+	nan := &bytes.Buffer{}
+	encoder := gob.NewEncoder(nan)
+	encoder.Encode(1)
+	encoder.Encode(KV{math.NaN(), nil})
+	if _, err := loaded.ReadFrom(nan); err != errNaNKey {
+		t.Fatal("a NaN key must fail the load", err)
+	}
+
+	lru := NewLRU(18, 10)
+	lru.ReadFrom(bytes.NewReader(checkpoint))
+	checkSanity(lru, t)
+	if lru.Len() != 10 {
+		t.Fatal("loading into an LRU list must respect its capacity", lru.Len())
+	}
+}
@@ -0,0 +1,29 @@
+package jumplist
+
+import "sync"
+
+// WithPooling recycles the columns removed from the list through a sync.Pool per level, so churn
+// under sustained inserts and deletes allocates far less. A removed column, such as the one returned
+// by Del or PopMin, keeps its key and value only until the next insert may reuse it, so read what you
+// need from it right away. Pooling is ignored for lists with an arena.
+func WithPooling() Option {
+	return func(list *SkipList) {
+		list.pool = &[64]sync.Pool{}
+	}
+}
+
+// pooled returns a recycled column of level levels, nil if there is none.
+func (list *SkipList) pooled(level int) *Column {
+	if list.pool == nil {
+		return nil
+	}
+	column, _ := list.pool[level-1].Get().(*Column)
+	return column
+}
+
+// release hands a column that left the list to the pool. It is not cleared, callers may still read it.
+func (list *SkipList) release(column *Column) {
+	if list.pool != nil && list.arena == nil && list.onRemove == nil && list.onExpire == nil {
+		list.pool[len(column.next)-1].Put(column)
+	}
+}
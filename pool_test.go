@@ -0,0 +1,69 @@
+package jumplist
+
+import "testing"
+
+func TestWithPooling(t *testing.T) {
+	list := New(WithPooling())
+	for i := 0; i < 1000; i++ {
+		list.Set(float64(i), i)
+	}
+
+	removed := list.Del(10)
+	if removed.Key() != 10 || removed.Value.(int) != 10 {
+		t.Fatal("a removed column must be readable until the next insert", removed)
+	}
+
+	reused := false
+	for i := 0; i < 1000; i++ { //churn, columns may move through the pool
+		list.Del(float64(i))
+		if c := list.Set(float64(i)+0.5, i); c == removed {
+			reused = true
+		}
+	}
+	list.RemoveRange(100, 200)
+	for i := 0; i < 1000; i++ {
+		list.Set(float64(i+5000), i)
+	}
+	checkSanity(list, t)
+	if list.Len() != 1900 {
+		t.Fatalf("wrong length %v after churn", list.Len())
+	}
+	for i := 200; i < 1000; i++ {
+		if c := list.Get(float64(i) + 0.5); c == nil || c.Value.(int) != i {
+			t.Fatalf("key %v holds %v", float64(i)+0.5, c)
+		}
+	}
+	if !reused {
+		t.Log("no column came back from the pool") //the pool may drop items at any GC
+	}
+
+	left, right := list.Split(3000)
+	list.Set(1, nil) //must not reuse the columns of the halves
+	checkSanity(left, t)
+	checkSanity(right, t)
+	if left.Len()+right.Len() != 1900 {
+		t.Fatal("split halves lost columns")
+	}
+}
+
+func BenchmarkPooling(b *testing.B) {
+	for _, pooled := range []bool{false, true} {
+		name := "alloc"
+		opts := []Option{}
+		if pooled {
+			name = "pooled"
+			opts = append(opts, WithPooling())
+		}
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			list := New(opts...)
+			for i := 0; i < 10000; i++ {
+				list.Set(float64(i), nil)
+			}
+			for i := 0; i < b.N; i++ {
+				list.Del(float64(i % 10000))
+				list.Set(float64(i%10000), nil)
+			}
+		})
+	}
+}
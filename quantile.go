@@ -0,0 +1,131 @@
+package jumplist
+
+import "math"
+
+// NewSampled returns a list in sampling mode. Observe keeps a uniform reservoir of at most capacity
+// observations keyed by the measured value, so Quantile estimates quantiles of the whole stream.
+// In this mode the Value of each column is the number of sampled observations with that key, so Set
+// and Put panic, and the counts are summed as by WithWeights, WeightedRank counting observations.
+func NewSampled(maxLevel, capacity int) *SkipList {
+	if capacity < 1 {
+		panic("capacity must be positive")
+	}
+	list := NewWithLevel(maxLevel, WithWeights(sampleCount))
+	list.sampleCap = capacity
+	return list
+}
+
+// sampleCount weighs a column of a sampled list by its count. Any other value, stored past Set, weighs 0.
+func sampleCount(value interface{}) float64 {
+	count, _ := value.(int)
+	return float64(count)
+}
+
+// checkUnsampled panics for op on a list made by NewSampled, whose values are the counts of Observe.
+func (list *SkipList) checkUnsampled(op string) {
+	if list.sampleCap > 0 {
+		panic(op + " on a list made by NewSampled, which only Observe may change")
+	}
+}
+
+// Observe feeds one measurement into a list made by NewSampled.
+func (list *SkipList) Observe(x float64) {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	if list.sampleCap == 0 {
+		panic("Observe needs a list made by NewSampled")
+	}
+	checkKey(x) //before an observation is dropped for it
+
+	list.sampleSeen++
+	if list.sampleLen >= list.sampleCap {
+		//reservoir is full, x replaces a random held observation with probability cap/seen
+		slot := list.randomSeed.Int63() % list.sampleSeen
+		if slot >= int64(list.sampleCap) {
+			return
+		}
+		list.unsample(list.weightedAt(float64(list.randomSeed.Int63() % int64(list.sampleLen))))
+	}
+
+	list.moveCursors(x)
+	column := list.levelCursors[0].next[0]
+	if column != nil && column.key == x {
+		column.Value = list.weight(column) + 1
+		list.reweighCursors(nil)
+	} else {
+		list.insertAtCursors(x, 1)
+	}
+	list.sampleLen++
+}
+
+func (list *SkipList) unsample(column *Column) {
+	list.sampleLen--
+	if cnt := list.weight(column); cnt > 1 {
+		column.Value = cnt - 1
+		list.reweighColumn(column)
+		return
+	}
+
+	list.del(column.key)
+}
+
+// weight is how many observations a column stands for, one unless the list is sampled.
+func (list *SkipList) weight(column *Column) int {
+	if list.sampleCap > 0 {
+		return int(sampleCount(column.Value))
+	}
+	return 1
+}
+
+// weightedAt descends to the column holding the rank-th observation (zero based), adding up the
+// counts in the sums on the way like byRank adds up spans.
+func (list *SkipList) weightedAt(rank float64) *Column {
+	pointers := &list.startPointers
+	seen := 0.0
+
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		for next := pointers.next[i]; next != nil && seen+pointers.sum[i] <= rank; next = pointers.next[i] {
+			seen += pointers.sum[i]
+			pointers = &next.pointerColumn
+		}
+	}
+	return pointers.next[0]
+}
+
+// Quantile returns the key at the q-quantile (0 <= q <= 1) of the list, or NaN if it is empty.
+// For a sampled list this is an estimate over everything observed so far.
+// Both a plain and a sampled list find it in O(log n), by rank or by the sums of the counts.
+func (list *SkipList) Quantile(q float64) float64 {
+	if column := list.QuantileElement(q); column != nil {
+		return column.key
+	}
+	return math.NaN()
+}
+
+// QuantileElement is Quantile returning the column at the q-quantile, nil if the list is empty.
+func (list *SkipList) QuantileElement(q float64) *Column {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	if q < 0 || q > 1 || math.IsNaN(q) {
+		panic("q must be within 0~1")
+	}
+
+	if list.sampleCap == 0 {
+		if list.length == 0 {
+			return nil
+		}
+		return list.byRank(int(q * float64(list.length-1)))
+	}
+
+	if list.sampleLen == 0 {
+		return nil
+	}
+	return list.weightedAt(math.Floor(q * float64(list.sampleLen-1)))
+}
+
+// Percentile returns the column at the p-th percentile (0 <= p <= 100), so Percentile(99) is the p99.
+func (list *SkipList) Percentile(p float64) *Column {
+	return list.QuantileElement(p / 100)
+}
@@ -0,0 +1,110 @@
+package jumplist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestQuantile(t *testing.T) {
+	list := New()
+	if !math.IsNaN(list.Quantile(0.5)) {
+		t.Fatal("quantile of an empty list must be NaN")
+	}
+
+	for i := 1; i <= 101; i++ {
+		list.Set(float64(i), nil)
+	}
+	if q := list.Quantile(0); q != 1 {
+		t.Fatalf("wrong min %v (expected 1)", q)
+	}
+	if q := list.Quantile(0.5); q != 51 {
+		t.Fatalf("wrong median %v (expected 51)", q)
+	}
+	if q := list.Quantile(1); q != 101 {
+		t.Fatalf("wrong max %v (expected 101)", q)
+	}
+}
+
+func TestQuantileElement(t *testing.T) {
+	list := New()
+	if list.QuantileElement(0.5) != nil || list.Percentile(99) != nil {
+		t.Fatal("an empty list has no quantile")
+	}
+
+	for i := 0; i < 1000; i++ {
+		list.Set(float64(i), i)
+	}
+	if c := list.QuantileElement(0.25); c == nil || c.Value != 249 {
+		t.Fatal("wrong first quartile", c)
+	}
+	if c := list.Percentile(99); c == nil || c.Key() != 989 {
+		t.Fatal("wrong p99", c)
+	}
+	if c := list.Percentile(100); c != list.Back() {
+		t.Fatal("p100 must be the largest key", c)
+	}
+}
+
+func TestSampledQuantile(t *testing.T) {
+	list := NewSampled(18, 2000)
+	list.randomSeed = rand.NewSource(1)
+
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 200000; i++ {
+		list.Observe(math.Floor(r.Float64()*1000) / 1000) //uniform on [0, 1) with repeats
+	}
+	checkSanity(list, t)
+
+	if list.sampleLen != 2000 {
+		t.Fatalf("reservoir holds %v observations, expected 2000", list.sampleLen)
+	}
+
+	if median := list.Quantile(0.5); math.Abs(median-0.5) > 0.05 {
+		t.Fatalf("estimated median %v is too far from 0.5", median)
+	}
+	if p95 := list.Quantile(0.95); math.Abs(p95-0.95) > 0.02 {
+		t.Fatalf("estimated p95 %v is too far from 0.95", p95)
+	}
+}
+
+func TestSampledQuantileByWeight(t *testing.T) {
+	list := NewSampled(18, 500)
+	list.randomSeed = rand.NewSource(3)
+	r := rand.New(rand.NewSource(4))
+	for i := 0; i < 20000; i++ {
+		list.Observe(float64(r.Intn(300)))
+	}
+	checkSanity(list, t)
+
+	for q := 0.0; q <= 1; q += 0.01 { //against a walk of level 0
+		rank, seen := math.Floor(q*float64(list.sampleLen-1)), 0
+		var expected *Column
+		for column := list.Front(); expected == nil; column = column.Next() {
+			if seen += column.Value.(int); float64(seen) > rank {
+				expected = column
+			}
+		}
+		if c := list.QuantileElement(q); c != expected {
+			t.Fatalf("quantile %v is %v, expected %v", q, c.Key(), expected.Key())
+		}
+	}
+	if list.WeightedRank(math.Inf(1)) != float64(list.sampleLen) {
+		t.Fatal("the sums must count the observations", list.WeightedRank(math.Inf(1)))
+	}
+
+	for name, fn := range map[string]func(){
+		"Set": func() { list.Set(1, "one") },
+		"Put": func() { list.Put(1, "one") },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error(name, "must panic on a sampled list")
+				}
+			}()
+			fn()
+		}()
+	}
+	list.Observe(1) //still counting
+}
@@ -0,0 +1,56 @@
+package jumplist
+
+import (
+	"context"
+	"sync"
+)
+
+// PopMin removes and returns the column with the smallest key, nil if the list is empty.
+func (list *SkipList) PopMin() *Column {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	column := list.startPointers.next[0]
+	if column == nil {
+		return nil
+	}
+	return list.del(column.key)
+}
+
+// PopMax removes and returns the column with the largest key, nil if the list is empty.
+func (list *SkipList) PopMax() *Column {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	column := list.back()
+	if column == nil {
+		return nil
+	}
+	return list.del(column.key)
+}
+
+// WaitPopMin removes and returns the column with the smallest key, blocking until the list is not empty
+// or ctx is done, which returns ctx.Err(). Waiters sleep on a condition variable of the list lock, so
+// waiting on an empty list created WithLocking(false) blocks forever.
+func (list *SkipList) WaitPopMin(ctx context.Context) (*Column, error) {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	if list.nonEmpty == nil {
+		list.nonEmpty = sync.NewCond(list.mutex)
+	}
+	stop := context.AfterFunc(ctx, func() {
+		list.mutex.Lock() //a waiter is either asleep or has not checked ctx yet
+		list.nonEmpty.Broadcast()
+		list.mutex.Unlock()
+	})
+	defer stop()
+
+	for list.startPointers.next[0] == nil {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		list.nonEmpty.Wait()
+	}
+	return list.del(list.startPointers.next[0].key), nil
+}
@@ -0,0 +1,123 @@
+package jumplist
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPopMinMax(t *testing.T) {
+	list := New()
+	if list.PopMin() != nil || list.PopMax() != nil {
+		t.Fatal("popping an empty list must return nil")
+	}
+
+	for _, k := range []float64{5, 3, 9, 1, 7} {
+		list.Set(k, nil)
+	}
+
+	for _, expected := range []float64{1, 3} {
+		if c := list.PopMin(); c == nil || c.key != expected {
+			t.Fatalf("PopMin returned %v, expected %v", c, expected)
+		}
+	}
+	for _, expected := range []float64{9, 7, 5} {
+		if c := list.PopMax(); c == nil || c.key != expected {
+			t.Fatalf("PopMax returned %v, expected %v", c, expected)
+		}
+	}
+	if list.Len() != 0 || list.PopMin() != nil {
+		t.Fatal("list must be empty after popping everything")
+	}
+}
+
+func TestPopMinConcurrent(t *testing.T) {
+	list := New()
+	for i := 0; i < 10000; i++ {
+		list.Set(float64(i), i)
+	}
+
+	popped := make([][]float64, 4)
+	wg := &sync.WaitGroup{}
+	for w := range popped {
+		wg.Add(1)
+		go func(w int) {
+			for c := list.PopMin(); c != nil; c = list.PopMin() {
+				popped[w] = append(popped[w], c.key)
+			}
+			wg.Done()
+		}(w)
+	}
+	wg.Wait()
+
+	seen := map[float64]bool{}
+	for _, keys := range popped {
+		for i, k := range keys {
+			if seen[k] {
+				t.Fatalf("key %v was popped twice", k)
+			}
+			if i > 0 && k <= keys[i-1] {
+				t.Fatal("every consumer must pop in ascending order")
+			}
+			seen[k] = true
+		}
+	}
+	if len(seen) != 10000 {
+		t.Fatalf("popped %v keys, expected 10000", len(seen))
+	}
+}
+
+func TestWaitPopMin(t *testing.T) {
+	list := New()
+	list.Set(3, nil)
+	if c, err := list.WaitPopMin(context.Background()); err != nil || c.key != 3 {
+		t.Fatal("a column already there must be returned right away", c, err)
+	}
+
+	const waiters = 8
+	popped := make(chan float64, waiters)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < waiters; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c, err := list.WaitPopMin(context.Background())
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			popped <- c.key
+		}()
+	}
+	for i := 0; i < waiters; i++ {
+		time.Sleep(time.Millisecond)
+		list.Set(float64(i), nil)
+	}
+	wg.Wait()
+	close(popped)
+
+	seen := map[float64]bool{}
+	for key := range popped {
+		if seen[key] {
+			t.Fatalf("key %v popped twice", key)
+		}
+		seen[key] = true
+	}
+	if len(seen) != waiters || list.Len() != 0 {
+		t.Fatalf("popped %v keys, expected %v", len(seen), waiters)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if c, err := list.WaitPopMin(ctx); c != nil || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("waiting on an empty list must end with the context", c, err)
+	}
+
+	hooked := New(OnRemove(func(*Column) {}))
+	go hooked.Append(1, nil)
+	if c, err := hooked.WaitPopMin(context.Background()); err != nil || c.key != 1 {
+		t.Fatal("appends must wake waiters too", c, err)
+	}
+}
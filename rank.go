@@ -0,0 +1,80 @@
+package jumplist
+
+// rank counts the columns with keys less than key, adding up spans on the way down.
+func (list *SkipList) rank(key float64) int {
+	pointers := &list.startPointers
+	rank := 0
+
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		for pointers.next[i] != nil && list.keyLess(pointers.next[i].key, key) {
+			rank += pointers.span[i]
+			pointers = &pointers.next[i].pointerColumn
+		}
+	}
+	return rank
+}
+
+// rankThrough counts the columns with keys less than or equal to key.
+func (list *SkipList) rankThrough(key float64) int {
+	pointers := &list.startPointers
+	rank := 0
+
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		for pointers.next[i] != nil && !list.keyLess(key, pointers.next[i].key) {
+			rank += pointers.span[i]
+			pointers = &pointers.next[i].pointerColumn
+		}
+	}
+	return rank
+}
+
+// byRank returns the column at zero based position i, nil if i is out of range.
+func (list *SkipList) byRank(i int) *Column {
+	if i < 0 || i >= list.length {
+		return nil
+	}
+
+	pointers := &list.startPointers
+	rank := 0
+	target := i + 1 //spans count the start pointers as rank 0
+
+	for l := list.maxLevel - 1; l >= 0; l-- {
+		for pointers.next[l] != nil && rank+pointers.span[l] <= target {
+			rank += pointers.span[l]
+			if rank == target {
+				return pointers.next[l]
+			}
+			pointers = &pointers.next[l].pointerColumn
+		}
+	}
+	return nil
+}
+
+// Rank returns the number of keys less than key, which is the zero based position of key
+// if it is present. It runs in O(log n).
+func (list *SkipList) Rank(key float64) int {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.rank(key)
+}
+
+// GetByRank returns the column at zero based position i in key order, nil if i is out of range.
+// It runs in O(log n).
+func (list *SkipList) GetByRank(i int) *Column {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.byRank(i)
+}
+
+// Count returns how many keys lie within [min, max] from two rank lookups, without visiting them.
+func (list *SkipList) Count(min, max float64) int {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	if list.keyLess(max, min) {
+		return 0
+	}
+	return list.rankThrough(max) - list.rank(min)
+}
@@ -0,0 +1,82 @@
+package jumplist
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRank(t *testing.T) {
+	list := New()
+	r := rand.New(rand.NewSource(1))
+	model := map[float64]bool{}
+	for i := 0; i < 5000; i++ {
+		key := float64(r.Intn(3000))
+		if r.Intn(3) == 0 {
+			list.Del(key)
+			delete(model, key)
+		} else {
+			list.Set(key, nil)
+			model[key] = true
+		}
+	}
+	list.ReplaceOrInsert(1500, "replaced")
+	model[1500] = true
+	checkSanity(list, t)
+
+	keys, _ := list.ExportColumns()
+	if len(keys) != len(model) {
+		t.Fatalf("list holds %v keys, expected %v", len(keys), len(model))
+	}
+	for i, k := range keys {
+		if c := list.GetByRank(i); c == nil || c.key != k {
+			t.Fatalf("GetByRank(%v) returned %v, expected %v", i, c, k)
+		}
+		if rank := list.Rank(k); rank != i {
+			t.Fatalf("Rank(%v) is %v, expected %v", k, rank, i)
+		}
+		if rank := list.Rank(k + 0.5); rank != i+1 {
+			t.Fatalf("Rank(%v) is %v, expected %v", k+0.5, rank, i+1)
+		}
+	}
+
+	if list.GetByRank(-1) != nil || list.GetByRank(len(keys)) != nil {
+		t.Fatal("out of range ranks must return nil")
+	}
+	if list.Rank(-1) != 0 || list.Rank(1e9) != len(keys) {
+		t.Fatal("ranks outside the keys must be 0 and Len")
+	}
+}
+
+func TestCount(t *testing.T) {
+	list := New()
+	if list.Count(-1, 1) != 0 {
+		t.Fatal("empty list has nothing to count")
+	}
+
+	for i := 0; i < 1000; i++ {
+		list.Set(float64(i*2), nil)
+	}
+
+	cases := []struct {
+		min, max float64
+		expected int
+	}{
+		{0, 1998, 1000},
+		{-100, 1e9, 1000},
+		{10, 20, 6},
+		{9, 21, 6},
+		{11, 19, 4},
+		{11, 11, 0},
+		{12, 12, 1},
+		{20, 10, 0},
+		{2000, 3000, 0},
+	}
+	for _, c := range cases {
+		if n := list.Count(c.min, c.max); n != c.expected {
+			t.Fatalf("Count(%v, %v) is %v, expected %v", c.min, c.max, n, c.expected)
+		}
+		if n := len(list.Range(c.min, c.max)); n != c.expected {
+			t.Fatalf("Count(%v, %v) does not agree with Range", c.min, c.max)
+		}
+	}
+}
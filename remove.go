@@ -0,0 +1,76 @@
+package jumplist
+
+// RemoveRange unlinks every column with a key within [min, max] under one lock acquisition
+// and returns how many were removed. Each level is cut once around the whole section.
+func (list *SkipList) RemoveRange(min, max float64) int {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	return list.removeRange(min, max)
+}
+
+func (list *SkipList) removeRange(min, max float64) int {
+	if list.keyLess(max, min) {
+		return 0
+	}
+
+	list.moveCursors(min)
+	first := list.levelCursors[0].next[0]
+	n := 0
+	var last *Column
+	for column := first; column != nil && !list.keyLess(max, column.key); column = column.next[0] {
+		n++
+		last = column
+		list.forget(column)
+	}
+	if n == 0 {
+		return 0
+	}
+
+	for i, cursor := range list.levelCursors {
+		distance := cursor.span[i]
+		next := cursor.next[i]
+		for next != nil && !list.keyLess(max, next.key) { //skip the section, its spans add up to the distance
+			distance += next.span[i]
+			next = next.next[i]
+		}
+		cursor.next[i] = next
+		cursor.span[i] = distance - n
+	}
+	list.reweighCursors(nil)
+	if last.next[0] != nil {
+		last.next[0].prev = first.prev
+	}
+	for column, i := first, 0; i < n; i++ { //the section is still linked on level 0 from first to last
+		next := column.next[0]
+		list.release(column)
+		column = next
+	}
+
+	list.resize(-n)
+	return n
+}
+
+// RemoveIf walks the list once under the write lock, unlinking every column for which pred holds as
+// it goes, and returns how many were removed. pred must not call back into the list.
+func (list *SkipList) RemoveIf(pred func(key float64, value interface{}) bool) int {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	for i := range list.levelCursors {
+		list.levelCursors[i], list.cursorRanks[i] = &list.startPointers, 0
+	}
+	list.cursorColumn = nil
+
+	n := 0
+	for column := list.startPointers.next[0]; column != nil; column = list.levelCursors[0].next[0] {
+		if !pred(column.key, column.Value) {
+			list.stepCursors()
+			continue
+		}
+		list.unlinkAtCursors()
+		list.trace("Del", column.key, column, true)
+		n++
+	}
+	return n
+}
@@ -0,0 +1,63 @@
+package jumplist
+
+import "testing"
+
+func TestRemoveRange(t *testing.T) {
+	list := New()
+	for i := 0; i < 1000; i++ {
+		list.Set(float64(i), i)
+	}
+
+	if n := list.RemoveRange(100.5, 199); n != 99 {
+		t.Fatalf("removed %v columns, expected 99", n)
+	}
+	checkSanity(list, t)
+	if list.Len() != 901 || list.Get(100) == nil || list.Get(101) != nil || list.Get(199) != nil || list.Get(200) == nil {
+		t.Fatal("only keys within the range must be removed")
+	}
+
+	if n := list.RemoveRange(-10, 9); n != 10 {
+		t.Fatalf("removed %v columns from the front, expected 10", n)
+	}
+	if n := list.RemoveRange(990, 5000); n != 10 {
+		t.Fatalf("removed %v columns from the back, expected 10", n)
+	}
+	if n := list.RemoveRange(150, 160); n != 0 {
+		t.Fatalf("removed %v columns from an empty gap, expected 0", n)
+	}
+	if n := list.RemoveRange(500, 400); n != 0 {
+		t.Fatalf("removed %v columns with an inverted range, expected 0", n)
+	}
+	checkSanity(list, t)
+
+	if n := list.RemoveRange(-1e9, 1e9); n != 881 || list.Len() != 0 || list.Front() != nil {
+		t.Fatalf("removed %v columns, expected everything to go", n)
+	}
+	checkSanity(list, t)
+}
+
+func TestRemoveIf(t *testing.T) {
+	list := New()
+	for i := 0; i < 1000; i++ {
+		list.Set(float64(i), i)
+	}
+
+	if n := list.RemoveIf(func(key float64, value interface{}) bool { return value.(int)%3 != 1 }); n != 667 {
+		t.Fatalf("removed %v columns, expected 667", n)
+	}
+	checkSanity(list, t)
+	if list.Len() != 333 || list.Get(1) == nil || list.Get(0) != nil || list.Get(999) != nil {
+		t.Fatal("only matching columns must be removed", list.Len())
+	}
+	if rank := list.Rank(997); rank != 332 {
+		t.Fatalf("spans must stay consistent, 997 ranks %v (expected 332)", rank)
+	}
+
+	if n := list.RemoveIf(func(float64, interface{}) bool { return false }); n != 0 || list.Len() != 333 {
+		t.Fatal("nothing must be removed", n)
+	}
+	if n := list.RemoveIf(func(float64, interface{}) bool { return true }); n != 333 || list.Front() != nil {
+		t.Fatal("everything must be removed", n)
+	}
+	checkSanity(list, t)
+}
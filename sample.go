@@ -0,0 +1,70 @@
+package jumplist
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// Sample returns n distinct columns drawn uniformly at random, in key order, or every column if the
+// list holds no more than n. Ranks are drawn with Floyd's algorithm and looked up by their spans, so it
+// takes O(n log N) without walking the list. It takes the write lock, the random source being shared.
+func (list *SkipList) Sample(n int) []*Column {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	if n <= 0 {
+		return nil
+	}
+	if n >= list.length {
+		columns := make([]*Column, 0, list.length)
+		for column := list.startPointers.next[0]; column != nil; column = column.next[0] {
+			columns = append(columns, column)
+		}
+		return columns
+	}
+
+	r := rand.New(list.randomSeed)
+	chosen := make(map[int]bool, n)
+	for j := list.length - n; j < list.length; j++ {
+		if rank := int(r.Int63n(int64(j + 1))); chosen[rank] {
+			chosen[j] = true
+		} else {
+			chosen[rank] = true
+		}
+	}
+
+	ranks := make([]int, 0, n)
+	for rank := range chosen {
+		ranks = append(ranks, rank)
+	}
+	sort.Ints(ranks)
+	columns := make([]*Column, len(ranks))
+	for i, rank := range ranks {
+		columns[i] = list.byRank(rank)
+	}
+	return columns
+}
+
+// SampleWeighted returns one column drawn at random with a chance proportional to weight(value), nil if
+// no column weighs anything. Weights must be finite, those not above zero are never picked. The weights
+// come from the call, so there is no index over them: it walks level 0 once, keeping each column with
+// the chance of its weight in the total so far, and takes O(n). It takes the write lock like Sample.
+func (list *SkipList) SampleWeighted(weight func(value interface{}) float64) *Column {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	r := rand.New(list.randomSeed)
+	var chosen *Column
+	total := 0.0
+	for column := list.startPointers.next[0]; column != nil; column = column.next[0] {
+		w := weight(column.Value)
+		if !(w > 0) { //NaN too
+			continue
+		}
+		total += w
+		if r.Float64()*total < w {
+			chosen = column
+		}
+	}
+	return chosen
+}
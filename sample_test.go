@@ -0,0 +1,62 @@
+package jumplist
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSample(t *testing.T) {
+	list := New(WithSeed(1))
+	if len(list.Sample(3)) != 0 {
+		t.Fatal("an empty list has nothing to sample")
+	}
+	for i := 0; i < 10; i++ {
+		list.Set(float64(i), nil)
+	}
+	if len(list.Sample(20)) != 10 || len(list.Sample(0)) != 0 {
+		t.Fatal("n must be clamped to the length")
+	}
+
+	counts := make([]int, 10)
+	const rounds = 20000
+	for i := 0; i < rounds; i++ {
+		sample := list.Sample(3)
+		if len(sample) != 3 || !(sample[0].Key() < sample[1].Key() && sample[1].Key() < sample[2].Key()) {
+			t.Fatal("a sample must hold distinct columns in key order", sample)
+		}
+		for _, c := range sample {
+			counts[int(c.Key())]++
+		}
+	}
+	for key, n := range counts {
+		if expected := rounds * 3 / 10; math.Abs(float64(n-expected)) > float64(expected)/20 {
+			t.Fatalf("key %v sampled %v times, expected about %v", key, n, expected)
+		}
+	}
+}
+
+func TestSampleWeighted(t *testing.T) {
+	list := New(WithSeed(1))
+	weight := func(value interface{}) float64 { return value.(float64) }
+	if list.SampleWeighted(weight) != nil {
+		t.Fatal("an empty list has nothing to pick")
+	}
+	for i, w := range []float64{1, 0, 3, -2, math.NaN(), 6} {
+		list.Set(float64(i), w)
+	}
+
+	counts := make([]int, list.Len())
+	const rounds = 20000
+	for i := 0; i < rounds; i++ {
+		counts[int(list.SampleWeighted(weight).Key())]++
+	}
+	for key, w := range []float64{1, 0, 3, 0, 0, 6} {
+		if expected := rounds * w / 10; math.Abs(float64(counts[key])-expected) > rounds/100+expected/20 {
+			t.Fatalf("key %v picked %v times, expected about %v", key, counts[key], expected)
+		}
+	}
+
+	if list.SampleWeighted(func(interface{}) float64 { return 0 }) != nil {
+		t.Fatal("nothing is picked when no column weighs anything")
+	}
+}
@@ -0,0 +1,29 @@
+package jumplist
+
+// FindByValue walks level 0 and returns every column whose value satisfies pred, in key order.
+// Values are not indexed, so this is always O(n).
+func (list *SkipList) FindByValue(pred func(interface{}) bool) []*Column {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	found := []*Column{}
+	for column := list.startPointers.next[0]; column != nil; column = column.next[0] {
+		if pred(column.Value) {
+			found = append(found, column)
+		}
+	}
+	return found
+}
+
+// ForEach calls fn with every key and value in key order while holding the read lock,
+// stopping early once fn returns false. fn must not write to the list.
+func (list *SkipList) ForEach(fn func(key float64, value interface{}) bool) {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	for column := list.startPointers.next[0]; column != nil; column = column.next[0] {
+		if !fn(column.key, column.Value) {
+			return
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package jumplist
+
+import "testing"
+
+func TestFindByValue(t *testing.T) {
+	list := New()
+	for i := 0; i < 100; i++ {
+		list.Set(float64(99-i), (99-i)%7)
+	}
+
+	found := list.FindByValue(func(v interface{}) bool { return v.(int) == 3 })
+	expected := []float64{3, 10, 17, 24, 31, 38, 45, 52, 59, 66, 73, 80, 87, 94}
+	if len(found) != len(expected) {
+		t.Fatalf("found %v columns, expected %v", len(found), len(expected))
+	}
+	for i, c := range found {
+		if c.key != expected[i] || c.Value.(int) != 3 {
+			t.Fatalf("column %v is %v=%v, expected %v=3", i, c.key, c.Value, expected[i])
+		}
+	}
+
+	if found := list.FindByValue(func(v interface{}) bool { return false }); len(found) != 0 {
+		t.Fatal("nothing must match a false predicate")
+	}
+}
+
+func TestForEach(t *testing.T) {
+	list := New()
+	for i := 0; i < 100; i++ {
+		list.Set(float64(99-i), 99-i)
+	}
+
+	visited := 0
+	list.ForEach(func(key float64, value interface{}) bool {
+		if key != float64(visited) || value.(int) != visited {
+			t.Fatalf("visited %v=%v at step %v", key, value, visited)
+		}
+		visited++
+		return true
+	})
+	if visited != 100 {
+		t.Fatalf("visited %v columns, expected 100", visited)
+	}
+
+	visited = 0
+	list.ForEach(func(key float64, _ interface{}) bool {
+		visited++
+		return key < 9
+	})
+	if visited != 10 {
+		t.Fatalf("visited %v columns after stopping at 9, expected 10", visited)
+	}
+
+	New().ForEach(func(float64, interface{}) bool {
+		t.Fatal("empty list must not call fn")
+		return true
+	})
+}
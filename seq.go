@@ -0,0 +1,36 @@
+package jumplist
+
+import "iter"
+
+// Seq returns the sequence number the last insert or update of the column got. Numbers grow with every
+// change to the list, so the column with the larger one was written later. Read it under the read lock
+// or while no one writes, like Value.
+func (column *Column) Seq() uint64 {
+	return column.seq
+}
+
+// Seq returns the sequence number of the last insert or update, 0 for a list never written to.
+// A replica that pulled ChangedSince(s) is up to date with the list as of the Seq read before.
+func (list *SkipList) Seq() uint64 {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.seq
+}
+
+// ChangedSince iterates in key order over the columns inserted or updated after sequence number seq,
+// for replication or cache invalidation pulling deltas. It walks level 0 under the read lock until
+// the loop ends, but yields only the changed columns. Removals leave no column behind, so they are
+// not seen here, Watch or the hooks report them.
+func (list *SkipList) ChangedSince(seq uint64) iter.Seq[*Column] {
+	return func(yield func(*Column) bool) {
+		list.mutex.RLock()
+		defer list.mutex.RUnlock()
+
+		for column := list.startPointers.next[0]; column != nil; column = column.next[0] {
+			if column.seq > seq && !yield(column) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package jumplist
+
+import "testing"
+
+func TestChangedSince(t *testing.T) {
+	list := New()
+	for i := 0; i < 100; i++ {
+		list.Set(float64(i), i)
+	}
+	since := list.Seq()
+	if since != 100 {
+		t.Fatalf("wrong sequence number %v after 100 inserts", since)
+	}
+
+	list.Set(50, "updated")
+	list.Set(200, "inserted")
+	list.Upsert(10, func(old interface{}, exists bool) interface{} { return "upserted" })
+	list.Del(20)
+
+	changed := []float64{}
+	for column := range list.ChangedSince(since) {
+		changed = append(changed, column.Key())
+		if column.Seq() <= since {
+			t.Fatal("only later changes must be yielded", column.Seq())
+		}
+	}
+	if len(changed) != 3 || changed[0] != 10 || changed[1] != 50 || changed[2] != 200 {
+		t.Fatal("wrong delta", changed)
+	}
+	if list.Get(200).Seq() <= list.Get(50).Seq() {
+		t.Fatal("later writes must get larger numbers")
+	}
+
+	n := 0
+	for range list.ChangedSince(0) {
+		n++
+	}
+	if n != list.Len() {
+		t.Fatalf("ChangedSince(0) yields %v columns out of %v", n, list.Len())
+	}
+
+	left, right := list.Split(60)
+	right.Set(300, nil)
+	if left.Len() == 0 || right.Get(300).Seq() <= right.Get(200).Seq() {
+		t.Fatal("a split list must keep counting on")
+	}
+
+	built := NewFromSorted([]float64{1, 2, 2}, []interface{}{nil, nil, nil})
+	if built.Seq() != 3 || built.Get(2).Seq() != 3 {
+		t.Fatal("builders must number their columns", built.Seq())
+	}
+}
@@ -0,0 +1,156 @@
+// Package server exposes a jumplist.SkipList over a small HTTP API with JSON bodies:
+//
+//	GET    /keys/{key}                               the value of key
+//	PUT    /keys/{key}                               sets key to the JSON value in the body
+//	DELETE /keys/{key}                               removes key
+//	GET    /range?min=&max=[&offset=][&limit=]       the pairs within [min, max], paged like ZRANGEBYSCORE
+//	GET    /stats                                    the Metrics and the Stats of the list
+//
+// Keys are parsed with strconv.ParseFloat, so "inf" and "-inf" work and NaN is rejected. Pairs are
+// written as {"key": k, "value": v}, infinite keys as the strings "+Inf" and "-Inf". Values are decoded
+// the way encoding/json decodes into interface{}, so numbers are stored as float64.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/abbychau/jumplist"
+)
+
+// MaxBodyBytes limits the size of a PUT body.
+const MaxBodyBytes = 1 << 20
+
+// Pair is a key and its value as written in responses.
+type Pair struct {
+	Key   Key         `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+// Key is a list key in JSON: a number, or "+Inf" and "-Inf" which JSON numbers cannot express.
+type Key float64
+
+// MarshalJSON writes the key as a number unless it is infinite.
+func (k Key) MarshalJSON() ([]byte, error) {
+	if math.IsInf(float64(k), 0) {
+		return json.Marshal(strconv.FormatFloat(float64(k), 'g', -1, 64))
+	}
+	return json.Marshal(float64(k))
+}
+
+// Handler returns the HTTP API of list. It is safe for concurrent use like the list.
+func Handler(list *jumplist.SkipList) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /keys/{key}", func(w http.ResponseWriter, r *http.Request) {
+		key, ok := parseKey(w, r.PathValue("key"))
+		if !ok {
+			return
+		}
+		value, ok := list.GetValue(key)
+		if !ok {
+			http.Error(w, "key not found", http.StatusNotFound)
+			return
+		}
+		reply(w, http.StatusOK, Pair{Key(key), value})
+	})
+	mux.HandleFunc("PUT /keys/{key}", func(w http.ResponseWriter, r *http.Request) {
+		key, ok := parseKey(w, r.PathValue("key"))
+		if !ok {
+			return
+		}
+		var value interface{}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxBodyBytes)).Decode(&value); err != nil {
+			status := http.StatusBadRequest
+			if errors.As(err, new(*http.MaxBytesError)) {
+				status = http.StatusRequestEntityTooLarge
+			}
+			http.Error(w, "malformed value: "+err.Error(), status)
+			return
+		}
+		status := http.StatusCreated
+		if _, _, updated := list.Put(key, value); updated {
+			status = http.StatusOK
+		}
+		reply(w, status, Pair{Key(key), value})
+	})
+	mux.HandleFunc("DELETE /keys/{key}", func(w http.ResponseWriter, r *http.Request) {
+		key, ok := parseKey(w, r.PathValue("key"))
+		if !ok {
+			return
+		}
+		if list.Del(key) == nil {
+			http.Error(w, "key not found", http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("GET /range", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		min, ok := parseKey(w, query.Get("min"))
+		if !ok {
+			return
+		}
+		max, ok := parseKey(w, query.Get("max"))
+		if !ok {
+			return
+		}
+		offset, ok := parseInt(w, query.Get("offset"), 0)
+		if !ok {
+			return
+		}
+		limit, ok := parseInt(w, query.Get("limit"), -1)
+		if !ok {
+			return
+		}
+
+		pairs := []Pair{}
+		for _, column := range list.RangeWithLimit(min, max, offset, limit) {
+			pairs = append(pairs, Pair{Key(column.Key()), list.LoadValue(column)}) //the lock is released by now
+		}
+		reply(w, http.StatusOK, pairs)
+	})
+	mux.HandleFunc("GET /stats", func(w http.ResponseWriter, r *http.Request) {
+		reply(w, http.StatusOK, struct {
+			Metrics jumplist.Metrics `json:"metrics"`
+			Stats   jumplist.Stats   `json:"stats"`
+		}{list.Metrics(), list.Stats()})
+	})
+	return mux
+}
+
+// parseKey parses a key, answering 400 for a malformed one or NaN.
+func parseKey(w http.ResponseWriter, s string) (float64, bool) {
+	key, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(key) {
+		http.Error(w, "malformed key "+strconv.Quote(s), http.StatusBadRequest)
+		return 0, false
+	}
+	return key, true
+}
+
+// parseInt parses an optional integer parameter, fallback being its value when absent.
+func parseInt(w http.ResponseWriter, s string, fallback int) (int, bool) {
+	if s == "" {
+		return fallback, true
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		http.Error(w, "malformed number "+strconv.Quote(s), http.StatusBadRequest)
+		return 0, false
+	}
+	return n, true
+}
+
+func reply(w http.ResponseWriter, status int, body interface{}) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(append(data, '\n'))
+}
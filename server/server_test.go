@@ -0,0 +1,76 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/abbychau/jumplist"
+)
+
+func TestHandler(t *testing.T) {
+	list := jumplist.New()
+	server := httptest.NewServer(Handler(list))
+	defer server.Close()
+
+	do := func(method, path, body string) (int, string) {
+		t.Helper()
+		req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, strings.TrimSpace(string(data))
+	}
+
+	if status, _ := do("PUT", "/keys/1.5", `{"name":"a"}`); status != http.StatusCreated {
+		t.Fatal("a new key must be created", status)
+	}
+	if status, _ := do("PUT", "/keys/1.5", `"b"`); status != http.StatusOK {
+		t.Fatal("an existing key must be updated", status)
+	}
+	if status, body := do("GET", "/keys/1.5", ""); status != http.StatusOK || body != `{"key":1.5,"value":"b"}` {
+		t.Fatal("wrong value", status, body)
+	}
+	do("PUT", "/keys/-inf", `1`)
+	for i := 2; i < 6; i++ {
+		do("PUT", "/keys/"+string(rune('0'+i)), `null`)
+	}
+
+	if status, body := do("GET", "/range?min=-inf&max=4&offset=1&limit=2", ""); status != http.StatusOK || body != `[{"key":1.5,"value":"b"},{"key":2,"value":null}]` {
+		t.Fatal("wrong range", status, body)
+	}
+	if _, body := do("GET", "/range?min=-inf&max=-inf", ""); body != `[{"key":"-Inf","value":1}]` {
+		t.Fatal("infinite keys must be written as strings", body)
+	}
+
+	if status, _ := do("DELETE", "/keys/1.5", ""); status != http.StatusNoContent {
+		t.Fatal("wrong delete", status)
+	}
+	if status, _ := do("GET", "/keys/1.5", ""); status != http.StatusNotFound {
+		t.Fatal("a deleted key must be gone", status)
+	}
+	if status, _ := do("DELETE", "/keys/1.5", ""); status != http.StatusNotFound {
+		t.Fatal("deleting an absent key must fail", status)
+	}
+
+	for _, bad := range [][3]string{{"GET", "/keys/nan", ""}, {"GET", "/keys/x", ""}, {"PUT", "/keys/1", "{"}, {"GET", "/range?min=0&max=1&limit=x", ""}} {
+		if status, _ := do(bad[0], bad[1], bad[2]); status != http.StatusBadRequest {
+			t.Fatal("malformed requests must be rejected", bad, status)
+		}
+	}
+	if status, _ := do("PUT", "/keys/1", `"`+strings.Repeat("x", MaxBodyBytes)+`"`); status != http.StatusRequestEntityTooLarge {
+		t.Fatal("large bodies must be rejected", status)
+	}
+
+	if status, body := do("GET", "/stats", ""); status != http.StatusOK || !strings.Contains(body, `"Columns":5`) {
+		t.Fatal("wrong stats", status, body)
+	}
+}
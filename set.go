@@ -0,0 +1,72 @@
+package jumplist
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Set is a sorted set of float64 keys without values, for key-only indexes where the memory per key
+// matters. It is a List with empty values, so each key costs its tower and the key itself: 32 bytes
+// where a Column of a SkipList takes over twice that. It has no spans or back links, so there are no
+// ranks or backward walks. Keys follow the ordering rules of SkipList, NaN panics.
+type Set struct {
+	list *List[float64, struct{}]
+}
+
+// NewSet returns an empty set.
+func NewSet() *Set {
+	return &Set{NewListFunc[float64, struct{}](18, cmp.Less[float64])}
+}
+
+// Add inserts key and reports whether it was absent.
+func (set *Set) Add(key float64) bool {
+	checkKey(key)
+
+	set.list.mutex.Lock()
+	defer set.list.mutex.Unlock()
+
+	_, added := set.list.set(key, struct{}{})
+	return added
+}
+
+// Has reports whether key is in the set.
+func (set *Set) Has(key float64) bool {
+	return set.list.Get(key) != nil
+}
+
+// Remove removes key and reports whether it was present.
+func (set *Set) Remove(key float64) bool {
+	return set.list.Del(key) != nil
+}
+
+// Range returns the keys within [min, max] in ascending order.
+func (set *Set) Range(min, max float64) []float64 {
+	set.list.mutex.RLock()
+	defer set.list.mutex.RUnlock()
+
+	keys := []float64{}
+	for node := set.list.seek(min); node != nil && node.key <= max; node = node.next[0] {
+		keys = append(keys, node.key)
+	}
+	return keys
+}
+
+// All iterates over the keys in ascending order, holding the read lock until the loop ends, so the
+// loop body must not write to the set.
+func (set *Set) All() iter.Seq[float64] {
+	return func(yield func(float64) bool) {
+		set.list.mutex.RLock()
+		defer set.list.mutex.RUnlock()
+
+		for node := set.list.startPointers[0]; node != nil; node = node.next[0] {
+			if !yield(node.key) {
+				return
+			}
+		}
+	}
+}
+
+// Len returns the number of keys.
+func (set *Set) Len() int {
+	return set.list.Len()
+}
@@ -0,0 +1,49 @@
+package jumplist
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"unsafe"
+)
+
+func TestSet(t *testing.T) {
+	set := NewSet()
+	for _, k := range []float64{5, 1, 3, math.Inf(1), math.Inf(-1)} {
+		if !set.Add(k) {
+			t.Fatal("adding the new key", k, "must report it")
+		}
+	}
+	if set.Add(3) {
+		t.Fatal("adding a present key must report nothing")
+	}
+	if !set.Add(math.Copysign(0, -1)) || set.Add(0) {
+		t.Fatal("-0 and 0 must be the same key")
+	}
+	if !set.Has(1) || set.Has(2) {
+		t.Fatal("Has must find exactly the keys added")
+	}
+	if !set.Remove(1) || set.Remove(1) || set.Has(1) {
+		t.Fatal("Remove must remove a key once")
+	}
+	if set.Len() != 5 {
+		t.Fatal("the set holds", set.Len(), "keys, expected 5")
+	}
+	if got := set.Range(0, 10); !slices.Equal(got, []float64{0, 3, 5}) {
+		t.Fatal("Range(0, 10) is", got)
+	}
+	if got := slices.Collect(set.All()); !slices.Equal(got, []float64{math.Inf(-1), 0, 3, 5, math.Inf(1)}) {
+		t.Fatal("All is", got)
+	}
+
+	if size := unsafe.Sizeof(Node[float64, struct{}]{}); size != 32 {
+		t.Fatal("a set node takes", size, "bytes, the empty value must not add padding")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("adding NaN must panic")
+		}
+	}()
+	set.Add(math.NaN())
+}
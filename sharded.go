@@ -0,0 +1,86 @@
+package jumplist
+
+import (
+	"math"
+	"math/rand"
+)
+
+// ShardedSkipList spreads keys over several SkipLists by a hash of the key, each with its own lock,
+// so writers to different shards do not wait on each other. Scans merge the shards back in order.
+type ShardedSkipList struct {
+	shards []*SkipList
+}
+
+// NewSharded returns a ShardedSkipList of n shards, options are applied to every shard.
+func NewSharded(n int, opts ...Option) *ShardedSkipList {
+	if n < 1 {
+		panic("n must be positive")
+	}
+	sharded := &ShardedSkipList{shards: make([]*SkipList, n)}
+	for i := range sharded.shards {
+		sharded.shards[i] = New(opts...)
+		if i > 0 { //a source given by WithRandSource would be shared, but shards are used concurrently
+			sharded.shards[i].randomSeed = rand.NewSource(sharded.shards[0].randomSeed.Int63())
+		}
+	}
+	return sharded
+}
+
+func (sharded *ShardedSkipList) shard(key float64) *SkipList {
+	if key == 0 {
+		key = 0 //-0 and +0 are the same key
+	}
+	//splitmix64 finalizer, float bits of small integers only differ in the high bits
+	h := math.Float64bits(key)
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return sharded.shards[h%uint64(len(sharded.shards))]
+}
+
+func (sharded *ShardedSkipList) Set(key float64, value interface{}) *Column {
+	return sharded.shard(key).Set(key, value)
+}
+
+func (sharded *ShardedSkipList) Get(key float64) *Column {
+	return sharded.shard(key).Get(key)
+}
+
+func (sharded *ShardedSkipList) Del(key float64) *Column {
+	return sharded.shard(key).Del(key)
+}
+
+// Len sums the shard lengths, each read separately, so it is only exact without concurrent writers.
+func (sharded *ShardedSkipList) Len() int {
+	n := 0
+	for _, shard := range sharded.shards {
+		n += shard.Len()
+	}
+	return n
+}
+
+// Range returns the columns with keys within [min, max] from every shard merged into ascending order.
+// Each shard is scanned under its own read lock, so the result is not one atomic snapshot.
+func (sharded *ShardedSkipList) Range(min, max float64) []*Column {
+	parts := make([][]*Column, len(sharded.shards))
+	total := 0
+	for i, shard := range sharded.shards {
+		parts[i] = shard.Range(min, max)
+		total += len(parts[i])
+	}
+
+	merged := make([]*Column, 0, total)
+	for len(merged) < total {
+		smallest := -1
+		for i, part := range parts {
+			if len(part) > 0 && (smallest < 0 || sharded.shards[0].keyLess(part[0].key, parts[smallest][0].key)) {
+				smallest = i
+			}
+		}
+		merged = append(merged, parts[smallest][0])
+		parts[smallest] = parts[smallest][1:]
+	}
+	return merged
+}
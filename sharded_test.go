@@ -0,0 +1,66 @@
+package jumplist
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestShardedSkipList(t *testing.T) {
+	sharded := NewSharded(8)
+
+	wg := &sync.WaitGroup{}
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			for i := w; i < 10000; i += 8 {
+				sharded.Set(float64(i), i)
+			}
+			wg.Done()
+		}(w)
+	}
+	wg.Wait()
+
+	if sharded.Len() != 10000 {
+		t.Fatalf("wrong length %v (expected 10000)", sharded.Len())
+	}
+	for _, shard := range sharded.shards {
+		if shard.Len() == 0 {
+			t.Fatal("keys must be spread over every shard")
+		}
+		checkSanity(shard, t)
+	}
+
+	if c := sharded.Get(1234); c == nil || c.Value.(int) != 1234 {
+		t.Fatal(`wrong "1234" value`, c)
+	}
+	if c := sharded.Del(1234); c == nil || sharded.Get(1234) != nil {
+		t.Fatal(`"1234" must be deleted`)
+	}
+
+	columns := sharded.Range(1200, 1300)
+	if len(columns) != 100 {
+		t.Fatalf("Range returned %v columns, expected 100", len(columns))
+	}
+	expected := 1200.0
+	for _, c := range columns {
+		if expected == 1234 {
+			expected++
+		}
+		if c.key != expected {
+			t.Fatalf("Range returned %v, expected %v", c.key, expected)
+		}
+		expected++
+	}
+}
+
+func BenchmarkShardedParallelSet(b *testing.B) {
+	sharded := NewSharded(16)
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			sharded.Set(float64(i), i)
+			i++
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package jumplist
+
+import (
+	"iter"
+	"sort"
+)
+
+// Snapshot is a read-only image of a list at the time it was taken. It holds the keys and values
+// in a sorted slice, so reading it takes no lock and never waits on writers of the list.
+type Snapshot struct {
+	items []KV
+	less  func(a, b float64) bool //order of the keys, that of the list
+}
+
+// Snapshot copies the keys and values under the read lock, which is held only for the copy.
+// Values are shared with the list, so a value that is mutated in place changes in the snapshot too.
+func (list *SkipList) Snapshot() *Snapshot {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return &Snapshot{list.items(), list.keyLess}
+}
+
+func (list *SkipList) items() []KV {
+	items := make([]KV, 0, list.length)
+	for column := list.startPointers.next[0]; column != nil; column = column.next[0] {
+		items = append(items, KV{column.key, column.Value})
+	}
+	return items
+}
+
+// Len returns the number of keys in the snapshot.
+func (snapshot *Snapshot) Len() int {
+	return len(snapshot.items)
+}
+
+// Get returns the value of key in O(log n) and whether it was present.
+func (snapshot *Snapshot) Get(key float64) (interface{}, bool) {
+	i := snapshot.search(key)
+	if i < len(snapshot.items) && snapshot.items[i].Key == key {
+		return snapshot.items[i].Value, true
+	}
+	return nil, false
+}
+
+// search returns the position of the first key not less than key.
+func (snapshot *Snapshot) search(key float64) int {
+	return sort.Search(len(snapshot.items), func(i int) bool { return !snapshot.less(snapshot.items[i].Key, key) })
+}
+
+// All iterates over every key and value in key order.
+func (snapshot *Snapshot) All() iter.Seq2[float64, interface{}] {
+	return snapshot.from(0)
+}
+
+// From iterates in key order starting at the first key not less than key.
+func (snapshot *Snapshot) From(key float64) iter.Seq2[float64, interface{}] {
+	return snapshot.from(snapshot.search(key))
+}
+
+func (snapshot *Snapshot) from(i int) iter.Seq2[float64, interface{}] {
+	return func(yield func(float64, interface{}) bool) {
+		for _, item := range snapshot.items[i:] {
+			if !yield(item.Key, item.Value) {
+				return
+			}
+		}
+	}
+}
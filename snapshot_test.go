@@ -0,0 +1,60 @@
+package jumplist
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	list := New()
+	for i := 0; i < 1000; i++ {
+		list.Set(float64(i), i)
+	}
+
+	snapshot := list.Snapshot()
+	list.Del(10)
+	list.Set(5, "changed")
+	list.Set(2000, nil)
+
+	if snapshot.Len() != 1000 {
+		t.Fatalf("snapshot holds %v keys, expected 1000", snapshot.Len())
+	}
+	if v, ok := snapshot.Get(10); !ok || v.(int) != 10 {
+		t.Fatal("snapshot must keep keys deleted afterwards", v, ok)
+	}
+	if v, _ := snapshot.Get(5); v.(int) != 5 {
+		t.Fatal("snapshot must keep the old values", v)
+	}
+	if _, ok := snapshot.Get(2000); ok {
+		t.Fatal("snapshot must not see later inserts")
+	}
+
+	n := 0
+	for k, v := range snapshot.From(990) {
+		if k != float64(990+n) || v.(int) != 990+n {
+			t.Fatalf("From yielded %v=%v at step %v", k, v, n)
+		}
+		n++
+	}
+	if n != 10 {
+		t.Fatalf("From yielded %v pairs, expected 10", n)
+	}
+
+	//scanning the snapshot does not hold up writers, run with -race
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		for i := 0; i < 1000; i++ {
+			list.Set(float64(i), nil)
+		}
+		wg.Done()
+	}()
+	sum := 0
+	for _, v := range snapshot.All() {
+		sum += v.(int)
+	}
+	wg.Wait()
+	if sum != 999*1000/2 {
+		t.Fatal("snapshot values changed during the scan", sum)
+	}
+}
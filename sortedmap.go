@@ -0,0 +1,94 @@
+package jumplist
+
+import (
+	"cmp"
+	"iter"
+)
+
+// SortedMap is a map kept in key order, for callers who want Get and Set with ordered iteration and
+// no nodes, levels or locks to think about. It is a List underneath and safe for concurrent use. Float
+// keys are ordered like cmp.Compare, NaN first and equal to itself.
+type SortedMap[K cmp.Ordered, V any] struct {
+	list *List[K, V]
+}
+
+// NewSortedMap returns an empty map.
+func NewSortedMap[K cmp.Ordered, V any]() *SortedMap[K, V] {
+	return &SortedMap[K, V]{NewList[K, V]()}
+}
+
+// Get returns the value at key and whether there is one.
+func (m *SortedMap[K, V]) Get(key K) (V, bool) {
+	m.list.mutex.RLock()
+	defer m.list.mutex.RUnlock()
+
+	if node := m.list.seek(key); m.list.found(node, key) {
+		return node.Value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Set stores value at key and reports whether the key was new.
+func (m *SortedMap[K, V]) Set(key K, value V) bool {
+	m.list.mutex.Lock()
+	defer m.list.mutex.Unlock()
+
+	_, added := m.list.set(key, value)
+	return added
+}
+
+// Delete removes key and reports whether it was present.
+func (m *SortedMap[K, V]) Delete(key K) bool {
+	return m.list.Del(key) != nil
+}
+
+// Len returns the number of keys.
+func (m *SortedMap[K, V]) Len() int {
+	return m.list.Len()
+}
+
+// Keys returns every key in ascending order.
+func (m *SortedMap[K, V]) Keys() []K {
+	m.list.mutex.RLock()
+	defer m.list.mutex.RUnlock()
+
+	keys := make([]K, 0, m.list.length)
+	for node := m.list.startPointers[0]; node != nil; node = node.next[0] {
+		keys = append(keys, node.key)
+	}
+	return keys
+}
+
+// Ascend iterates over the keys and values in ascending key order, holding the read lock until the
+// loop ends, so the loop body must not write to the map.
+func (m *SortedMap[K, V]) Ascend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.list.mutex.RLock()
+		defer m.list.mutex.RUnlock()
+
+		for node := m.list.startPointers[0]; node != nil; node = node.next[0] {
+			if !yield(node.key, node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Descend iterates over the keys and values in descending key order under the read lock like Ascend.
+// Nodes have no back links, so each step descends from the top in O(log n).
+func (m *SortedMap[K, V]) Descend() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.list.mutex.RLock()
+		defer m.list.mutex.RUnlock()
+
+		list := m.list
+		for node := list.last(func(*Node[K, V]) bool { return true }); node != nil; {
+			if !yield(node.key, node.Value) {
+				return
+			}
+			key := node.key
+			node = list.last(func(next *Node[K, V]) bool { return list.less(next.key, key) })
+		}
+	}
+}
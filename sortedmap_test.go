@@ -0,0 +1,63 @@
+package jumplist
+
+import (
+	"maps"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSortedMap(t *testing.T) {
+	m := NewSortedMap[string, int]()
+	model := map[string]int{}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 3000; i++ {
+		key := string(rune('a' + r.Intn(26)))
+		if r.Intn(3) == 0 {
+			_, held := model[key]
+			if m.Delete(key) != held {
+				t.Fatalf("Delete(%q) disagrees with the model", key)
+			}
+			delete(model, key)
+			continue
+		}
+		_, held := model[key]
+		if m.Set(key, i) == held {
+			t.Fatalf("Set(%q) must report a new key only", key)
+		}
+		model[key] = i
+	}
+
+	keys := slices.Sorted(maps.Keys(model))
+	if m.Len() != len(keys) || !slices.Equal(m.Keys(), keys) {
+		t.Fatal("Keys is", m.Keys(), "expected", keys)
+	}
+	for _, key := range keys {
+		if v, ok := m.Get(key); !ok || v != model[key] {
+			t.Fatalf("Get(%q) is %v %v, expected %v", key, v, ok, model[key])
+		}
+	}
+	if v, ok := m.Get("?"); ok || v != 0 {
+		t.Fatal("an absent key must give the zero value, got", v)
+	}
+
+	ascending := []string{}
+	for key, value := range m.Ascend() {
+		if value != model[key] {
+			t.Fatalf("Ascend yields %q=%v, expected %v", key, value, model[key])
+		}
+		ascending = append(ascending, key)
+	}
+	descending := []string{}
+	for key := range m.Descend() {
+		descending = append(descending, key)
+	}
+	slices.Reverse(descending)
+	if !slices.Equal(ascending, keys) || !slices.Equal(descending, keys) {
+		t.Fatal("Ascend is", ascending, "and reversed Descend is", descending, "expected", keys)
+	}
+	for range m.Descend() {
+		break //stopping early must release the lock
+	}
+	m.Set("z", 0)
+}
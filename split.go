@@ -0,0 +1,68 @@
+package jumplist
+
+import "math/rand"
+
+// Split cuts the list at key in O(log n): left gets the columns with smaller keys and right the rest.
+// The columns are moved, not copied, so the list itself is left empty. Both halves keep the level count,
+// probability, locking, tracer, shared arena, duplicates mode, WithEpsilon and WithComparator of the list, but not its
+// capacity bound.
+// A list that owned its arena gives it up, the halves keep living in it without resetting it.
+func (list *SkipList) Split(key float64) (left, right *SkipList) {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	left, right = list.sibling(), list.sibling()
+	list.moveCursors(key)
+	n := list.cursorRanks[0] //columns going left
+
+	for i, cursor := range list.levelCursors {
+		right.startPointers.next[i] = cursor.next[i]
+		right.startPointers.span[i] = cursor.span[i] + list.cursorRanks[i] - n //ranks on the right start after n
+		cursor.next[i] = nil
+		cursor.span[i] = n - list.cursorRanks[i]
+	}
+	if first := right.startPointers.next[0]; first != nil {
+		first.prev = nil
+	}
+	copy(left.startPointers.next, list.startPointers.next)
+	copy(left.startPointers.span, list.startPointers.span)
+	left.length, right.length = n, list.length-n
+	left.resize(0)
+	right.resize(0)
+
+	for i := range list.startPointers.next {
+		list.startPointers.next[i] = nil //the columns belong to the halves now, clear must not release them
+	}
+	list.ownsArena = false //the halves still live in it
+	list.clear()
+
+	return left, right
+}
+
+// sibling returns an empty list configured like this one, without the capacity bound. Clone calls it
+// under the read lock only, so the new seed is drawn under seedMutex.
+func (list *SkipList) sibling() *SkipList {
+	sibling := New(WithMaxLevel(list.levelCap), WithProbability(list.probability))
+	sibling.raise(list.maxLevel) //as tall as the columns it may receive
+	list.seedMutex.Lock()
+	sibling.randomSeed = rand.NewSource(list.randomSeed.Int63()) //sources are not safe for concurrent use
+	list.seedMutex.Unlock()
+	mutex := list.mutex
+	if h, ok := mutex.(*hookLocker); ok {
+		mutex = h.Locker //the hooks are not carried over
+	}
+	switch l := mutex.(type) {
+	case noLock:
+		sibling.mutex = noLock{}
+	case *stripedLocker:
+		sibling.mutex = newStripedLocker(len(l.stripes))
+	}
+	sibling.tracer = list.tracer
+	if !list.ownsArena {
+		sibling.arena = list.arena
+	}
+	sibling.duplicates, sibling.ties, sibling.tieLess = list.duplicates, list.ties, list.tieLess
+	sibling.epsilon, sibling.less = list.epsilon, list.less
+	sibling.seq = list.seq //moved columns keep their numbers, later changes must count on from them
+	return sibling
+}
@@ -0,0 +1,44 @@
+package jumplist
+
+import "testing"
+
+func TestSplit(t *testing.T) {
+	list := New()
+	for i := 0; i < 1000; i++ {
+		list.Set(float64(i), i)
+	}
+
+	left, right := list.Split(300.5)
+	checkSanity(left, t)
+	checkSanity(right, t)
+	checkSanity(list, t)
+	if left.Len() != 301 || right.Len() != 699 || list.Len() != 0 {
+		t.Fatalf("split into %v and %v, %v left behind", left.Len(), right.Len(), list.Len())
+	}
+	if left.Back().Key() != 300 || right.Front().Key() != 301 || right.Front().Prev() != nil {
+		t.Fatal("halves must end and start around the split key")
+	}
+
+	//the halves are independent
+	left.Set(2000, nil)
+	right.Del(500)
+	right.Set(-1, nil)
+	checkSanity(left, t)
+	checkSanity(right, t)
+	if right.Get(2000) != nil || left.Get(-1) != nil {
+		t.Fatal("writes to one half must not show in the other")
+	}
+
+	//cutting at either end leaves one half empty
+	all, none := right.Split(-100)
+	if all.Len() != 0 || none.Len() != 699 {
+		t.Fatalf("split before the front gave %v and %v", all.Len(), none.Len())
+	}
+	none, all = left.Split(1e9)
+	checkSanity(none, t)
+	if none.Len() != 302 || all.Len() != 0 {
+		t.Fatalf("split after the back gave %v and %v", none.Len(), all.Len())
+	}
+	list.Set(1, nil)
+	checkSanity(list, t)
+}
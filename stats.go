@@ -0,0 +1,108 @@
+package jumplist
+
+import (
+	"sync/atomic"
+	"unsafe"
+)
+
+// statsSamples caps the lookups replayed to measure the search depth.
+const statsSamples = 1024
+
+// Stats describes the shape of a list, for tuning maxLevel and the probability to a data size.
+type Stats struct {
+	Columns  int
+	MaxLevel int   //most levels the list may grow to
+	Height   int   //levels in use now, grown with the length
+	Heights  []int //Heights[i] counts the columns exactly i+1 levels tall
+
+	//AvgSearchDepth is the mean number of key comparisons a lookup makes on its way down,
+	//replayed for up to statsSamples evenly spaced keys.
+	AvgSearchDepth float64
+
+	//MemoryBytes estimates the list structure itself: columns, pointers and spans. Values are not counted.
+	MemoryBytes int
+
+	FingerSeeks int64 //calls to Finger.Seek
+	FingerHits  int64 //of those, the ones served without climbing to the top level
+}
+
+// Stats walks the list in O(n) under the read lock.
+func (list *SkipList) Stats() Stats {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	stats := Stats{
+		Columns:     list.length,
+		MaxLevel:    list.levelCap,
+		Height:      list.maxLevel,
+		Heights:     make([]int, list.maxLevel),
+		FingerSeeks: atomic.LoadInt64(&list.fingerSeeks),
+		FingerHits:  atomic.LoadInt64(&list.fingerHits),
+	}
+
+	stats.MemoryBytes = list.headerBytes()
+	for column := list.startPointers.next[0]; column != nil; column = column.next[0] {
+		stats.Heights[len(column.next)-1]++
+		stats.MemoryBytes += columnBytes(column)
+	}
+
+	if list.length > 0 {
+		samples := min(list.length, statsSamples)
+		compared := 0
+		for i := 0; i < samples; i++ {
+			compared += list.searchDepth(list.byRank(i * list.length / samples).key)
+		}
+		stats.AvgSearchDepth = float64(compared) / float64(samples)
+	}
+
+	return stats
+}
+
+// searchDepth replays seek for key and counts its key comparisons.
+func (list *SkipList) searchDepth(key float64) int {
+	pointers := &list.startPointers
+	compared := 0
+
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		next := pointers.next[i]
+		for next != nil && list.keyLess(next.key, key) {
+			compared++
+			pointers = &next.pointerColumn
+			next = next.next[i]
+		}
+		if next != nil {
+			compared++ //the column that stopped this level
+		}
+	}
+	return compared
+}
+
+// pointerSize is one level of a column, a next pointer and its span.
+const pointerSize = int(unsafe.Sizeof(&Column{}) + unsafe.Sizeof(0))
+
+// headerBytes is the list itself with its start pointers and cursors.
+func (list *SkipList) headerBytes() int {
+	return int(unsafe.Sizeof(*list)) + list.maxLevel*pointerSize*2
+}
+
+// columnBytes is a column with its next, span and weight sum slices, its value being one interface word pair.
+func columnBytes(column *Column) int {
+	return int(unsafe.Sizeof(*column)) + len(column.next)*pointerSize + len(column.sum)*int(unsafe.Sizeof(0.0))
+}
+
+// MemoryFootprint estimates the bytes held by the list in O(n) under the read lock: the columns, their
+// pointer and span slices and the keys, plus sizer(value) for every value if sizer is not nil, such as
+// len of a string or byte slice. Allocator rounding and the bookkeeping of LRU and TTL lists are not counted.
+func (list *SkipList) MemoryFootprint(sizer func(value interface{}) int64) int64 {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	bytes := int64(list.headerBytes())
+	for column := list.startPointers.next[0]; column != nil; column = column.next[0] {
+		bytes += int64(columnBytes(column))
+		if sizer != nil {
+			bytes += sizer(column.Value)
+		}
+	}
+	return bytes
+}
@@ -0,0 +1,64 @@
+package jumplist
+
+import (
+	"testing"
+	"unsafe"
+)
+
+func TestStats(t *testing.T) {
+	if stats := New().Stats(); stats.Columns != 0 || stats.AvgSearchDepth != 0 || stats.MemoryBytes == 0 {
+		t.Fatal("wrong stats for an empty list", stats)
+	}
+
+	list := New()
+	for i := 0; i < 10000; i++ {
+		list.Set(float64(i), nil)
+	}
+	stats := list.Stats()
+
+	total := 0
+	for i, n := range stats.Heights {
+		total += n
+		if i > 0 && i < 4 && n > stats.Heights[i-1] {
+			t.Fatal("taller columns must be rarer", stats.Heights)
+		}
+	}
+	if stats.Columns != 10000 || total != 10000 || stats.MaxLevel != 18 || stats.Height != len(stats.Heights) || stats.Height != 10 {
+		t.Fatal("every column must be counted once", stats.Columns, total)
+	}
+	if stats.AvgSearchDepth < 5 || stats.AvgSearchDepth > 60 {
+		t.Fatal("search depth must be logarithmic", stats.AvgSearchDepth)
+	}
+	if stats.MemoryBytes < 10000*int(unsafe.Sizeof(Column{})) {
+		t.Fatal("memory estimate must cover the columns", stats.MemoryBytes)
+	}
+
+	finger := list.NewFinger()
+	for i := 0; i < 100; i++ {
+		finger.Seek(float64(i))
+	}
+	if stats := list.Stats(); stats.FingerSeeks != 100 || stats.FingerHits < 90 {
+		t.Fatal("seeking in order must be served by the finger", stats.FingerSeeks, stats.FingerHits)
+	}
+}
+
+func TestMemoryFootprint(t *testing.T) {
+	list := New()
+	empty := list.MemoryFootprint(nil)
+	for i := 0; i < 1000; i++ {
+		list.Set(float64(i), "0123456789")
+	}
+
+	structure := list.MemoryFootprint(nil)
+	if structure != int64(list.Stats().MemoryBytes) {
+		t.Fatalf("footprint %v must match the Stats estimate %v without a sizer", structure, list.Stats().MemoryBytes)
+	}
+	if perColumn := (structure - empty) / 1000; perColumn < int64(unsafe.Sizeof(Column{})) || perColumn > 200 {
+		t.Fatalf("%v bytes per column is not plausible", perColumn)
+	}
+
+	withValues := list.MemoryFootprint(func(value interface{}) int64 { return int64(len(value.(string))) })
+	if withValues != structure+10*1000 {
+		t.Fatalf("sizer must add the value sizes, got %v for %v", withValues, structure)
+	}
+}
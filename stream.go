@@ -0,0 +1,39 @@
+package jumplist
+
+import "context"
+
+// streamChunk is how many columns Stream collects per read lock.
+const streamChunk = 256
+
+// Stream sends the columns with keys within [min, max] in ascending order from a goroutine, closing
+// the channel at the end or once ctx is done. The read lock is only held while a chunk is collected,
+// never while the consumer is waited on, so writers go ahead between chunks. Each chunk resumes after
+// the last key sent, so keys inserted behind the scan are missed and keys inserted ahead are seen.
+func (list *SkipList) Stream(ctx context.Context, min, max float64) <-chan *Column {
+	out := make(chan *Column)
+	go func() {
+		defer close(out)
+
+		list.mutex.RLock()
+		columns, cursor := list.page(list.seek(min), streamChunk, max)
+		list.mutex.RUnlock()
+
+		for {
+			for _, column := range columns {
+				select {
+				case out <- column:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if cursor.End() {
+				return
+			}
+
+			list.mutex.RLock()
+			columns, cursor = list.page(list.resume(cursor), streamChunk, max)
+			list.mutex.RUnlock()
+		}
+	}()
+	return out
+}
@@ -0,0 +1,42 @@
+package jumplist
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStream(t *testing.T) {
+	list := New()
+	for i := 0; i < 3*streamChunk; i++ {
+		list.Set(float64(i), i)
+	}
+
+	expected := 10.0
+	for column := range list.Stream(context.Background(), 10, 700) {
+		if column.key != expected {
+			t.Fatalf("streamed %v, expected %v", column.key, expected)
+		}
+		if column.key == 300 {
+			list.Set(20, "behind") //writers are not blocked by the scan
+			list.Del(600)          //in a chunk not collected yet
+		}
+		expected++
+		if expected == 600 {
+			expected++
+		}
+	}
+	if expected != 701 {
+		t.Fatalf("stream stopped before %v, expected 701", expected)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := list.Stream(ctx, 0, list.Back().key)
+	<-stream
+	cancel()
+	for range stream { //drains whatever was in flight, then closes
+	}
+
+	for range list.Stream(context.Background(), 5000, 6000) {
+		t.Fatal("nothing lies within the range")
+	}
+}
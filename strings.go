@@ -0,0 +1,27 @@
+package jumplist
+
+import "strings"
+
+// StringSkipList is a List keyed by strings in lexicographic byte order, for IDs and names that
+// would lose their order if hashed into float64 keys.
+type StringSkipList struct {
+	*List[string, interface{}]
+}
+
+// NewStringSkipList returns an empty string keyed list.
+func NewStringSkipList() *StringSkipList {
+	return &StringSkipList{NewList[string, interface{}]()}
+}
+
+// RangeByPrefix returns the nodes whose key starts with prefix in ascending order. Those keys are
+// contiguous in lexicographic order, so it seeks to prefix in O(log n) and stops at the first miss.
+func (list *StringSkipList) RangeByPrefix(prefix string) []*Node[string, interface{}] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	nodes := []*Node[string, interface{}]{}
+	for node := list.seek(prefix); node != nil && strings.HasPrefix(node.key, prefix); node = node.next[0] {
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
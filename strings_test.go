@@ -0,0 +1,48 @@
+package jumplist
+
+import "testing"
+
+func TestStringSkipList(t *testing.T) {
+	list := NewStringSkipList()
+	for i, k := range []string{"user:10", "user:2", "order:7", "user:1", "user", "users", "", "user:1"} {
+		list.Set(k, i)
+	}
+
+	keys := func(nodes []*Node[string, interface{}]) []string {
+		out := []string{}
+		for _, n := range nodes {
+			out = append(out, n.Key())
+		}
+		return out
+	}
+	equal := func(a, b []string) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	if got := keys(list.RangeByPrefix("user:")); !equal(got, []string{"user:1", "user:10", "user:2"}) {
+		t.Fatal("wrong prefix range", got)
+	}
+	if got := keys(list.RangeByPrefix("user")); !equal(got, []string{"user", "user:1", "user:10", "user:2", "users"}) {
+		t.Fatal("a prefix matches the key equal to it", got)
+	}
+	if got := keys(list.RangeByPrefix("")); len(got) != list.Len() {
+		t.Fatal("the empty prefix matches every key", got)
+	}
+	if got := list.RangeByPrefix("zzz"); len(got) != 0 {
+		t.Fatal("no key starts with zzz", keys(got))
+	}
+	if got := keys(list.Range("order", "user:10")); !equal(got, []string{"order:7", "user", "user:1", "user:10"}) {
+		t.Fatal("wrong lexicographic range", got)
+	}
+	if n := list.Get("user:1"); n == nil || n.Value != 7 {
+		t.Fatal(`wrong "user:1" value (expected 7)`, n)
+	}
+}
@@ -0,0 +1,82 @@
+package jumplist
+
+import (
+	"math"
+	"math/rand"
+)
+
+// StripedSkipList splits the keys by interval over n SkipLists, each with its own lock, so a writer in
+// one key region blocks neither readers nor writers of the others, inserts and removals included,
+// which WithStripes cannot offer a single list as its towers and spans reach across every region. The
+// stripes are lists of their own as in ShardedSkipList, with only Set, Get, Del, Len and Range. Being split by key instead of hash, a Range only visits the stripes it overlaps
+// and concatenates them in order without merging. Keys crowding one interval crowd one stripe, so the
+// bounds should follow where the keys fall.
+type StripedSkipList struct {
+	min, width float64
+	stripes    []*SkipList
+}
+
+// NewStriped returns a StripedSkipList of n stripes of equal width over [min, max). Keys outside go
+// to the first or the last stripe. Options are applied to every stripe.
+func NewStriped(n int, min, max float64, opts ...Option) *StripedSkipList {
+	if n < 1 {
+		panic("n must be positive")
+	}
+	if !(min < max) || math.IsInf(max-min, 0) {
+		panic("min and max must be finite with min below max")
+	}
+	striped := &StripedSkipList{min: min, width: (max - min) / float64(n), stripes: make([]*SkipList, n)}
+	for i := range striped.stripes {
+		striped.stripes[i] = New(opts...)
+		if i > 0 { //a source given by WithRandSource would be shared, but stripes are used concurrently
+			striped.stripes[i].randomSeed = rand.NewSource(striped.stripes[0].randomSeed.Int63())
+		}
+	}
+	return striped
+}
+
+// stripe returns the index of the stripe holding key, which never decreases as key grows.
+func (striped *StripedSkipList) stripe(key float64) int {
+	i := math.Floor((key - striped.min) / striped.width)
+	switch {
+	case !(i > 0): //NaN too, for Set to reject
+		return 0
+	case i >= float64(len(striped.stripes)):
+		return len(striped.stripes) - 1
+	}
+	return int(i)
+}
+
+func (striped *StripedSkipList) Set(key float64, value interface{}) *Column {
+	return striped.stripes[striped.stripe(key)].Set(key, value)
+}
+
+func (striped *StripedSkipList) Get(key float64) *Column {
+	return striped.stripes[striped.stripe(key)].Get(key)
+}
+
+func (striped *StripedSkipList) Del(key float64) *Column {
+	return striped.stripes[striped.stripe(key)].Del(key)
+}
+
+// Len sums the stripe lengths, each read separately, so it is only exact without concurrent writers.
+func (striped *StripedSkipList) Len() int {
+	n := 0
+	for _, stripe := range striped.stripes {
+		n += stripe.Len()
+	}
+	return n
+}
+
+// Range returns the columns with keys within [min, max] in ascending order, scanning each stripe it
+// overlaps under its own read lock, so the result is not one atomic snapshot.
+func (striped *StripedSkipList) Range(min, max float64) []*Column {
+	columns := []*Column{}
+	if !(min <= max) {
+		return columns
+	}
+	for i := striped.stripe(min); i <= striped.stripe(max); i++ {
+		columns = append(columns, striped.stripes[i].Range(min, max)...)
+	}
+	return columns
+}
@@ -0,0 +1,77 @@
+package jumplist
+
+import (
+	"math"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStripedSkipList(t *testing.T) {
+	striped := NewStriped(8, 0, 10000)
+
+	wg := &sync.WaitGroup{}
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			for i := w; i < 10000; i += 8 {
+				striped.Set(float64(i), i)
+			}
+			wg.Done()
+		}(w)
+	}
+	wg.Wait()
+	striped.Set(math.Inf(-1), "low")
+	striped.Set(math.Inf(1), "high")
+
+	if striped.Len() != 10002 {
+		t.Fatalf("wrong length %v (expected 10002)", striped.Len())
+	}
+	for i, stripe := range striped.stripes {
+		if stripe.Len() < 1250 {
+			t.Fatalf("stripe %v holds %v keys, expected 1250 or more", i, stripe.Len())
+		}
+		checkSanity(stripe, t)
+	}
+	if c := striped.Get(1234); c == nil || c.Value.(int) != 1234 {
+		t.Fatal(`wrong "1234" value`, c)
+	}
+	if c := striped.Del(1234); c == nil || striped.Get(1234) != nil {
+		t.Fatal(`"1234" must be deleted`)
+	}
+
+	columns := striped.Range(1200, 2600) //over three stripes
+	if len(columns) != 1400 {
+		t.Fatalf("Range returned %v columns, expected 1400", len(columns))
+	}
+	for i := 1; i < len(columns); i++ {
+		if columns[i].key <= columns[i-1].key {
+			t.Fatal("Range must return ascending keys", columns[i-1].key, columns[i].key)
+		}
+	}
+	if all := striped.Range(math.Inf(-1), math.Inf(1)); len(all) != striped.Len() || all[0].Value != "low" || all[len(all)-1].Value != "high" {
+		t.Fatal("keys outside the bounds must go to the edge stripes")
+	}
+	if len(striped.Range(30, 20)) != 0 {
+		t.Fatal("an empty range holds nothing")
+	}
+}
+
+func TestStripedIndependent(t *testing.T) {
+	striped := NewStriped(4, 0, 400)
+	striped.stripes[3].mutex.Lock() //a writer busy in the last region
+	defer striped.stripes[3].mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		striped.Set(10, "a")
+		striped.Get(150)
+		striped.Range(0, 299)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("other regions must not wait for the locked stripe")
+	}
+}
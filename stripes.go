@@ -0,0 +1,163 @@
+package jumplist
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// WithStripes splits the lock of the list into n stripes, each over an interval of n-th of the keys by
+// rank, so updating the value of a present key with Set or Put write locks only its stripe and runs
+// alongside the updates of other stripes and alongside Get, Contains, Range, Floor and Ceiling, which
+// do not look at values. The other reads lock every stripe, except RangeAppend, which read locks the
+// stripes it overlaps only. Inserts and removals relink towers whose spans reach across all the
+// stripes, so they still lock the whole list, as do updates on lists with TTLs, hooks, watchers,
+// access tracking, value indexes, bloom filters, metadata, aggregates, weights, duplicates or
+// sampling, which keep state beyond the value. Like WithLocker, it replaces the lock of the list.
+func WithStripes(n int) Option {
+	if n < 1 {
+		panic("n must be positive")
+	}
+	return func(list *SkipList) {
+		list.mutex = newStripedLocker(n)
+	}
+}
+
+// stripedLocker is the Locker of WithStripes. Its write lock is the one of the structure, its read lock
+// also read locks every stripe, so the methods not knowing about stripes are excluded from the updates.
+type stripedLocker struct {
+	keys    sync.RWMutex   //the columns and their links
+	stripes []sync.RWMutex //the values of an interval of ranks each, taken in ascending order
+}
+
+func newStripedLocker(n int) *stripedLocker {
+	return &stripedLocker{stripes: make([]sync.RWMutex, n)}
+}
+
+func (l *stripedLocker) Lock()         { l.keys.Lock() }
+func (l *stripedLocker) Unlock()       { l.keys.Unlock() }
+func (l *stripedLocker) TryLock() bool { return l.keys.TryLock() }
+
+func (l *stripedLocker) RLock() {
+	l.keys.RLock()
+	l.rlockStripes(0, len(l.stripes)-1)
+}
+
+func (l *stripedLocker) RUnlock() {
+	l.runlockStripes(0, len(l.stripes)-1)
+	l.keys.RUnlock()
+}
+
+// TryRLock is RLock if the lock and every stripe are free, for TryGet.
+func (l *stripedLocker) TryRLock() bool {
+	if !l.keys.TryRLock() {
+		return false
+	}
+	for i := range l.stripes {
+		if !l.stripes[i].TryRLock() {
+			l.runlockStripes(0, i-1)
+			l.keys.RUnlock()
+			return false
+		}
+	}
+	return true
+}
+
+func (l *stripedLocker) rlockStripes(lo, hi int) {
+	for i := lo; i <= hi; i++ {
+		l.stripes[i].RLock()
+	}
+}
+
+func (l *stripedLocker) runlockStripes(lo, hi int) {
+	for i := hi; i >= lo; i-- {
+		l.stripes[i].RUnlock()
+	}
+}
+
+// stripe returns the index of the stripe holding rank, the keys being read locked.
+func (l *stripedLocker) stripe(rank, length int) int {
+	return rank * len(l.stripes) / length
+}
+
+// striped returns the Locker of WithStripes, nil if the list has another one.
+func (list *SkipList) striped() *stripedLocker {
+	l, _ := list.mutex.(*stripedLocker)
+	return l
+}
+
+// rlockKeys takes the read lock of the structure only, for reads not looking at values. Without
+// WithStripes that is the read lock of the list.
+func (list *SkipList) rlockKeys() {
+	if l := list.striped(); l != nil {
+		l.keys.RLock()
+	} else {
+		list.mutex.RLock()
+	}
+}
+
+func (list *SkipList) runlockKeys() {
+	if l := list.striped(); l != nil {
+		l.keys.RUnlock()
+	} else {
+		list.mutex.RUnlock()
+	}
+}
+
+// valueOnly reports whether an update writes nothing but the value, its sequence number and the
+// atomic counters, which is what one stripe may do while the others are in use.
+func (list *SkipList) valueOnly() bool {
+	return !list.duplicates && list.sampleCap == 0 && (list.ttl == nil || len(list.ttl.expires) == 0) &&
+		list.lru == nil && list.valueIndex == nil && list.bloom == nil && list.metadata == nil &&
+		list.aggregates == nil && list.weigher == nil && !list.hooked() && len(list.watchers) == 0
+}
+
+// putStriped is put for a present key of a list with WithStripes, write locking only the stripe of
+// the key. ok is false when it did nothing, the key being missing or the update needing the write lock.
+func (list *SkipList) putStriped(key float64, value interface{}) (column *Column, prev interface{}, ok bool) {
+	l := list.striped()
+	if l == nil {
+		return nil, nil, false
+	}
+	l.keys.RLock()
+	defer l.keys.RUnlock()
+
+	if !list.valueOnly() {
+		return nil, nil, false
+	}
+	key = list.snap(key)
+	column, rank := list.seekRank(key)
+	if column == nil || column.key != key {
+		return nil, nil, false
+	}
+
+	stripe := &l.stripes[l.stripe(rank, list.length)]
+	stripe.Lock()
+	prev, column.Value = column.Value, value
+	column.seq = atomic.AddUint64(&list.seq, 1) //the other stripes count too, the plain writes hold the write lock
+	atomic.AddUint64(&list.changes, 1)
+	list.countChange(EventUpdate)
+	stripe.Unlock()
+
+	list.trace("Set", key, column, true)
+	return column, prev, true
+}
+
+// rangeAppendStriped is RangeAppend for a list with WithStripes, read locking only the stripes the
+// pairs are in.
+func (list *SkipList) rangeAppendStriped(l *stripedLocker, dst []KV, min, max float64) []KV {
+	l.keys.RLock()
+	defer l.keys.RUnlock()
+
+	column, rank := list.seekRank(min)
+	if column == nil || list.keyLess(max, column.key) {
+		return dst
+	}
+	lo, hi := l.stripe(rank, list.length), l.stripe(list.rankThrough(max)-1, list.length)
+	l.rlockStripes(lo, hi)
+	defer l.runlockStripes(lo, hi)
+
+	for ; column != nil && !list.keyLess(max, column.key); column = column.next[0] {
+		dst = append(dst, KV{column.key, column.Value})
+	}
+	return dst
+}
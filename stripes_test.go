@@ -0,0 +1,101 @@
+package jumplist
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithStripes(t *testing.T) {
+	list := New(WithStripes(4))
+	for i := 0; i < 1000; i++ {
+		list.Set(float64(i), i)
+	}
+
+	wg := &sync.WaitGroup{}
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < 1000; i += 8 {
+				list.Set(float64(i), -i) //updates only, each under its stripe
+				if c := list.Get(float64(i)); c == nil {
+					t.Error("missing key", i)
+				}
+				list.RangeAppend(nil, float64(i), float64(i+50))
+				if i%100 == 0 {
+					list.Set(float64(i)+0.5, i) //an insert takes the whole list
+					list.Del(float64(i) + 0.5)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	checkSanity(list, t)
+	for i := 0; i < 1000; i++ {
+		if v, _ := list.GetValue(float64(i)); v != -i {
+			t.Fatal("wrong value", v, "for", i)
+		}
+	}
+	if _, prev, updated := list.Put(5, "five"); !updated || prev != -5 {
+		t.Fatal("Put must report the value it overwrote", prev, updated)
+	}
+	if list.Seq() <= 1000 {
+		t.Fatal("striped updates must count in Seq", list.Seq())
+	}
+
+	left, _ := list.Split(500)
+	if left.striped() == nil || len(left.striped().stripes) != 4 {
+		t.Fatal("Split must keep the stripes")
+	}
+}
+
+func TestWithStripesIndependent(t *testing.T) {
+	list := New(WithStripes(4))
+	for i := 0; i < 100; i++ {
+		list.Set(float64(i), i)
+	}
+	l := list.striped()
+	l.stripes[0].Lock() //as an update of the first keys would
+
+	done := make(chan struct{})
+	go func() {
+		list.Set(90, "updated") //the last stripe
+		list.Get(10)
+		list.Range(0, 99)
+		list.RangeAppend(nil, 50, 99)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("a held stripe must not block the others or the reads of the keys")
+	}
+
+	blocked := make(chan struct{})
+	go func() {
+		list.Set(10, "updated")
+		close(blocked)
+	}()
+	select {
+	case <-blocked:
+		t.Fatal("an update must wait for the stripe of its key")
+	case <-time.After(50 * time.Millisecond):
+	}
+	l.stripes[0].Unlock()
+	<-blocked
+	if list.Get(10).Value != "updated" || list.Get(90).Value != "updated" {
+		t.Fatal("the updates must be applied")
+	}
+}
+
+func TestWithStripesFallback(t *testing.T) {
+	updates := 0
+	list := New(WithStripes(2), OnUpdate(func(*Column, interface{}) { updates++ }))
+	list.Set(1, "a")
+	list.Set(1, "b")
+	if updates != 1 {
+		t.Fatal("updates needing more than the value must take the write lock and run the hooks")
+	}
+}
@@ -0,0 +1,307 @@
+package jumplist
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/gob"
+	"errors"
+	"iter"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// Tiered keeps up to maxHot columns in memory and spills the rest to a sorted segment on disk, so it
+// holds more than fits in RAM when most keys are seldom used. The segment has the layout of WriteMapped
+// and is searched in place, its values gob encoded as in a checkpoint of WriteTo, so values take the
+// types WriteTo can store. Get and Range look at the hot list first and then at the segment. A Set
+// always lands in memory, and a Del of a spilled key leaves a tombstone in memory until the next spill.
+// Once the hot list grows past maxHot its least recently used half is merged with the segment into a
+// new one, a rewrite of the segment every maxHot/2 new keys. Tiered is safe for concurrent use.
+type Tiered struct {
+	mutex  sync.RWMutex         //held for writing by Set, Del and spills, the hot list has its own
+	hot    *SkipList            //tracks access, so the coldest columns are known
+	cold   *Mapped              //nil before the first spill
+	dead   map[float64]struct{} //spilled keys deleted since
+	path   string
+	maxHot int
+	length int
+}
+
+// NewTiered returns a tiered list spilling to the segment at path, which it takes up again when a
+// previous Tiered was closed there. opts are applied to the hot list, which must not be a multiset.
+func NewTiered(path string, maxHot int, opts ...Option) (*Tiered, error) {
+	if maxHot < 1 {
+		panic("maxHot must be positive")
+	}
+	t := &Tiered{
+		hot:    New(append(opts, WithAccessTracking())...),
+		dead:   map[float64]struct{}{},
+		path:   path,
+		maxHot: maxHot,
+	}
+	if t.hot.less != nil {
+		panic("Tiered needs the numeric order of keys, not WithComparator")
+	}
+	cold, err := OpenMapped(path)
+	if err == nil {
+		t.cold, t.length = cold, cold.Len()
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Len returns the number of keys in memory and on disk.
+func (t *Tiered) Len() int {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.length
+}
+
+// Get returns the value at key and whether there is one. A spilled value is decoded from the segment
+// and stays there, err reporting a value that does not decode.
+func (t *Tiered) Get(key float64) (value interface{}, ok bool, err error) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	if column := t.hot.Get(key); column != nil {
+		return column.Value, true, nil
+	}
+	data, ok := t.spilled(key)
+	if !ok {
+		return nil, false, nil
+	}
+	value, err = decodeSpilled(data)
+	return value, err == nil, err
+}
+
+// spilled returns the bytes of key in the segment unless it was deleted since.
+func (t *Tiered) spilled(key float64) ([]byte, bool) {
+	if t.cold == nil {
+		return nil, false
+	}
+	if _, dead := t.dead[key]; dead {
+		return nil, false
+	}
+	return t.cold.Get(key)
+}
+
+// Set stores value at key in memory and spills if the hot list grew too large. An error comes from
+// the spill: the value is set all the same, only the hot list could not shrink.
+func (t *Tiered) Set(key float64, value interface{}) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if _, _, updated := t.hot.Put(key, value); !updated {
+		if _, ok := t.spilled(key); !ok {
+			t.length++
+		}
+		delete(t.dead, key) //the hot value shadows the spilled one
+	}
+	if t.hot.Len() > t.maxHot {
+		return t.spill(t.maxHot / 2)
+	}
+	return nil
+}
+
+// Del removes key from memory and from the segment and reports whether it was present.
+func (t *Tiered) Del(key float64) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	_, spilled := t.spilled(key)
+	if spilled {
+		t.dead[key] = struct{}{}
+	}
+	if t.hot.Del(key) == nil && !spilled {
+		return false
+	}
+	t.length--
+	return true
+}
+
+// Range calls fn on the keys within [min, max] in ascending order with their values until fn returns
+// false, merging the hot list with the segment. The hot part is copied first and the segment read as
+// it goes, under the read lock, so fn must not write to t. err reports a spilled value that does not decode.
+func (t *Tiered) Range(min, max float64, fn func(key float64, value interface{}) bool) error {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	hot := t.hot.RangeAppend(nil, min, max)
+	next, stop := iter.Pull2(t.coldFrom(min))
+	defer stop()
+
+	key, data, ok := next()
+	for {
+		inCold := ok && key <= max
+		if !inCold && len(hot) == 0 {
+			return nil
+		}
+		if inCold && (len(hot) == 0 || key < hot[0].Key) {
+			if _, dead := t.dead[key]; !dead {
+				value, err := decodeSpilled(data)
+				if err != nil {
+					return err
+				}
+				if !fn(key, value) {
+					return nil
+				}
+			}
+			key, data, ok = next()
+			continue
+		}
+		if inCold && key == hot[0].Key {
+			key, data, ok = next() //shadowed by the hot value
+		}
+		if !fn(hot[0].Key, hot[0].Value) {
+			return nil
+		}
+		hot = hot[1:]
+	}
+}
+
+// coldFrom iterates over the segment from key, nothing before the first spill.
+func (t *Tiered) coldFrom(key float64) iter.Seq2[float64, []byte] {
+	if t.cold == nil {
+		return func(func(float64, []byte) bool) {}
+	}
+	return t.cold.From(key)
+}
+
+// Close spills every hot column and closes the segment, so a Tiered opened on the same path later
+// finds all the keys. t must not be used afterwards.
+func (t *Tiered) Close() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	err := t.spill(0)
+	if t.cold != nil {
+		if closeErr := t.cold.Close(); err == nil {
+			err = closeErr
+		}
+		t.cold = nil
+	}
+	return err
+}
+
+// spill merges the least recently used hot columns beyond keep with the segment into a new one,
+// dropping the tombstones, and replaces the old segment atomically. On error both tiers are unchanged.
+func (t *Tiered) spill(keep int) error {
+	victims := t.coldest(t.hot.Len() - keep)
+	if len(victims) == 0 && len(t.dead) == 0 {
+		return nil
+	}
+	slices.SortFunc(victims, func(a, b *Column) int { return cmp.Compare(a.key, b.key) })
+	spilling := make([]mappedEntry, len(victims))
+	for i, column := range victims {
+		data, err := encodeSpilled(column.Value)
+		if err != nil {
+			return err
+		}
+		spilling[i] = mappedEntry{key: column.key, value: data}
+	}
+
+	//the segment is written with the evenly spaced levels of NewFromSorted
+	levels := make(evenLevels, levelsFor(1/math.E, t.length))
+	entries := make([]mappedEntry, 0, t.length)
+	add := func(e mappedEntry) {
+		e.height = levels.next()
+		entries = append(entries, e)
+	}
+	for key, data := range t.coldFrom(math.Inf(-1)) {
+		for len(spilling) > 0 && spilling[0].key < key {
+			add(spilling[0])
+			spilling = spilling[1:]
+		}
+		if _, dead := t.dead[key]; !dead && (len(spilling) == 0 || spilling[0].key != key) {
+			add(mappedEntry{key: key, value: data})
+		}
+	}
+	for _, e := range spilling {
+		add(e)
+	}
+
+	cold, err := t.writeSegment(entries, len(levels))
+	if err != nil {
+		return err
+	}
+	for _, column := range victims {
+		t.hot.RemoveElement(column)
+	}
+	if t.cold != nil {
+		t.cold.Close() //the entries written from it are done with
+	}
+	t.cold = cold
+	clear(t.dead)
+	return nil
+}
+
+// coldest returns the n least recently used hot columns.
+func (t *Tiered) coldest(n int) []*Column {
+	hot := t.hot
+	hot.mutex.Lock()
+	defer hot.mutex.Unlock()
+
+	columns := make([]*Column, 0, max(n, 0))
+	for node := hot.lru.root.prev; node != &hot.lru.root && len(columns) < n; node = node.prev {
+		columns = append(columns, node.column)
+	}
+	return columns
+}
+
+// writeSegment writes entries to a temporary file next to the segment, moves it over the segment
+// and maps it.
+func (t *Tiered) writeSegment(entries []mappedEntry, levels int) (*Mapped, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(t.path), "jumplist-segment-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name()) //no-op once renamed
+
+	err = writeMapped(tmp, entries, levels)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), t.path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return OpenMapped(t.path)
+}
+
+// encodeSpilled gob encodes a value the way checkpoints store them, nil as no bytes at all since gob
+// cannot encode a nil interface.
+func encodeSpilled(value interface{}) ([]byte, error) {
+	if value == nil {
+		return nil, nil
+	}
+	encoded, err := encodeValue(value)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&encoded); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeSpilled(data []byte) (interface{}, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var value interface{}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&value); err != nil {
+		return nil, err
+	}
+	return decodeValue(value)
+}
@@ -0,0 +1,98 @@
+package jumplist
+
+import (
+	"math/rand"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestTiered(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cold.seg")
+	tiered, err := NewTiered(path, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	model := map[float64]interface{}{}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 3000; i++ {
+		key := float64(r.Intn(500))
+		switch r.Intn(4) {
+		case 0:
+			_, present := model[key]
+			if tiered.Del(key) != present {
+				t.Fatalf("Del(%v) must report %v", key, present)
+			}
+			delete(model, key)
+		case 1:
+			if err := tiered.Set(key, nil); err != nil { //nil values are spilled too
+				t.Fatal(err)
+			}
+			model[key] = nil
+		default:
+			if err := tiered.Set(key, i); err != nil {
+				t.Fatal(err)
+			}
+			model[key] = i
+		}
+	}
+	if tiered.hot.Len() > 32 || tiered.cold == nil {
+		t.Fatal("the hot list holds", tiered.hot.Len(), "columns, the rest must be spilled")
+	}
+
+	check := func(tiered *Tiered) {
+		t.Helper()
+		if tiered.Len() != len(model) {
+			t.Fatalf("Len is %v, expected %v", tiered.Len(), len(model))
+		}
+		for key := -1.0; key <= 500; key++ {
+			value, ok, err := tiered.Get(key)
+			if expected, present := model[key]; err != nil || ok != present || value != expected {
+				t.Fatalf("Get(%v) is %v %v %v, expected %v %v", key, value, ok, err, expected, present)
+			}
+		}
+
+		keys := []float64{}
+		for key := range model {
+			if key >= 100 && key <= 300 {
+				keys = append(keys, key)
+			}
+		}
+		slices.Sort(keys)
+		got := []float64{}
+		err := tiered.Range(100, 300, func(key float64, value interface{}) bool {
+			if value != model[key] {
+				t.Fatalf("Range yields %v=%v, expected %v", key, value, model[key])
+			}
+			got = append(got, key)
+			return true
+		})
+		if err != nil || !slices.Equal(got, keys) {
+			t.Fatal("Range is", got, err, "expected", keys)
+		}
+	}
+	check(tiered)
+
+	visited := 0
+	tiered.Range(0, 500, func(float64, interface{}) bool {
+		visited++
+		return visited < 3
+	})
+	if visited != 3 {
+		t.Fatal("Range must stop when fn returns false, visited", visited)
+	}
+
+	if err := tiered.Close(); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := NewTiered(path, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	if reopened.hot.Len() != 0 {
+		t.Fatal("a reopened tiered list starts with every key on disk")
+	}
+	check(reopened)
+}
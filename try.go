@@ -0,0 +1,148 @@
+package jumplist
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// acquire calls try, which takes a lock if it is free, until it succeeds or ctx is done, and returns
+// ctx.Err() then. A free lock is taken even if ctx is already done. It backs off from a microsecond to
+// a millisecond between tries while the lock stays contended.
+func acquire(ctx context.Context, try func() bool) error {
+	if try() {
+		return nil
+	}
+
+	wait := time.Microsecond
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+		if try() {
+			return nil
+		}
+		if wait < time.Millisecond {
+			wait *= 2 //back off while the lock stays contended
+		}
+		timer.Reset(wait)
+	}
+}
+
+// lockWithin takes the write lock if it becomes available before timeout passes.
+func (list *SkipList) lockWithin(timeout time.Duration) bool {
+	if list.mutex.TryLock() {
+		return true
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return acquire(ctx, list.mutex.TryLock) == nil
+}
+
+// ErrBusy is returned by TrySet, TryGet and TryRemove when the lock they need is held.
+var ErrBusy = errors.New("jumplist: list is busy")
+
+// tryReadLock takes the lock Get needs if it is free and returns the function releasing it, nil if
+// it is not. A Locker without a TryRLock, unlike *sync.RWMutex, can only be tried for the write lock.
+func (list *SkipList) tryReadLock() (unlock func()) {
+	mutex := list.mutex
+	if h, ok := mutex.(*hookLocker); ok {
+		mutex = h.Locker //its read lock is the one underneath
+	}
+	r, ok := mutex.(interface{ TryRLock() bool })
+	if !ok || list.lru != nil && !list.lru.insertionOrder {
+		if list.mutex.TryLock() {
+			return list.mutex.Unlock
+		}
+		return nil
+	}
+	if r.TryRLock() {
+		return list.mutex.RUnlock
+	}
+	return nil
+}
+
+// readLockCtx takes the lock Get needs before ctx is done and returns the function releasing it.
+func (list *SkipList) readLockCtx(ctx context.Context) (unlock func(), err error) {
+	err = acquire(ctx, func() bool {
+		unlock = list.tryReadLock()
+		return unlock != nil
+	})
+	return unlock, err
+}
+
+// SetWithin works like Set but gives up when the write lock cannot be taken within timeout.
+// ok is false if it gave up, in which case the list is left untouched. With a zero timeout it tries
+// the lock once, like TrySet.
+func (list *SkipList) SetWithin(key float64, value interface{}, timeout time.Duration) (column *Column, ok bool) {
+	if !list.lockWithin(timeout) {
+		return nil, false
+	}
+	defer list.mutex.Unlock()
+
+	return list.set(key, value), true
+}
+
+// GetCtx is Get giving up with ctx.Err() if ctx is done before the lock is free, so a stalled writer
+// cannot hold up a request past its deadline. It tries the lock with backoff rather than queueing
+// for it, so a steady stream of writers can keep it waiting until ctx ends.
+func (list *SkipList) GetCtx(ctx context.Context, key float64) (*Column, error) {
+	unlock, err := list.readLockCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	return list.get(key), nil
+}
+
+// SetCtx is Set giving up with ctx.Err() if ctx is done before the write lock is free, leaving the
+// list untouched then. It waits like GetCtx.
+func (list *SkipList) SetCtx(ctx context.Context, key float64, value interface{}) (*Column, error) {
+	if err := acquire(ctx, list.mutex.TryLock); err != nil {
+		return nil, err
+	}
+	defer list.mutex.Unlock()
+
+	return list.set(key, value), nil
+}
+
+// TrySet is Set returning ErrBusy at once if the lock is held, leaving the list untouched then, so a
+// real-time loop can skip the update rather than block.
+func (list *SkipList) TrySet(key float64, value interface{}) (*Column, error) {
+	if !list.mutex.TryLock() {
+		return nil, ErrBusy
+	}
+	defer list.mutex.Unlock()
+
+	return list.set(key, value), nil
+}
+
+// TryGet is Get returning ErrBusy at once if the lock is held by a writer, so a real-time loop can
+// skip the read rather than block. An LRU list needs the write lock, so other readers make it busy too.
+func (list *SkipList) TryGet(key float64) (*Column, error) {
+	unlock := list.tryReadLock()
+	if unlock == nil {
+		return nil, ErrBusy
+	}
+	defer unlock()
+
+	return list.get(key), nil
+}
+
+// TryRemove is Del returning ErrBusy at once if the lock is held, leaving the list untouched then.
+func (list *SkipList) TryRemove(key float64) (*Column, error) {
+	if !list.mutex.TryLock() {
+		return nil, ErrBusy
+	}
+	defer list.mutex.Unlock()
+
+	column := list.del(list.snap(key))
+	list.trace("Del", key, column, column != nil)
+	return column, nil
+}
@@ -0,0 +1,131 @@
+package jumplist
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSetWithin(t *testing.T) {
+	list := New()
+
+	if c, ok := list.SetWithin(1, "a", time.Millisecond); !ok || c == nil || c.Value != "a" {
+		t.Fatal("uncontended SetWithin must succeed")
+	}
+
+	locked := make(chan struct{})
+	release := make(chan struct{})
+	go func() {
+		list.mutex.Lock()
+		close(locked)
+		<-release
+		list.mutex.Unlock()
+	}()
+	<-locked
+
+	start := time.Now()
+	if c, ok := list.SetWithin(2, "b", 20*time.Millisecond); ok || c != nil {
+		t.Fatal("SetWithin must time out while the lock is held")
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Fatalf("SetWithin gave up after %v, before its timeout", elapsed)
+	}
+	close(release)
+
+	if list.Get(2) != nil {
+		t.Fatal("timed out SetWithin must not modify the list")
+	}
+	if _, ok := list.SetWithin(2, "b", time.Second); !ok || list.Get(2) == nil {
+		t.Fatal("SetWithin must succeed once the lock is released")
+	}
+}
+
+func TestGetSetCtx(t *testing.T) {
+	list := New()
+	list.Set(1, "a")
+
+	list.mutex.RLock() //another reader does not hold up GetCtx
+	if c, err := list.GetCtx(context.Background(), 1); err != nil || c == nil || c.Value != "a" {
+		t.Fatal("GetCtx must share the lock with readers, got", c, err)
+	}
+	list.mutex.RUnlock()
+
+	list.mutex.Lock() //a stalled writer
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if c, err := list.GetCtx(ctx, 1); !errors.Is(err, context.DeadlineExceeded) || c != nil {
+		t.Fatal("GetCtx must give up at the deadline, got", c, err)
+	}
+	if c, err := list.SetCtx(ctx, 2, "b"); !errors.Is(err, context.DeadlineExceeded) || c != nil {
+		t.Fatal("SetCtx must give up at the deadline, got", c, err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond || elapsed > time.Second {
+		t.Fatal("gave up after", elapsed)
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	if _, err := list.SetCtx(canceled, 2, "b"); !errors.Is(err, context.Canceled) {
+		t.Fatal("SetCtx must give up when ctx is canceled, got", err)
+	}
+	list.mutex.Unlock()
+
+	if c, err := list.SetCtx(canceled, 2, "b"); err != nil || c.Value != "b" || list.Len() != 2 {
+		t.Fatal("a free lock is taken even with ctx done, got", c, err)
+	}
+
+	lru := NewLRU(18, 4) //Get reorders, so GetCtx takes the write lock
+	lru.Set(1, "a")
+	lru.mutex.RLock()
+	short, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	if _, err := lru.GetCtx(short, 1); err == nil {
+		t.Fatal("GetCtx on an LRU list must wait for the write lock")
+	}
+	lru.mutex.RUnlock()
+}
+
+func TestTrySetGetRemove(t *testing.T) {
+	list := New()
+	list.Set(1, "a")
+	list.Set(2, "b")
+
+	list.mutex.RLock()
+	if c, err := list.TryGet(1); err != nil || c.Value != "a" {
+		t.Fatal("TryGet must share the lock with readers, got", c, err)
+	}
+	if c, err := list.TryRemove(1); err != ErrBusy || c != nil {
+		t.Fatal("TryRemove must not wait for readers, got", c, err)
+	}
+	if c, err := list.TrySet(3, "c"); err != ErrBusy || c != nil {
+		t.Fatal("TrySet must not wait for readers, got", c, err)
+	}
+	list.mutex.RUnlock()
+
+	list.mutex.Lock()
+	if c, err := list.TryGet(1); err != ErrBusy || c != nil {
+		t.Fatal("TryGet must not wait for a writer, got", c, err)
+	}
+	if c, err := list.TrySet(3, "c"); err != ErrBusy || c != nil {
+		t.Fatal("TrySet must not wait for a writer, got", c, err)
+	}
+	if c, ok := list.SetWithin(3, "c", 0); ok || c != nil {
+		t.Fatal("SetWithin with no timeout must not wait for a writer")
+	}
+	list.mutex.Unlock()
+
+	if c, err := list.TryRemove(1); err != nil || c == nil || c.Value != "a" {
+		t.Fatal("TryRemove of a free list must remove the key, got", c, err)
+	}
+	if c, err := list.TryRemove(1); err != nil || c != nil {
+		t.Fatal("TryRemove of an absent key removes nothing, got", c, err)
+	}
+	if c, err := list.TryGet(1); err != nil || c != nil || list.Len() != 1 {
+		t.Fatal("the key must be gone, got", c, err)
+	}
+	if c, err := list.TrySet(3, "c"); err != nil || c == nil || list.Get(3) != c {
+		t.Fatal("TrySet of a free list must set the key, got", c, err)
+	}
+}
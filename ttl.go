@@ -0,0 +1,146 @@
+package jumplist
+
+import (
+	"container/heap"
+	"time"
+)
+
+type expiry struct {
+	column *Column
+	at     time.Time
+}
+
+// expiryHeap orders deadlines soonest first. Entries whose deadline changed since are skipped when popped.
+type expiryHeap []expiry
+
+func (h expiryHeap) Len() int            { return len(h) }
+func (h expiryHeap) Less(i, j int) bool  { return h[i].at.Before(h[j].at) }
+func (h expiryHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *expiryHeap) Push(x interface{}) { *h = append(*h, x.(expiry)) }
+func (h *expiryHeap) Pop() interface{} {
+	old := *h
+	e := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return e
+}
+
+type ttlState struct {
+	expires map[*Column]time.Time
+	queue   expiryHeap
+	now     func() time.Time
+}
+
+// SetWithTTL sets key like Set and makes it expire after ttl. Get treats an expired key as missing
+// right away, and it is removed by the next Sweep, which StartSweeper runs periodically.
+// Other reads see an expired column until it is swept. A later Set of the key without a TTL keeps it.
+func (list *SkipList) SetWithTTL(key float64, value interface{}, ttl time.Duration) *Column {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	if list.ttl == nil {
+		list.ttl = &ttlState{expires: map[*Column]time.Time{}, now: time.Now}
+	}
+	column := list.set(key, value)
+	list.setExpiry(column, list.ttl.now().Add(ttl))
+	return column
+}
+
+func (list *SkipList) setExpiry(column *Column, at time.Time) {
+	list.ttl.expires[column] = at
+	heap.Push(&list.ttl.queue, expiry{column, at})
+}
+
+// expired reports whether column outlived its TTL. It only reads, so it runs under the read lock.
+func (list *SkipList) expired(column *Column) bool {
+	if list.ttl == nil {
+		return false
+	}
+	at, ok := list.ttl.expires[column]
+	return ok && !list.ttl.now().Before(at)
+}
+
+// unexpired returns the first column from column on that holds key and has not outlived its TTL, so
+// Get and Contains skip an expired column before it is swept, in a multiset moving on to the next
+// column with the same key.
+func (list *SkipList) unexpired(column *Column, key float64) *Column {
+	for ; column != nil && column.key == key; column = column.next[0] {
+		if !list.expired(column) {
+			return column
+		}
+	}
+	return nil
+}
+
+// ttlOf returns the deadline of column, ok false if it has none.
+func (list *SkipList) ttlOf(column *Column) (at time.Time, ok bool) {
+	if list.ttl == nil {
+		return time.Time{}, false
+	}
+	at, ok = list.ttl.expires[column]
+	return at, ok
+}
+
+// dropExpiry forgets the TTL of column, once it was overwritten or removed.
+func (list *SkipList) dropExpiry(column *Column) {
+	if list.ttl != nil {
+		delete(list.ttl.expires, column)
+	}
+}
+
+// Sweep removes every expired column and returns how many there were.
+func (list *SkipList) Sweep() int {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	return list.sweep()
+}
+
+func (list *SkipList) sweep() int {
+	if list.ttl == nil {
+		return 0
+	}
+
+	now := list.ttl.now()
+	n := 0
+	for len(list.ttl.queue) > 0 && !now.Before(list.ttl.queue[0].at) {
+		e := heap.Pop(&list.ttl.queue).(expiry)
+		if at, ok := list.ttl.expires[e.column]; !ok || !at.Equal(e.at) {
+			continue //overwritten, removed or given a new TTL since
+		}
+		if !list.removeColumn(e.column) { //the exact column, which need not be first among equal keys
+			delete(list.ttl.expires, e.column) //no longer in the list, removing it drops the deadline
+			continue
+		}
+		if list.onExpire != nil { //only here, when Get merely hides the key, so StartSweeper bounds the delay
+			column := e.column
+			list.pending = append(list.pending, func() { list.onExpire(column) })
+		}
+		n++
+	}
+	return n
+}
+
+// StartSweeper runs Sweep every interval on a goroutine until the returned stop function is called.
+func (list *SkipList) StartSweeper(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				list.Sweep()
+			}
+		}
+	}()
+
+	stopped := false
+	return func() {
+		if !stopped {
+			stopped = true
+			close(done)
+		}
+	}
+}
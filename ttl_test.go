@@ -0,0 +1,121 @@
+package jumplist
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetWithTTL(t *testing.T) {
+	list := New()
+	now := time.Unix(1000, 0)
+	list.SetWithTTL(1, "short", time.Second) //sets up the TTL state, so its clock can be replaced
+	list.ttl.now = func() time.Time { return now }
+	list.SetWithTTL(1, "short", time.Second)
+	list.SetWithTTL(2, "long", time.Minute)
+	list.SetWithTTL(3, "overwritten", time.Second)
+	list.Set(3, "kept")
+	list.SetWithTTL(4, "deleted", time.Second)
+	list.Del(4)
+	list.Set(5, "plain")
+
+	if list.Get(1) == nil || list.Sweep() != 0 {
+		t.Fatal("nothing has expired yet")
+	}
+
+	now = now.Add(time.Second)
+	if list.Get(1) != nil {
+		t.Fatal("Get must hide an expired key before it is swept")
+	}
+	if n := list.Sweep(); n != 1 {
+		t.Fatalf("swept %v columns, expected 1", n)
+	}
+	checkSanity(list, t)
+	if keys := list.Keys(); len(keys) != 3 || keys[0] != 2 || keys[1] != 3 || keys[2] != 5 {
+		t.Fatal("only the expired key must be removed", keys)
+	}
+
+	list.SetWithTTL(2, "renewed", 2*time.Minute) //the old deadline is skipped
+	now = now.Add(time.Minute)
+	if list.Sweep() != 0 || list.Get(2) == nil {
+		t.Fatal("a renewed TTL must replace the old one")
+	}
+	now = now.Add(time.Minute)
+	if list.Sweep() != 1 || list.Len() != 2 {
+		t.Fatal("renewed key must expire at its new deadline", list.Len())
+	}
+}
+
+func TestStartSweeper(t *testing.T) {
+	list := New()
+	for i := 0; i < 100; i++ {
+		list.SetWithTTL(float64(i), i, time.Millisecond)
+	}
+	list.Set(1000, nil)
+
+	stop := list.StartSweeper(time.Millisecond)
+	defer stop()
+	deadline := time.Now().Add(5 * time.Second)
+	for list.Len() != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("sweeper did not remove the expired keys", list.Len())
+		}
+		time.Sleep(time.Millisecond)
+	}
+	stop()
+	stop() //stopping twice is harmless
+	checkSanity(list, t)
+}
+
+func TestOnExpire(t *testing.T) {
+	expired := []interface{}{}
+	var list *SkipList
+	list = New(OnExpire(func(column *Column) {
+		expired = append(expired, column.Value)
+		list.Set(column.Key()+100, "renewed") //the lock is released, so fn may write
+	}), WithPooling())
+	now := time.Unix(1000, 0)
+	list.SetWithTTL(1, "a", time.Second)
+	list.ttl.now = func() time.Time { return now }
+	list.SetWithTTL(1, "a", time.Second)
+	list.SetWithTTL(2, "b", 2*time.Second)
+	list.SetWithTTL(3, "c", time.Minute)
+	list.SetWithTTL(4, "deleted", time.Second)
+	list.Del(4)
+
+	now = now.Add(2 * time.Second)
+	if n := list.Sweep(); n != 2 || len(expired) != 2 || expired[0] != "a" || expired[1] != "b" {
+		t.Fatal("OnExpire must be called for each swept column in deadline order, got", expired, n)
+	}
+	if list.Get(101) == nil || list.Get(102) == nil || list.Len() != 3 {
+		t.Fatal("OnExpire must be able to write to the list")
+	}
+}
+
+func TestSetWithTTLMultiset(t *testing.T) {
+	list := New(AllowDuplicates())
+	now := time.Unix(1000, 0)
+	list.SetWithTTL(0, "setup", time.Second)
+	list.ttl.now = func() time.Time { return now }
+	list.Del(0)
+	list.SetWithTTL(1, "first", 2*time.Second)
+	list.SetWithTTL(1, "second", time.Second) //expires first, behind a duplicate that does not
+	list.SetWithTTL(2, "early", time.Second)
+	list.SetWithTTL(2, "late", 2*time.Second)
+
+	now = now.Add(time.Second)
+	if value, _ := list.GetValue(2); value != "late" || !list.Contains(2) {
+		t.Fatal("Get must skip an expired first duplicate, got", value)
+	}
+	if n := list.Sweep(); n != 2 || list.Len() != 2 {
+		t.Fatal("Sweep must remove the expired duplicates wherever they are in their run, swept", n, "left", list.Len())
+	}
+	checkSanity(list, t)
+	if value, _ := list.GetValue(1); value != "first" {
+		t.Fatal("the unexpired duplicate must stay, got", value)
+	}
+
+	now = now.Add(time.Second)
+	if n := list.Sweep(); n != 2 || list.Len() != 0 {
+		t.Fatal("the remaining duplicates keep their deadlines, swept", n, "left", list.Len())
+	}
+}
@@ -0,0 +1,70 @@
+package jumplist
+
+// Txn collects the changes of an Update. It must not be used after fn returns.
+type Txn struct {
+	list    *SkipList
+	ops     []txnOp
+	pending map[float64]int //index in ops of the last change to each key
+}
+
+type txnOp struct {
+	key     float64
+	value   interface{}
+	removed bool
+}
+
+// Update runs fn holding the write lock and applies the changes it made through tx only once it
+// returns nil, so readers see all of them or none. If fn returns an error or panics nothing is applied.
+// Writing to the list directly from fn deadlocks.
+func (list *SkipList) Update(fn func(tx *Txn) error) error {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	tx := &Txn{list: list, pending: map[float64]int{}}
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	for _, op := range tx.ops {
+		if !op.removed {
+			list.set(op.key, op.value)
+			continue
+		}
+		column := list.del(op.key)
+		list.trace("Del", op.key, column, column != nil)
+	}
+	return nil
+}
+
+// Get returns the value of key as the transaction sees it, its own changes included.
+func (tx *Txn) Get(key float64) (interface{}, bool) {
+	key = tx.list.snap(key)
+	if i, ok := tx.pending[key]; ok {
+		op := tx.ops[i]
+		return op.value, !op.removed
+	}
+	if next := tx.list.unexpired(tx.list.seek(key), key); next != nil {
+		tx.list.touch(next)
+		return next.Value, true
+	}
+	return nil, false
+}
+
+// Set records setting key to value. In a multiset every Set adds a column.
+func (tx *Txn) Set(key float64, value interface{}) {
+	checkKey(key) //panic now rather than halfway through applying
+	tx.record(txnOp{key: tx.list.snap(key), value: value})
+}
+
+// Remove records removing key and reports whether the transaction saw it present.
+func (tx *Txn) Remove(key float64) bool {
+	key = tx.list.snap(key)
+	_, present := tx.Get(key)
+	tx.record(txnOp{key: key, removed: true})
+	return present
+}
+
+func (tx *Txn) record(op txnOp) {
+	tx.pending[op.key] = len(tx.ops)
+	tx.ops = append(tx.ops, op)
+}
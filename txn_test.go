@@ -0,0 +1,82 @@
+package jumplist
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestUpdate(t *testing.T) {
+	list := New()
+	list.Set(1, "a")
+	list.Set(2, "b")
+
+	err := list.Update(func(tx *Txn) error {
+		v, ok := tx.Get(1)
+		if !ok || !tx.Remove(1) {
+			t.Fatal("the transaction must see the list")
+		}
+		tx.Set(10, v)
+		if _, ok := tx.Get(1); ok {
+			t.Fatal("the transaction must see its own removal")
+		}
+		if v, _ := tx.Get(10); v != "a" {
+			t.Fatal("the transaction must see its own set", v)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSanity(list, t)
+	if list.Len() != 2 || list.Get(1) != nil || list.Get(10).Value != "a" {
+		t.Fatal("the changes must be applied", list.Keys())
+	}
+
+	failed := errors.New("failed")
+	err = list.Update(func(tx *Txn) error {
+		tx.Set(3, nil)
+		tx.Remove(2)
+		return failed
+	})
+	if err != failed || list.Len() != 2 || list.Get(2) == nil || list.Get(3) != nil {
+		t.Fatal("a failed transaction must change nothing", err, list.Keys())
+	}
+}
+
+func TestUpdateIsAtomic(t *testing.T) {
+	list := New()
+	list.Set(0, "token")
+
+	stop := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			if n := list.Len(); n != 1 {
+				t.Errorf("a reader saw %v columns", n)
+				return
+			}
+		}
+	}()
+
+	for i := 1; i < 1000; i++ {
+		list.Update(func(tx *Txn) error {
+			v, _ := tx.Get(float64(i - 1))
+			tx.Remove(float64(i - 1))
+			tx.Set(float64(i), v)
+			return nil
+		})
+	}
+	close(stop)
+	wg.Wait()
+	if list.Get(999) == nil || list.Len() != 1 {
+		t.Fatal("the token must have moved to the last key")
+	}
+}
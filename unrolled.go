@@ -0,0 +1,210 @@
+package jumplist
+
+import (
+	"iter"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// unrolledNode holds up to entriesPerNode pairs in key order. Its first key orders it among the nodes.
+type unrolledNode struct {
+	next  []*unrolledNode
+	items []KV
+}
+
+// Unrolled is a skip list whose nodes each pack a small sorted array of pairs. Scans walk
+// contiguous memory and there are far fewer pointers per pair, at the cost of shifting pairs
+// inside a node on insert and delete. A full node splits in half, an empty one is unlinked.
+type Unrolled struct {
+	mutex          sync.RWMutex
+	head           []*unrolledNode
+	predecessors   []*unrolledNode //scratch for writers, nil stands for the head
+	entriesPerNode int
+	randomSeed     rand.Source
+	levels         levelDist
+	length         int
+}
+
+// NewUnrolled returns an empty unrolled skip list with up to entriesPerNode pairs per node.
+func NewUnrolled(entriesPerNode int) *Unrolled {
+	if entriesPerNode < 2 {
+		panic("entriesPerNode must be at least 2")
+	}
+	const maxLevel = 18
+	return &Unrolled{
+		head:           make([]*unrolledNode, maxLevel),
+		predecessors:   make([]*unrolledNode, maxLevel),
+		entriesPerNode: entriesPerNode,
+		randomSeed:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		levels:         newLevelDist(maxLevel, 1/math.E),
+	}
+}
+
+func (list *Unrolled) nextOf(node *unrolledNode, level int) *unrolledNode {
+	if node == nil {
+		return list.head[level]
+	}
+	return node.next[level]
+}
+
+// find returns the last node whose first key is not greater than key, nil if key is below every node.
+// Writers pass the predecessors to fill on each level.
+func (list *Unrolled) find(key float64, predecessors []*unrolledNode) *unrolledNode {
+	var node *unrolledNode
+	for i := len(list.head) - 1; i >= 0; i-- {
+		for next := list.nextOf(node, i); next != nil && next.items[0].Key <= key; next = next.next[i] {
+			node = next
+		}
+		if predecessors != nil {
+			predecessors[i] = node
+		}
+	}
+	return node
+}
+
+// search returns the position of the first pair in node with a key not less than key.
+func (node *unrolledNode) search(key float64) int {
+	return sort.Search(len(node.items), func(i int) bool { return node.items[i].Key >= key })
+}
+
+// Set inserts or overwrites key.
+func (list *Unrolled) Set(key float64, value interface{}) {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	node := list.find(key, list.predecessors)
+	if node == nil {
+		node = list.head[0] //below every node, it becomes the first key of the first one
+	}
+	if node == nil {
+		list.link(&unrolledNode{items: append(make([]KV, 0, list.entriesPerNode), KV{key, value})})
+		list.length++
+		return
+	}
+
+	i := node.search(key)
+	if i < len(node.items) && node.items[i].Key == key {
+		node.items[i].Value = value
+		return
+	}
+	node.items = append(node.items, KV{})
+	copy(node.items[i+1:], node.items[i:])
+	node.items[i] = KV{key, value}
+	list.length++
+
+	if len(node.items) > list.entriesPerNode {
+		//the upper half moves to a new node linked right after this one
+		half := len(node.items) / 2
+		moved := node.items[half:]
+		split := &unrolledNode{items: append(make([]KV, 0, list.entriesPerNode), moved...)}
+		clear(moved) //let the moved values be collected
+		node.items = node.items[:half]
+		list.find(split.items[0].Key, list.predecessors) //key may have been below every node
+		list.link(split)
+	}
+}
+
+// link inserts node after the predecessors left by find for its first key.
+func (list *Unrolled) link(node *unrolledNode) {
+	node.next = make([]*unrolledNode, randLevel(list.randomSeed, list.levels))
+	for i := range node.next {
+		if prev := list.predecessors[i]; prev == nil {
+			node.next[i], list.head[i] = list.head[i], node
+		} else {
+			node.next[i], prev.next[i] = prev.next[i], node
+		}
+	}
+}
+
+// Get returns the value of key and whether it is present.
+func (list *Unrolled) Get(key float64) (interface{}, bool) {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	if node := list.find(key, nil); node != nil {
+		if i := node.search(key); i < len(node.items) && node.items[i].Key == key {
+			return node.items[i].Value, true
+		}
+	}
+	return nil, false
+}
+
+// Del removes key and returns its value and whether it was present.
+func (list *Unrolled) Del(key float64) (interface{}, bool) {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	node := list.find(key, nil)
+	if node == nil {
+		return nil, false
+	}
+	i := node.search(key)
+	if i == len(node.items) || node.items[i].Key != key {
+		return nil, false
+	}
+
+	value := node.items[i].Value
+	copy(node.items[i:], node.items[i+1:])
+	node.items[len(node.items)-1] = KV{}
+	node.items = node.items[:len(node.items)-1]
+	list.length--
+
+	if len(node.items) == 0 {
+		//find the predecessors of the node itself, every other node has another first key
+		var prev *unrolledNode
+		for i := len(list.head) - 1; i >= 0; i-- {
+			next := list.nextOf(prev, i)
+			for next != nil && next != node && next.items[0].Key < key {
+				prev = next
+				next = next.next[i]
+			}
+			if next == node {
+				if prev == nil {
+					list.head[i] = node.next[i]
+				} else {
+					prev.next[i] = node.next[i]
+				}
+			}
+		}
+	}
+	return value, true
+}
+
+// Len returns the number of pairs.
+func (list *Unrolled) Len() int {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.length
+}
+
+// All iterates over every pair in key order. The read lock is held until the loop ends.
+func (list *Unrolled) All() iter.Seq2[float64, interface{}] {
+	return list.From(math.Inf(-1))
+}
+
+// From iterates in key order starting at the first key not less than key.
+func (list *Unrolled) From(key float64) iter.Seq2[float64, interface{}] {
+	return func(yield func(float64, interface{}) bool) {
+		list.mutex.RLock()
+		defer list.mutex.RUnlock()
+
+		node := list.find(key, nil)
+		i := 0
+		if node == nil {
+			node = list.head[0]
+		} else {
+			i = node.search(key)
+		}
+		for ; node != nil; node, i = node.next[0], 0 {
+			for _, item := range node.items[i:] {
+				if !yield(item.Key, item.Value) {
+					return
+				}
+			}
+		}
+	}
+}
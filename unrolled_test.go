@@ -0,0 +1,90 @@
+package jumplist
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestUnrolled(t *testing.T) {
+	for _, size := range []int{2, 3, 16} {
+		list := NewUnrolled(size)
+		reference := map[float64]int{}
+		r := rand.New(rand.NewSource(int64(size)))
+
+		for i := 0; i < 20000; i++ {
+			key := float64(r.Intn(2000))
+			if r.Intn(3) == 0 {
+				_, ok := list.Del(key)
+				if _, expected := reference[key]; ok != expected {
+					t.Fatalf("size %v: Del(%v) reported %v", size, key, ok)
+				}
+				delete(reference, key)
+			} else {
+				list.Set(key, i)
+				reference[key] = i
+			}
+		}
+
+		if list.Len() != len(reference) {
+			t.Fatalf("size %v: length %v, expected %v", size, list.Len(), len(reference))
+		}
+		for k, v := range reference {
+			if got, ok := list.Get(k); !ok || got.(int) != v {
+				t.Fatalf("size %v: key %v holds %v, expected %v", size, k, got, v)
+			}
+		}
+
+		//nodes must be ordered, within and across levels, and never over capacity
+		for i, first := range list.head {
+			last := -1.0
+			for node := first; node != nil; node = node.next[i] {
+				if len(node.items) == 0 || len(node.items) > size {
+					t.Fatalf("size %v: node holds %v pairs", size, len(node.items))
+				}
+				if node.items[0].Key <= last {
+					t.Fatalf("size %v: level %v is out of order at %v", size, i, node.items[0].Key)
+				}
+				last = node.items[0].Key
+			}
+		}
+
+		n, prev := 0, -1.0
+		for k := range list.All() {
+			if k <= prev {
+				t.Fatalf("size %v: All yielded %v after %v", size, k, prev)
+			}
+			prev = k
+			n++
+		}
+		if n != len(reference) {
+			t.Fatalf("size %v: All yielded %v pairs, expected %v", size, n, len(reference))
+		}
+		for k := range list.From(1000.5) {
+			if k < 1000.5 {
+				t.Fatalf("size %v: From yielded %v", size, k)
+			}
+			break
+		}
+	}
+}
+
+func BenchmarkUnrolledScan(b *testing.B) {
+	list, unrolled := New(), NewUnrolled(32)
+	for i := 0; i < 100000; i++ {
+		list.Set(float64(i), i)
+		unrolled.Set(float64(i), i)
+	}
+
+	b.Run("SkipList", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for range list.All() {
+			}
+		}
+	})
+	b.Run("Unrolled", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for range unrolled.All() {
+			}
+		}
+	})
+}
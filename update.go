@@ -0,0 +1,233 @@
+package jumplist
+
+import "errors"
+
+var (
+	// ErrKeyNotFound is returned by Move when the key to move is absent.
+	ErrKeyNotFound = errors.New("jumplist: key not found")
+	// ErrKeyExists is returned by Move when the target key is taken and overwriting was not asked for.
+	ErrKeyExists = errors.New("jumplist: key already exists")
+)
+
+// ReplaceOrInsert sets key to value. If the key was already present, the existing column is swapped
+// out for a new one and returned detached from the list with its previous value, otherwise nil.
+func (list *SkipList) ReplaceOrInsert(key float64, value interface{}) *Column {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	key = list.snap(key)
+	list.moveCursors(key)
+	old := list.levelCursors[0].next[0]
+	if old == nil || old.key != key {
+		list.insertAtCursors(key, value)
+		return nil
+	}
+
+	//same height and same successors, only the predecessors need to point at the replacement
+	column := list.newColumn(len(old.next), key, value)
+	copy(column.next, old.next)
+	copy(column.span, old.span)
+	copy(column.sum, old.sum)
+	for i := range column.next {
+		list.levelCursors[i].next[i] = column
+	}
+	column.prev = old.prev
+	if column.next[0] != nil {
+		column.next[0].prev = column
+	}
+
+	list.tail = nil //old may be one of the tails
+	list.version++
+	list.replaced(old, column)
+	return old
+}
+
+// Accumulate stores combine(current, delta) at key under one write lock, or delta itself if key is absent.
+func (list *SkipList) Accumulate(key float64, delta interface{}, combine func(current, delta interface{}) interface{}) {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	list.accumulate(list.snap(key), delta, combine)
+}
+
+func (list *SkipList) accumulate(key float64, delta interface{}, combine func(current, delta interface{}) interface{}) *Column {
+	list.moveCursors(key)
+	column := list.levelCursors[0].next[0]
+	if column != nil && column.key == key {
+		old := column.Value
+		column.Value = combine(old, delta)
+		list.touch(column)
+		list.notify(EventUpdate, column, old)
+		return column
+	}
+
+	return list.insertAtCursors(key, delta)
+}
+
+// Upsert stores fn(old, exists) at key under one write lock, for read-modify-write such as bumping a
+// counter. When key is absent fn gets nil and false and its result is inserted. In multisets the first
+// column with key is updated. fn runs under the lock, so it must not call back into the list.
+func (list *SkipList) Upsert(key float64, fn func(old interface{}, exists bool) interface{}) *Column {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	key = list.snap(key)
+	list.moveCursors(key)
+	column := list.levelCursors[0].next[0]
+	if column != nil && column.key == key {
+		old := column.Value
+		column.Value = fn(old, true)
+		list.touch(column)
+		list.notify(EventUpdate, column, old)
+		list.trace("Set", key, column, true)
+		return column
+	}
+
+	column = list.insertAtCursors(key, fn(nil, false))
+	list.trace("Set", key, column, false)
+	return column
+}
+
+// Clamp re-keys every column below min to min and above max to max in one locked pass.
+// When a moved value lands on a key that is already taken, resolve(existing, moved) decides the
+// value to keep; moved columns are merged in ascending key order and a nil resolve keeps the moved value.
+func (list *SkipList) Clamp(min, max float64, resolve func(existing, moved interface{}) interface{}) {
+	if list.keyLess(max, min) {
+		panic("min must not be greater than max")
+	}
+	if resolve == nil {
+		resolve = func(existing, moved interface{}) interface{} { return moved }
+	}
+
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	below, above := []*Column{}, []*Column{}
+	for column := list.startPointers.next[0]; column != nil; column = column.next[0] {
+		if list.keyLess(column.key, min) {
+			below = append(below, column)
+		} else if list.keyLess(max, column.key) {
+			above = append(above, column)
+		}
+	}
+
+	for _, column := range below {
+		list.del(column.key)
+		list.accumulate(min, column.Value, resolve)
+	}
+	for _, column := range above {
+		list.del(column.key)
+		list.accumulate(max, column.Value, resolve)
+	}
+}
+
+// GetOrSet returns the column at key if there is one, otherwise it inserts value there.
+// inserted tells which happened, both under one lock so concurrent callers cannot both insert.
+func (list *SkipList) GetOrSet(key float64, value interface{}) (column *Column, inserted bool) {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	key = list.snap(key)
+	list.moveCursors(key)
+	column = list.levelCursors[0].next[0]
+	if column != nil && column.key == key {
+		list.touch(column)
+		return column, false
+	}
+
+	return list.insertAtCursors(key, value), true
+}
+
+// CompareAndSwap replaces the value at key with new if it currently equals old and reports
+// whether it did. Values are compared with ==, which panics for uncomparable types as sync.Map does.
+// An expired key not swept yet is absent, as for Get.
+func (list *SkipList) CompareAndSwap(key float64, old, new interface{}) bool {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	key = list.snap(key)
+	column := list.seek(key)
+	if column == nil || column.key != key || list.expired(column) || column.Value != old {
+		return false
+	}
+	column.Value = new
+	list.touch(column)
+	list.notify(EventUpdate, column, old)
+	return true
+}
+
+// LoadValue reads the value of column under the read lock. Reading column.Value directly races with
+// a concurrent Set of the same key, so a column kept from an earlier Set or Get is read through this.
+func (list *SkipList) LoadValue(column *Column) interface{} {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return column.Value
+}
+
+// StoreValue replaces the value of column under the write lock and reports whether column is still in
+// the list, leaving a removed one unchanged. Unlike Set it updates this very column among equal keys
+// of a multiset, and keeps its deadline if it has one.
+func (list *SkipList) StoreValue(column *Column, value interface{}) bool {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	c := list.seek(column.key)
+	for c != nil && c != column && c.key == column.key {
+		c = c.next[0]
+	}
+	if c != column {
+		return false
+	}
+
+	old := column.Value
+	column.Value = value
+	list.touch(column)
+	list.notify(EventUpdate, column, old)
+	return true
+}
+
+// UpdateKey moves the value at oldKey to newKey under one write lock and returns the column now holding it,
+// or nil if oldKey is absent. A value already at newKey is overwritten, like Set.
+func (list *SkipList) UpdateKey(oldKey, newKey float64) *Column {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	checkKey(newKey) //before the old column is removed
+	oldKey, newKey = list.snap(oldKey), list.snap(newKey)
+	if oldKey == newKey {
+		if column := list.seek(oldKey); column != nil && column.key == oldKey {
+			return column
+		}
+		return nil
+	}
+	old := list.del(oldKey)
+	if old == nil {
+		return nil
+	}
+	return list.set(newKey, old.Value)
+}
+
+// Move relocates the value at oldKey to newKey under one write lock and returns the column now holding it.
+// It fails with ErrKeyNotFound if oldKey is absent and with ErrKeyExists if newKey is present, unless
+// overwrite is set, in which case it does what UpdateKey does. Moving a key onto itself is a no-op.
+func (list *SkipList) Move(oldKey, newKey float64, overwrite bool) (*Column, error) {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	checkKey(newKey)
+	oldKey, newKey = list.snap(oldKey), list.snap(newKey)
+	column := list.seek(oldKey)
+	if column == nil || column.key != oldKey {
+		return nil, ErrKeyNotFound
+	}
+	if oldKey == newKey {
+		return column, nil
+	}
+	if target := list.seek(newKey); !overwrite && target != nil && target.key == newKey {
+		return nil, ErrKeyExists
+	}
+
+	old := list.del(oldKey)
+	return list.set(newKey, old.Value), nil
+}
@@ -0,0 +1,284 @@
+package jumplist
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestReplaceOrInsert(t *testing.T) {
+	list := New()
+	list.Set(10, "a")
+	list.Set(30, "c")
+
+	if old := list.ReplaceOrInsert(20, "b"); old != nil {
+		t.Fatal("fresh insert must return nil, got", old)
+	}
+	if v := list.Get(20); v == nil || v.Value != "b" {
+		t.Fatal(`wrong "20" value (expected "b")`, v)
+	}
+
+	old := list.ReplaceOrInsert(20, "B")
+	if old == nil || old.key != 20 || old.Value != "b" {
+		t.Fatal(`replacement must return the old "20" column with value "b"`, old)
+	}
+	if v := list.Get(20); v == nil || v == old || v.Value != "B" {
+		t.Fatal(`wrong "20" value (expected "B")`, v)
+	}
+	checkSanity(list, t)
+
+	cnt := 0
+	for c := list.startPointers.next[0]; c != nil; c = c.next[0] {
+		cnt++
+	}
+	if cnt != 3 {
+		t.Fatalf("expected 3 columns after replacement, got %v", cnt)
+	}
+}
+
+func TestAccumulate(t *testing.T) {
+	list := New()
+	sum := func(current, delta interface{}) interface{} { return current.(int) + delta.(int) }
+
+	for i := 0; i < 100; i++ {
+		list.Accumulate(float64(i%4), i, sum)
+	}
+
+	expected := map[float64]int{}
+	for i := 0; i < 100; i++ {
+		expected[float64(i%4)] += i
+	}
+	for k, total := range expected {
+		if v := list.Get(k); v == nil || v.Value.(int) != total {
+			t.Fatalf("wrong running total for %v (expected %v)", k, total)
+		}
+	}
+	checkSanity(list, t)
+}
+
+func TestUpsert(t *testing.T) {
+	list := New()
+	increment := func(old interface{}, exists bool) interface{} {
+		if !exists {
+			return 1
+		}
+		return old.(int) + 1
+	}
+
+	wg := &sync.WaitGroup{}
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			for i := 0; i < 1000; i++ {
+				list.Upsert(float64(i%10), increment)
+			}
+			wg.Done()
+		}()
+	}
+	wg.Wait()
+	checkSanity(list, t)
+
+	if list.Len() != 10 {
+		t.Fatalf("wrong length %v (expected 10)", list.Len())
+	}
+	for c := list.Front(); c != nil; c = c.Next() {
+		if c.Value.(int) != 800 {
+			t.Fatalf("counter %v is %v, expected 800", c.Key(), c.Value)
+		}
+	}
+}
+
+func TestClamp(t *testing.T) {
+	list := New()
+	for _, k := range []float64{-20, -10, 0, 5, 10, 20, 30} {
+		list.Set(k, []float64{k})
+	}
+	merge := func(existing, moved interface{}) interface{} {
+		return append(existing.([]float64), moved.([]float64)...)
+	}
+
+	list.Clamp(0, 15, merge)
+	checkSanity(list, t)
+
+	expected := map[float64][]float64{
+		0:  {0, -20, -10}, //collided with the existing min key
+		5:  {5},
+		10: {10},
+		15: {20, 30}, //max was free, the first moved value lands there
+	}
+	keys, values := list.ExportColumns()
+	if len(keys) != len(expected) {
+		t.Fatalf("got keys %v after clamping", keys)
+	}
+	for i, k := range keys {
+		merged := values[i].([]float64)
+		if len(merged) != len(expected[k]) {
+			t.Fatalf("key %v holds %v, expected %v", k, merged, expected[k])
+		}
+		for j := range merged {
+			if merged[j] != expected[k][j] {
+				t.Fatalf("key %v holds %v, expected %v", k, merged, expected[k])
+			}
+		}
+	}
+
+	list.Clamp(100, 200, nil)
+	if keys, values := list.ExportColumns(); len(keys) != 1 || keys[0] != 100 || values[0].([]float64)[0] != 20 {
+		t.Fatal("without a resolver the last moved value must win", keys, values)
+	}
+}
+
+func TestGetOrSet(t *testing.T) {
+	list := New()
+
+	c, inserted := list.GetOrSet(1, "a")
+	if !inserted || c == nil || c.Value != "a" {
+		t.Fatal("absent key must be inserted")
+	}
+	c, inserted = list.GetOrSet(1, "b")
+	if inserted || c == nil || c.Value != "a" {
+		t.Fatal("present key must be returned untouched")
+	}
+
+	wins := make(chan bool, 8)
+	for w := 0; w < 8; w++ {
+		go func(w int) {
+			_, inserted := list.GetOrSet(2, w)
+			wins <- inserted
+		}(w)
+	}
+	won := 0
+	for w := 0; w < 8; w++ {
+		if <-wins {
+			won++
+		}
+	}
+	if won != 1 || list.Len() != 2 {
+		t.Fatalf("%v concurrent callers inserted the same key, expected exactly 1", won)
+	}
+}
+
+func TestCompareAndSwap(t *testing.T) {
+	list := New()
+	list.Set(1, "a")
+
+	if list.CompareAndSwap(2, nil, "x") || list.Get(2) != nil {
+		t.Fatal("swapping an absent key must fail")
+	}
+	if list.CompareAndSwap(1, "b", "c") || list.Get(1).Value != "a" {
+		t.Fatal("swapping with a stale old value must fail")
+	}
+	if !list.CompareAndSwap(1, "a", "c") || list.Get(1).Value != "c" {
+		t.Fatal("swapping with the current value must succeed")
+	}
+
+	//optimistic increments from several goroutines must not lose updates
+	list.Set(3, 0)
+	load := func() int { //Value of a returned column is not synchronized
+		list.mutex.RLock()
+		defer list.mutex.RUnlock()
+		return list.seek(3).Value.(int)
+	}
+	done := make(chan struct{})
+	for w := 0; w < 4; w++ {
+		go func() {
+			for i := 0; i < 1000; i++ {
+				for {
+					current := load()
+					if list.CompareAndSwap(3, current, current+1) {
+						break
+					}
+				}
+			}
+			done <- struct{}{}
+		}()
+	}
+	for w := 0; w < 4; w++ {
+		<-done
+	}
+	if v := load(); v != 4000 {
+		t.Fatalf("counter reached %v, expected 4000", v)
+	}
+}
+
+func TestUpdateKey(t *testing.T) {
+	list := New()
+	for i := 0; i < 10; i++ {
+		list.Set(float64(i), i)
+	}
+
+	if c := list.UpdateKey(3, 30); c == nil || c.Key() != 30 || c.Value.(int) != 3 {
+		t.Fatal("value must move to the new key", c)
+	}
+	if list.Get(3) != nil || list.Len() != 10 {
+		t.Fatal("old key must be gone", list.Len())
+	}
+	if c := list.UpdateKey(4, 5); c == nil || c.Value.(int) != 4 || list.Len() != 9 {
+		t.Fatal("moving onto a taken key must overwrite it", c, list.Len())
+	}
+	if c := list.UpdateKey(6, 6); c == nil || c.Value.(int) != 6 {
+		t.Fatal("moving a key onto itself must keep it", c)
+	}
+	if list.UpdateKey(100, 200) != nil || list.Get(200) != nil {
+		t.Fatal("missing key must not be moved")
+	}
+	checkSanity(list, t)
+}
+
+func TestMove(t *testing.T) {
+	list := New()
+	for i := 0; i < 10; i++ {
+		list.Set(float64(i), i)
+	}
+
+	if c, err := list.Move(3, 30, false); err != nil || c.Key() != 30 || c.Value.(int) != 3 || list.Get(3) != nil {
+		t.Fatal("value must move to the new key", c, err)
+	}
+	if c, err := list.Move(4, 5, false); c != nil || !errors.Is(err, ErrKeyExists) || list.Get(4).Value.(int) != 4 || list.Get(5).Value.(int) != 5 {
+		t.Fatal("moving onto a taken key must fail and change nothing", c, err)
+	}
+	if c, err := list.Move(4, 5, true); err != nil || c.Value.(int) != 4 || list.Len() != 9 {
+		t.Fatal("moving with overwrite must replace the taken key", c, err, list.Len())
+	}
+	if c, err := list.Move(100, 200, true); c != nil || !errors.Is(err, ErrKeyNotFound) || list.Get(200) != nil {
+		t.Fatal("missing key must not be moved", c, err)
+	}
+	if c, err := list.Move(6, 6, false); err != nil || c.Value.(int) != 6 {
+		t.Fatal("moving a key onto itself must keep it", c, err)
+	}
+	checkSanity(list, t)
+}
+
+func TestLoadStoreValue(t *testing.T) {
+	list := New()
+	column := list.Set(1, 0)
+
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < 1000; i++ {
+			list.Set(1, i) //races with column.Value, not with LoadValue
+		}
+		done <- true
+	}()
+	for i := 0; i < 1000; i++ {
+		if v, ok := list.LoadValue(column).(int); !ok || v < 0 || v >= 1000 {
+			t.Fatal("read a value nobody stored", v)
+		}
+	}
+	<-done
+
+	if !list.StoreValue(column, "stored") || list.Get(1).Value != "stored" {
+		t.Fatal("StoreValue must replace the value in the list")
+	}
+	list.Del(1)
+	if list.StoreValue(column, "gone") || column.Value != "stored" {
+		t.Fatal("a removed column must be left alone")
+	}
+
+	multi := New(AllowDuplicates())
+	multi.Set(2, "a")
+	second := multi.Set(2, "b")
+	if !multi.StoreValue(second, "B") || multi.GetAll(2)[0].Value != "a" || multi.GetAll(2)[1].Value != "B" {
+		t.Fatal("StoreValue must change the column passed, not the oldest with its key")
+	}
+}
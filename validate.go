@@ -0,0 +1,99 @@
+package jumplist
+
+import (
+	"fmt"
+	"math"
+)
+
+// Validate checks the structural invariants in one O(n) walk: keys ascend on level 0 (strictly
+// unless duplicates are allowed, equal ones in the order of the tie breaker if any), every column is
+// between 1 and maxLevel tall, each higher level links exactly the columns tall enough to take part
+// in it in level 0 order, and the back links, spans, weight sums and length agree. It returns the first violation found, nil for a sound list.
+func (list *SkipList) Validate() error {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	if len(list.startPointers.next) != list.maxLevel || len(list.startPointers.span) != list.maxLevel {
+		return fmt.Errorf("jumplist: start pointers have %d levels, maxLevel is %d", len(list.startPointers.next), list.maxLevel)
+	}
+
+	if list.weigher != nil && len(list.startPointers.sum) != list.maxLevel {
+		return fmt.Errorf("jumplist: start pointers have %d weight sums, maxLevel is %d", len(list.startPointers.sum), list.maxLevel)
+	}
+
+	last := make([]*pointerColumn, list.maxLevel) //last column seen on each level
+	lastRank := make([]int, list.maxLevel)
+	weighed := make([]float64, list.maxLevel) //weight since the last column on each level
+	scale := make([]float64, list.maxLevel)   //and its absolute value, which bounds the rounding
+	for i := range last {
+		last[i] = &list.startPointers
+	}
+
+	var prev *Column
+	rank := 0
+	for column := list.startPointers.next[0]; column != nil; column = column.next[0] {
+		rank++
+		if rank > list.length {
+			return fmt.Errorf("jumplist: level 0 holds more columns than the length %d, or a cycle", list.length)
+		}
+		if level := len(column.next); level < 1 || level > list.maxLevel || len(column.span) != level {
+			return fmt.Errorf("jumplist: column %v at rank %d is %d levels tall, maxLevel is %d", column.key, rank, level, list.maxLevel)
+		}
+		if column.prev != prev {
+			return fmt.Errorf("jumplist: column %v at rank %d does not link back to the previous column", column.key, rank)
+		}
+		if prev != nil && (list.keyLess(column.key, prev.key) || column.key == prev.key && !list.duplicates || column.key != column.key) {
+			return fmt.Errorf("jumplist: column %v at rank %d is out of order after %v", column.key, rank, prev.key)
+		}
+		if prev != nil && column.key == prev.key && list.tieLess != nil && list.tieLess(column.Value, prev.Value) {
+			return fmt.Errorf("jumplist: column %v at rank %d is out of tie order", column.key, rank)
+		}
+		if list.weigher != nil {
+			if len(column.sum) != len(column.next) {
+				return fmt.Errorf("jumplist: column %v at rank %d has %d weight sums for %d levels", column.key, rank, len(column.sum), len(column.next))
+			}
+			w := list.weigher(column.Value)
+			for i := range weighed {
+				weighed[i] += w
+				scale[i] += math.Abs(w)
+			}
+		}
+
+		for i := range column.next {
+			if last[i].next[i] != column {
+				return fmt.Errorf("jumplist: level %d skips column %v at rank %d", i, column.key, rank)
+			}
+			if last[i].span[i] != rank-lastRank[i] {
+				return fmt.Errorf("jumplist: span %d before column %v is %d, expected %d", i, column.key, last[i].span[i], rank-lastRank[i])
+			}
+			if list.weigher != nil && !weighsAbout(last[i].sum[i], weighed[i], scale[i]) {
+				return fmt.Errorf("jumplist: weight sum %d before column %v is %v, expected %v", i, column.key, last[i].sum[i], weighed[i])
+			}
+			weighed[i], scale[i] = 0, 0
+			last[i], lastRank[i] = &column.pointerColumn, rank
+		}
+		prev = column
+	}
+
+	if rank != list.length {
+		return fmt.Errorf("jumplist: level 0 holds %d columns, length is %d", rank, list.length)
+	}
+	for i, pointers := range last {
+		if pointers.next[i] != nil {
+			return fmt.Errorf("jumplist: level %d links column %v that is not on level 0", i, pointers.next[i].key)
+		}
+		if pointers.span[i] != rank-lastRank[i] {
+			return fmt.Errorf("jumplist: span %d at the end of the list is %d, expected %d", i, pointers.span[i], rank-lastRank[i])
+		}
+		if list.weigher != nil && !weighsAbout(pointers.sum[i], weighed[i], scale[i]) {
+			return fmt.Errorf("jumplist: weight sum %d at the end of the list is %v, expected %v", i, pointers.sum[i], weighed[i])
+		}
+	}
+	return nil
+}
+
+// weighsAbout reports whether a kept weight sum matches the one just added up, allowing for the
+// different order of the additions.
+func weighsAbout(sum, expected, scale float64) bool {
+	return math.Abs(sum-expected) <= 1e-9*scale
+}
@@ -0,0 +1,35 @@
+package jumplist
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	list := New()
+	if err := list.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 1000; i++ {
+		list.Set(float64(i%300), i)
+	}
+	if err := list.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	breakers := map[string]func(l *SkipList){
+		"out of order": func(l *SkipList) { l.Front().Next().key = -1 },
+		"link back":    func(l *SkipList) { l.Back().prev = nil },
+		"length":       func(l *SkipList) { l.length++ },
+		"span":         func(l *SkipList) { l.startPointers.span[0] = 2 },
+		"skips":        func(l *SkipList) { l.startPointers.next[1] = nil },
+		"tall":         func(l *SkipList) { l.Front().next = append(l.Front().next, nil) },
+	}
+	for broken, breaker := range breakers {
+		l := list.Clone()
+		breaker(l)
+		if err := l.Validate(); err == nil || !strings.Contains(err.Error(), broken) {
+			t.Fatalf("%v: got %v", broken, err)
+		}
+	}
+}
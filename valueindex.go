@@ -0,0 +1,55 @@
+package jumplist
+
+// WithValueIndex keeps a map from keyFn(value) to the column holding the value, for lookups by value such
+// as member to score in a leaderboard, see GetByValueKey. Every insert, update and removal keeps it up to
+// date under the write lock. Values should map to distinct strings: when two columns share one, the index
+// points at the one written last.
+func WithValueIndex(keyFn func(value interface{}) string) Option {
+	return func(list *SkipList) {
+		list.valueKey = keyFn
+		list.valueIndex = map[string]*Column{}
+	}
+}
+
+// GetByValueKey returns the column whose value maps to valueKey, nil if there is none or the list has no
+// value index. It is a map lookup under the read lock.
+func (list *SkipList) GetByValueKey(valueKey string) *Column {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.valueIndex[valueKey]
+}
+
+// indexValue updates the value index for a change, old being the previous value for an update.
+func (list *SkipList) indexValue(kind EventKind, column *Column, old interface{}) {
+	if list.valueIndex == nil {
+		return
+	}
+	switch kind {
+	case EventInsert:
+		list.valueIndex[list.valueKey(column.Value)] = column
+	case EventUpdate:
+		list.unindex(list.valueKey(old), column)
+		list.valueIndex[list.valueKey(column.Value)] = column
+	case EventDelete:
+		list.unindex(list.valueKey(column.Value), column)
+	}
+}
+
+// unindex drops valueKey unless it already points at another column.
+func (list *SkipList) unindex(valueKey string, column *Column) {
+	if list.valueIndex[valueKey] == column {
+		delete(list.valueIndex, valueKey)
+	}
+}
+
+// reindex rebuilds the value index from level 0, for builders that link columns outside appendColumn.
+func (list *SkipList) reindex() {
+	if list.valueIndex == nil {
+		return
+	}
+	clear(list.valueIndex)
+	for column := list.startPointers.next[0]; column != nil; column = column.next[0] {
+		list.valueIndex[list.valueKey(column.Value)] = column
+	}
+}
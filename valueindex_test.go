@@ -0,0 +1,71 @@
+package jumplist
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestValueIndex(t *testing.T) {
+	member := func(v interface{}) string { return v.(string) }
+	list := New(WithValueIndex(member))
+	list.Set(30, "alice")
+	list.Set(10, "bob")
+	list.Set(20, "carol")
+
+	if c := list.GetByValueKey("bob"); c == nil || c.Key() != 10 {
+		t.Fatal("bob must score 10", c)
+	}
+	list.Set(10, "dave") //overwrites bob
+	if list.GetByValueKey("bob") != nil || list.GetByValueKey("dave").Key() != 10 {
+		t.Fatal("an overwritten value must leave the index")
+	}
+	list.UpdateKey(30, 40)
+	if c := list.GetByValueKey("alice"); c == nil || c.Key() != 40 {
+		t.Fatal("a moved value must follow its column", c)
+	}
+	list.Del(20)
+	list.RemoveRange(40, 40)
+	if list.GetByValueKey("carol") != nil || list.GetByValueKey("alice") != nil || list.GetByValueKey("dave") == nil {
+		t.Fatal("removed values must leave the index")
+	}
+
+	list.Clear()
+	if list.GetByValueKey("dave") != nil {
+		t.Fatal("Clear must empty the index")
+	}
+
+	keys := make([]float64, 1000)
+	values := make([]interface{}, len(keys))
+	for i := range keys {
+		keys[i], values[i] = float64(i), fmt.Sprint("m", i)
+	}
+	for name, built := range map[string]*SkipList{
+		"NewFromSorted": NewFromSorted(keys, values, WithValueIndex(member)),
+		"NewParallel":   NewParallel(18, 4, keys, values, WithValueIndex(member)),
+	} {
+		if c := built.GetByValueKey("m500"); c == nil || c.Key() != 500 {
+			t.Fatalf("%v must index the values it builds with", name)
+		}
+	}
+
+	if New().GetByValueKey("x") != nil {
+		t.Fatal("a list without an index finds nothing")
+	}
+}
+
+func TestValueIndexReplace(t *testing.T) {
+	list := New(WithValueIndex(func(v interface{}) string { return v.(string) }))
+	list.Set(1, "a")
+	list.ReplaceOrInsert(1, "b")
+	if list.GetByValueKey("a") != nil || list.GetByValueKey("b") != list.Get(1) {
+		t.Fatal("a replaced column must leave the index for its replacement")
+	}
+
+	updates := 0
+	hooked := New(OnUpdate(func(*Column, interface{}) { updates++ }))
+	hooked.Set(1, "a")
+	hooked.ReplaceOrInsert(1, "b")
+	if updates != 1 {
+		t.Fatal("replacing a column must count as an update outside LRU lists too")
+	}
+}
@@ -0,0 +1,92 @@
+package jumplist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// stringColumns caps the columns drawn by String, the rest are summarized.
+const stringColumns = 64
+
+// String draws the levels as ASCII, the top occupied level first. Each column prints its key on the
+// levels it takes part in and dashes on the others, so the towers line up:
+//
+//	2 | 1 - --- - --- 7
+//	1 | 1 - --- 4 --- 7
+//	0 | 1 2 3.5 4 5.5 7
+func (list *SkipList) String() string {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	var labels []string
+	var heights []int
+	top := 1
+	for column := list.startPointers.next[0]; column != nil && len(labels) < stringColumns; column = column.next[0] {
+		labels = append(labels, strconv.FormatFloat(column.key, 'g', -1, 64))
+		heights = append(heights, len(column.next))
+		top = max(top, len(column.next))
+	}
+
+	b := &strings.Builder{}
+	width := len(strconv.Itoa(top - 1))
+	for level := top - 1; level >= 0; level-- {
+		fmt.Fprintf(b, "%*d |", width, level)
+		for i, label := range labels {
+			if heights[i] > level {
+				b.WriteString(" " + label)
+			} else {
+				b.WriteString(" " + strings.Repeat("-", len(label)))
+			}
+		}
+		if level == 0 && list.length > len(labels) {
+			fmt.Fprintf(b, " ... (%d more)", list.length-len(labels))
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// WriteDOT writes the list as a graphviz digraph, one record node per column with a port per level
+// and an edge for every forward pointer. Render it with `dot -Tsvg`.
+func (list *SkipList) WriteDOT(w io.Writer) error {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString("digraph jumplist {\n\trankdir=LR;\n\tnode [shape=record];\n")
+
+	//the start pointers are node n0, each column is named by its rank
+	names := make(map[*Column]int, list.length)
+	writeNode := func(name int, label string, level int) {
+		ports := make([]string, level)
+		for i := range ports {
+			ports[level-1-i] = fmt.Sprintf("<l%d> %s", i, label)
+		}
+		fmt.Fprintf(bw, "\tn%d [label=\"%s\"];\n", name, strings.Join(ports, "|"))
+	}
+	writeNode(0, "head", list.maxLevel)
+	rank := 0
+	for column := list.startPointers.next[0]; column != nil; column = column.next[0] {
+		rank++
+		names[column] = rank
+		writeNode(rank, strconv.FormatFloat(column.key, 'g', -1, 64), len(column.next))
+	}
+
+	writeEdges := func(name int, pointers *pointerColumn) {
+		for i, next := range pointers.next {
+			if next != nil {
+				fmt.Fprintf(bw, "\tn%d:l%d -> n%d:l%d;\n", name, i, names[next], i)
+			}
+		}
+	}
+	writeEdges(0, &list.startPointers)
+	for column := list.startPointers.next[0]; column != nil; column = column.next[0] {
+		writeEdges(names[column], &column.pointerColumn)
+	}
+
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
@@ -0,0 +1,61 @@
+package jumplist
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	list := New()
+	if s := list.String(); s != "0 |\n" {
+		t.Fatalf("wrong picture of an empty list %q", s)
+	}
+
+	t1 := list.newTails()
+	for i, level := range []int{3, 1, 1, 2, 1, 3} {
+		list.appendColumn(t1, level, []float64{1, 2, 3.5, 4, 5.5, 7}[i], nil)
+	}
+	list.resize(0)
+
+	expected := "2 | 1 - --- - --- 7\n1 | 1 - --- 4 --- 7\n0 | 1 2 3.5 4 5.5 7\n"
+	if s := list.String(); s != expected {
+		t.Fatalf("got\n%s\nexpected\n%s", s, expected)
+	}
+
+	for i := 0; i < 100; i++ {
+		list.Set(float64(100+i), nil)
+	}
+	if s := list.String(); !strings.Contains(s, "... (42 more)\n") {
+		t.Fatal("long lists must be cut short", s)
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	list := New()
+	t1 := list.newTails()
+	list.appendColumn(t1, 2, 1, nil)
+	list.appendColumn(t1, 1, 2, nil)
+	list.resize(0)
+
+	buf := &bytes.Buffer{}
+	if err := list.WriteDOT(buf); err != nil {
+		t.Fatal(err)
+	}
+	dot := buf.String()
+	for _, line := range []string{
+		"digraph jumplist {",
+		`n1 [label="<l1> 1|<l0> 1"];`,
+		`n2 [label="<l0> 2"];`,
+		"n0:l1 -> n1:l1;",
+		"n0:l0 -> n1:l0;",
+		"n1:l0 -> n2:l0;",
+	} {
+		if !strings.Contains(dot, line) {
+			t.Fatalf("missing %q in\n%s", line, dot)
+		}
+	}
+	if strings.Contains(dot, "n1:l1 ->") {
+		t.Fatal("nil pointers must not be drawn", dot)
+	}
+}
@@ -0,0 +1,294 @@
+package jumplist
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	walSet byte = iota + 1
+	walSetNil
+	walDel
+
+	walHeader     = 8       //record length and CRC-32 of the payload
+	walMinCompact = 1024    //records before the log is worth compacting
+	walMaxRecord  = 1 << 30 //longest payload, a longer length field is corrupt
+)
+
+var (
+	errCorruptRecord = errors.New("jumplist: corrupt log record")
+	errLargeRecord   = errors.New("jumplist: log record too large")
+)
+
+// Persistent is a list whose changes are appended to a write-ahead log at path before they are applied.
+// The log is compacted into a checkpoint at path+".snapshot" once it holds more records than the list
+// has columns, and opening replays the checkpoint and then the log. Values are gob encoded, so their
+// concrete types must be registered with gob.Register unless they are basic types or have a codec,
+// see RegisterCodec.
+type Persistent struct {
+	mutex   sync.Mutex //orders the log like the changes
+	list    *SkipList
+	path    string
+	wal     *os.File
+	records int
+	scratch bytes.Buffer
+}
+
+// NewPersistent opens or creates the log at path and restores the list it describes.
+// A record torn by a crash at the end of the log is dropped, a corrupt one before the end is an error.
+func NewPersistent(path string, opts ...Option) (*Persistent, error) {
+	p := &Persistent{list: New(opts...), path: path}
+
+	if snapshot, err := os.Open(p.snapshotPath()); err == nil {
+		_, err = p.list.ReadFrom(bufio.NewReader(snapshot))
+		snapshot.Close()
+		if err != nil {
+			return nil, err
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	wal, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	p.wal = wal
+
+	valid, err := p.replay()
+	if err == nil {
+		err = wal.Truncate(valid)
+	}
+	if err == nil {
+		_, err = wal.Seek(valid, io.SeekStart)
+	}
+	if err != nil {
+		wal.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Persistent) snapshotPath() string {
+	return p.path + ".snapshot"
+}
+
+// replay applies the log records and returns the offset after the last complete one. Only the last
+// record may be short or fail its CRC, which is how a crash during its write leaves it.
+func (p *Persistent) replay() (int64, error) {
+	info, err := p.wal.Stat()
+	if err != nil {
+		return 0, err
+	}
+	size := info.Size()
+	r := bufio.NewReader(p.wal)
+	var valid int64
+	header := make([]byte, walHeader)
+
+	for valid < size {
+		if size-valid < walHeader {
+			return valid, nil //torn header
+		}
+		if _, err := io.ReadFull(r, header); err != nil {
+			return 0, err
+		}
+		length := binary.BigEndian.Uint32(header)
+		if length > walMaxRecord {
+			return 0, fmt.Errorf("%w at offset %v", errCorruptRecord, valid)
+		}
+		end := valid + walHeader + int64(length)
+		if end > size {
+			return valid, nil //torn record, nothing is allocated for the bytes missing
+		}
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return 0, err
+		}
+		if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(header[4:]) {
+			if end == size {
+				return valid, nil //torn record
+			}
+			return 0, fmt.Errorf("%w at offset %v", errCorruptRecord, valid)
+		}
+		if err := p.apply(payload); err != nil {
+			return 0, err
+		}
+		valid = end
+		p.records++
+	}
+	return valid, nil
+}
+
+func (p *Persistent) apply(payload []byte) error {
+	if len(payload) < 9 {
+		return errCorruptRecord
+	}
+	key := math.Float64frombits(binary.BigEndian.Uint64(payload[1:9]))
+
+	switch payload[0] {
+	case walSet:
+		var value interface{}
+		err := gob.NewDecoder(bytes.NewReader(payload[9:])).Decode(&value)
+		if err == nil {
+			value, err = decodeValue(value)
+		}
+		if err != nil {
+			return err
+		}
+		p.list.Set(key, value)
+	case walSetNil:
+		p.list.Set(key, nil)
+	case walDel:
+		p.list.Del(key)
+	default:
+		return errCorruptRecord
+	}
+	return nil
+}
+
+// append writes one record to the log, compacting it first if it grew past the list.
+func (p *Persistent) append(op byte, key float64, value interface{}) error {
+	if p.records >= walMinCompact && p.records > p.list.Len() {
+		if err := p.compact(); err != nil {
+			return err
+		}
+	}
+
+	p.scratch.Reset()
+	p.scratch.Write(make([]byte, walHeader+9))
+	if op == walSet {
+		encoded, err := encodeValue(value)
+		if err == nil {
+			err = gob.NewEncoder(&p.scratch).Encode(&encoded)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	record := p.scratch.Bytes()
+	payload := record[walHeader:]
+	if len(payload) > walMaxRecord {
+		return errLargeRecord //replay would take it for a corrupt length
+	}
+	payload[0] = op
+	binary.BigEndian.PutUint64(payload[1:9], math.Float64bits(key))
+	binary.BigEndian.PutUint32(record, uint32(len(payload)))
+	binary.BigEndian.PutUint32(record[4:], crc32.ChecksumIEEE(payload))
+
+	if _, err := p.wal.Write(record); err != nil {
+		return err
+	}
+	p.records++
+	return nil
+}
+
+// Set logs the change and then applies it. Changes reach the file right away, Sync makes them durable.
+func (p *Persistent) Set(key float64, value interface{}) (*Column, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	op := walSet
+	if value == nil {
+		op = walSetNil //gob cannot encode a nil interface
+	}
+	if err := p.append(op, key, value); err != nil {
+		return nil, err
+	}
+	return p.list.Set(key, value), nil
+}
+
+// Del logs the removal and then applies it, returning the removed column like SkipList.Del.
+func (p *Persistent) Del(key float64) (*Column, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if err := p.append(walDel, key, nil); err != nil {
+		return nil, err
+	}
+	return p.list.Del(key), nil
+}
+
+// Get looks key up without touching the log.
+func (p *Persistent) Get(key float64) *Column {
+	return p.list.Get(key)
+}
+
+// List returns the list behind the log for reading. Writing to it directly bypasses the log.
+func (p *Persistent) List() *SkipList {
+	return p.list
+}
+
+// Compact writes the list to the checkpoint and empties the log.
+func (p *Persistent) Compact() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.compact()
+}
+
+// compact replaces the checkpoint atomically before truncating the log. A crash in between replays
+// the old log over the new checkpoint, which ends in the same state.
+func (p *Persistent) compact() error {
+	tmp, err := os.CreateTemp(filepath.Dir(p.path), "jumplist-snapshot-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //no-op once renamed
+
+	w := bufio.NewWriter(tmp)
+	_, err = p.list.WriteTo(w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), p.snapshotPath())
+	}
+	if err == nil {
+		err = p.wal.Truncate(0)
+	}
+	if err == nil {
+		_, err = p.wal.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		return err
+	}
+
+	p.records = 0
+	return nil
+}
+
+// Sync flushes the log to stable storage.
+func (p *Persistent) Sync() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.wal.Sync()
+}
+
+// Close syncs and closes the log. The list stays readable.
+func (p *Persistent) Close() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if err := p.wal.Sync(); err != nil {
+		p.wal.Close()
+		return err
+	}
+	return p.wal.Close()
+}
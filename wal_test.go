@@ -0,0 +1,138 @@
+package jumplist
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPersistent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "list.wal")
+
+	p, err := NewPersistent(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		if _, err := p.Set(float64(i), i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	p.Set(5, nil)
+	p.Del(6)
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err = NewPersistent(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSanity(p.List(), t)
+	if p.List().Len() != 99 || p.Get(6) != nil || p.Get(5).Value != nil || p.Get(7).Value.(int) != 7 {
+		t.Fatal("reopening must replay the log", p.List().Len())
+	}
+
+	//a torn record at the end is dropped
+	p.Set(1000, "torn")
+	p.Close()
+	info, _ := os.Stat(path)
+	os.Truncate(path, info.Size()-3)
+
+	p, err = NewPersistent(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Get(1000) != nil || p.List().Len() != 99 {
+		t.Fatal("torn record must be dropped")
+	}
+	p.Set(1001, "after")
+	p.Close()
+	if p, err = NewPersistent(path); err != nil || p.Get(1001) == nil {
+		t.Fatal("log must stay appendable after dropping a torn record", err)
+	}
+
+	//compaction moves the list into the checkpoint and empties the log
+	if err := p.Compact(); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := os.Stat(path); info.Size() != 0 {
+		t.Fatal("compaction must empty the log", info.Size())
+	}
+	p.Del(1001)
+	p.Close()
+	p, err = NewPersistent(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSanity(p.List(), t)
+	if p.List().Len() != 99 || p.Get(1001) != nil || p.Get(7).Value.(int) != 7 {
+		t.Fatal("reopening must load the checkpoint and replay the log over it", p.List().Len())
+	}
+
+	//the log compacts itself once it outgrows the list
+	for i := 0; i < 3*walMinCompact; i++ {
+		p.Set(float64(i%10), i)
+	}
+	if p.records >= 2*walMinCompact {
+		t.Fatal("log was not compacted", p.records)
+	}
+	p.Set(9, "last")
+	p.Close()
+	if p, err = NewPersistent(path); err != nil || p.Get(9).Value != "last" || p.Get(8).Value.(int) != 3*walMinCompact-4 {
+		t.Fatal("automatic compaction must keep the latest values", err)
+	}
+	p.Close()
+}
+
+func TestPersistentCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "list.wal")
+	write := func() []byte {
+		os.Remove(path)
+		p, err := NewPersistent(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 10; i++ {
+			p.Set(float64(i), i)
+		}
+		p.Close()
+		log, _ := os.ReadFile(path)
+		return log
+	}
+	reopen := func(log []byte) (*Persistent, error) {
+		os.WriteFile(path, log, 0o644)
+		return NewPersistent(path)
+	}
+
+	log := write()
+	log[len(log)-1] ^= 0xff //the last record fails its CRC as if its write was torn
+	if p, err := reopen(log); err != nil || p.List().Len() != 9 {
+		t.Fatal("a corrupt last record must be dropped", err)
+	}
+
+	log = write()
+	log[walHeader+3] ^= 0xff //inside the payload of the first record
+	if _, err := reopen(log); !errors.Is(err, errCorruptRecord) {
+		t.Fatal("a corrupt record before the end must fail the replay, got", err)
+	}
+
+	log = write()
+	binary.BigEndian.PutUint32(log, math.MaxUint32) //a length field no record has
+	if _, err := reopen(log); !errors.Is(err, errCorruptRecord) {
+		t.Fatal("an oversized length must fail the replay, got", err)
+	}
+
+	log = write()
+	last := 0
+	for next := 0; next < len(log); next += walHeader + int(binary.BigEndian.Uint32(log[next:])) {
+		last = next
+	}
+	binary.BigEndian.PutUint32(log[last:], 1<<20) //the length of the last record, past the end
+	if p, err := reopen(log); err != nil || p.List().Len() != 9 {
+		t.Fatal("a record running past the end must be dropped", err)
+	}
+}
@@ -0,0 +1,171 @@
+package jumplist
+
+import (
+	"context"
+	"sync"
+)
+
+// EventKind tells what happened to the key of an Event.
+type EventKind int
+
+const (
+	EventInsert EventKind = iota + 1 //a new column, from Set, SetBatch, Append and the like
+	EventUpdate                      //the value of an existing column changed
+	EventDelete                      //a column left the list: Del, ranges, eviction or expiry
+)
+
+// Event is a change to one key. For EventDelete, Value is the value removed.
+type Event struct {
+	Kind  EventKind
+	Key   float64
+	Value interface{}
+}
+
+// watcher queues the events of one Watch or Changes. The list appends under its write lock without
+// ever blocking and a goroutine feeds the channel, so a slow reader delays nothing and misses nothing.
+type watcher struct {
+	min, max float64
+	every    bool //of Changes, every key whatever the order of the list
+	mutex    sync.Mutex
+	queue    []ChangeRecord
+	ending   bool          //close the channel once the queue is drained
+	wake     chan struct{} //signalled when the queue grows or ends
+	done     chan struct{}
+	stop     sync.Once
+	out      chan Event        //of a Watch
+	records  chan ChangeRecord //of a Changes stream
+}
+
+func newWatcher(min, max float64) *watcher {
+	return &watcher{min: min, max: max, wake: make(chan struct{}, 1), done: make(chan struct{})}
+}
+
+// Watch returns a channel receiving every change to a key within [min, max] in the order it happened,
+// until cancel is called, which closes the channel. Clear, Split, Reset and the loads that replace the
+// whole list are not reported. Events are queued without bound, so a reader must keep up or cancel.
+func (list *SkipList) Watch(min, max float64) (<-chan Event, context.CancelFunc) {
+	w := newWatcher(min, max)
+	w.out = make(chan Event)
+
+	list.mutex.Lock()
+	list.watchers = append(list.watchers, w)
+	list.mutex.Unlock()
+
+	go w.run()
+
+	return w.out, func() { list.cancel(w) }
+}
+
+// cancel unregisters w and stops its goroutine, dropping what it has not delivered yet.
+func (list *SkipList) cancel(w *watcher) {
+	w.stop.Do(func() {
+		list.mutex.Lock()
+		list.unwatch(w)
+		list.mutex.Unlock()
+		close(w.done)
+	})
+}
+
+// unwatch removes w from the watchers, if it is still there.
+func (list *SkipList) unwatch(w *watcher) {
+	for i, other := range list.watchers {
+		if other == w {
+			list.watchers = append(list.watchers[:i:i], list.watchers[i+1:]...)
+			return
+		}
+	}
+}
+
+// signal wakes the goroutine of w, unless it is already signalled.
+func (w *watcher) signal() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (w *watcher) run() {
+	if w.records != nil {
+		defer close(w.records)
+	} else {
+		defer close(w.out)
+	}
+	for {
+		w.mutex.Lock()
+		records, ending := w.queue, w.ending
+		w.queue = nil
+		w.mutex.Unlock()
+
+		if len(records) == 0 {
+			if ending {
+				return
+			}
+			select {
+			case <-w.wake:
+				continue
+			case <-w.done:
+				return
+			}
+		}
+		for _, r := range records {
+			if !w.send(r) {
+				return
+			}
+		}
+	}
+}
+
+// send delivers r, as a bare Event to a Watch, and reports false once w is cancelled.
+func (w *watcher) send(r ChangeRecord) bool {
+	if w.records != nil {
+		select {
+		case w.records <- r:
+			return true
+		case <-w.done:
+			return false
+		}
+	}
+	select {
+	case w.out <- r.Event:
+		return true
+	case <-w.done:
+		return false
+	}
+}
+
+// track keeps the value index, the Bloom filter, the metadata, the aggregates and the sequence numbers
+// up to date for a change. notify calls it, builders that link or overwrite columns without notifying
+// call it themselves.
+func (list *SkipList) track(kind EventKind, column *Column, old interface{}) {
+	list.indexValue(kind, column, old)
+	list.filterKey(kind, column)
+	list.stamp(kind, column)
+	list.aggregate(kind, column, old)
+	if kind != EventDelete {
+		list.seq++
+		column.seq = list.seq
+	}
+}
+
+// notify hands a change to the hooks and the watchers of its key. It is called holding the write lock,
+// old being the previous value for an update.
+func (list *SkipList) notify(kind EventKind, column *Column, old interface{}) {
+	list.countChange(kind)
+	list.track(kind, column, old)
+	if kind == EventUpdate {
+		list.reweighColumn(column)
+	}
+	list.changes++
+	if list.hooked() {
+		list.queueHook(kind, column, old)
+	}
+	for _, w := range list.watchers {
+		if !w.every && (list.keyLess(column.key, w.min) || list.keyLess(w.max, column.key)) {
+			continue
+		}
+		w.mutex.Lock()
+		w.queue = append(w.queue, ChangeRecord{list.changes, Event{kind, column.key, column.Value}})
+		w.mutex.Unlock()
+		w.signal()
+	}
+}
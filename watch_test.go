@@ -0,0 +1,67 @@
+package jumplist
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	list := New()
+	list.Set(5, "before")
+	events, cancel := list.Watch(0, 10)
+
+	list.Set(1, "a")  //insert
+	list.Set(1, "b")  //update
+	list.Set(20, nil) //outside the range
+	list.Accumulate(1, "c", func(current, delta interface{}) interface{} { return current.(string) + delta.(string) })
+	list.Del(5)             //delete
+	list.RemoveRange(0, 30) //deletes 1, and 20 outside the range
+	list.Append(9, "tail")
+
+	expected := []Event{
+		{EventInsert, 1, "a"},
+		{EventUpdate, 1, "b"},
+		{EventUpdate, 1, "bc"},
+		{EventDelete, 5, "before"},
+		{EventDelete, 1, "bc"},
+		{EventInsert, 9, "tail"},
+	}
+	for _, e := range expected {
+		select {
+		case got := <-events:
+			if got != e {
+				t.Fatalf("got %v, expected %v", got, e)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("missing event", e)
+		}
+	}
+
+	cancel()
+	cancel() //idempotent
+	list.Set(2, nil)
+	for e := range events {
+		t.Fatal("no event may follow cancel", e)
+	}
+	if len(list.watchers) != 0 {
+		t.Fatal("cancel must unregister the watcher")
+	}
+}
+
+func TestWatchEviction(t *testing.T) {
+	list := NewWithCapacity(2, EvictSmallest)
+	events, cancel := list.Watch(-100, 100)
+	defer cancel()
+
+	for i := 0; i < 1000; i++ { //nobody reads while the list changes, nothing may block
+		list.Set(float64(i%100), i)
+	}
+	for i := 0; i < 3; i++ {
+		if e := <-events; e.Kind != EventInsert || e.Key != float64(i) {
+			t.Fatal("wrong event", e)
+		}
+	}
+	if e := <-events; e.Kind != EventDelete || e.Key != 0 {
+		t.Fatal("evictions must be reported", e)
+	}
+}
@@ -0,0 +1,142 @@
+package jumplist
+
+// WithWeights augments every forward pointer with the sum of weight(value) over the columns its span
+// steps over, so SumRange and WeightedRank add up weights in O(log n) the way Count and Rank add up
+// spans, such as the quantity resting within a price range of an order book or the quota used by the
+// keys below a bound. nil weighs the Go integer and float types by their value and everything else,
+// NaN included, as 0. Weights must be finite and may only change through the list, by Set, Upsert,
+// StoreValue and the like, since the sums are fixed up as the list sees each change.
+//
+// Inserts, updates and removals cost O(log n) more under the write lock, an update also descending to
+// its column, and Append descends like Set instead of keeping the tails. Builders such as NewFromSorted
+// and Load add one O(n) pass. Clone keeps it, but like WithAggregates it is not carried over to the
+// lists made by Split, Freeze and the like.
+func WithWeights(weight func(value interface{}) float64) Option {
+	return func(list *SkipList) {
+		if weight == nil {
+			weight = func(value interface{}) float64 {
+				if x, ok := numeric(value); ok && x == x {
+					return x
+				}
+				return 0
+			}
+		}
+		list.weigher = weight
+		list.startPointers.sum = make([]float64, list.maxLevel)
+	}
+}
+
+// resized returns sum with length n, reusing its array when it is large enough.
+func resized(sum []float64, n int) []float64 {
+	if cap(sum) < n {
+		return make([]float64, n)
+	}
+	return sum[:n]
+}
+
+// reweigh recomputes the sum of pointers on level i, the weight of the next column on level 0 and
+// above it the sums of the pointers on level i-1 up to next[i], which must be right already.
+func (list *SkipList) reweigh(pointers *pointerColumn, i int) {
+	if i == 0 {
+		pointers.sum[0] = 0
+		if next := pointers.next[0]; next != nil {
+			pointers.sum[0] = list.weigher(next.Value)
+		}
+		return
+	}
+
+	sum := 0.0
+	for below := pointers; ; below = &below.next[i-1].pointerColumn {
+		sum += below.sum[i-1]
+		if below.next[i-1] == pointers.next[i] {
+			break
+		}
+	}
+	pointers.sum[i] = sum
+}
+
+// reweighCursors brings the sums up to date from the bottom up after column was linked right after
+// the cursors, or with column nil after the column there was unlinked or weighs differently. Only the
+// cursors and the new column cover a changed span, and each of them sums about 1/p pointers below it.
+func (list *SkipList) reweighCursors(column *Column) {
+	if list.weigher == nil {
+		return
+	}
+	for i := 0; i < list.maxLevel; i++ {
+		if column != nil && i < len(column.next) {
+			list.reweigh(&column.pointerColumn, i)
+		}
+		list.reweigh(list.levelCursors[i], i)
+	}
+}
+
+// reweighColumn updates the sums over column after its value changed, moving the cursors before it.
+func (list *SkipList) reweighColumn(column *Column) {
+	if list.weigher == nil {
+		return
+	}
+	list.moveCursors(column.key)
+	for list.levelCursors[0].next[0] != column { //past the equal keys before it in a multiset
+		list.stepCursors()
+	}
+	list.reweighCursors(nil)
+}
+
+// reweighAll sizes and recomputes every sum in O(n), for builders that link columns at the tails.
+func (list *SkipList) reweighAll() {
+	if list.weigher == nil {
+		return
+	}
+	list.startPointers.sum = resized(list.startPointers.sum, list.maxLevel)
+	for column := list.startPointers.next[0]; column != nil; column = column.next[0] {
+		column.sum = resized(column.sum, len(column.next))
+	}
+	for i := 0; i < list.maxLevel; i++ {
+		for pointers := &list.startPointers; ; pointers = &pointers.next[i].pointerColumn {
+			list.reweigh(pointers, i)
+			if pointers.next[i] == nil {
+				break
+			}
+		}
+	}
+}
+
+// weightBelow adds up the weights of the columns with keys less than key, or not greater than key
+// with through set, on the way down like rank.
+func (list *SkipList) weightBelow(key float64, through bool) float64 {
+	pointers := &list.startPointers
+	sum := 0.0
+
+	for i := list.maxLevel - 1; i >= 0; i-- {
+		for next := pointers.next[i]; next != nil && (list.keyLess(next.key, key) || through && key == next.key); next = pointers.next[i] {
+			sum += pointers.sum[i]
+			pointers = &next.pointerColumn
+		}
+	}
+	return sum
+}
+
+// WeightedRank returns the total weight of the columns with keys less than key, the weighted
+// counterpart of Rank, in O(log n). It is 0 without WithWeights.
+func (list *SkipList) WeightedRank(key float64) float64 {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	if list.weigher == nil {
+		return 0
+	}
+	return list.weightBelow(key, false)
+}
+
+// SumRange returns the total weight of the columns with keys within [min, max] from two descents like
+// Count, in O(log n) however many columns it covers. Being a difference of two sums it may be off by
+// rounding for weights that are not integers. It is 0 without WithWeights.
+func (list *SkipList) SumRange(min, max float64) float64 {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	if list.weigher == nil || list.keyLess(max, min) {
+		return 0
+	}
+	return list.weightBelow(max, true) - list.weightBelow(min, false)
+}
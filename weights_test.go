@@ -0,0 +1,146 @@
+package jumplist
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestWeights(t *testing.T) {
+	list := New(WithWeights(nil), WithSeed(1))
+	if list.SumRange(math.Inf(-1), math.Inf(1)) != 0 || list.WeightedRank(5) != 0 {
+		t.Fatal("an empty list weighs nothing")
+	}
+
+	model := map[float64]int{}
+	sumRange := func(min, max float64) int {
+		sum := 0
+		for key, w := range model {
+			if key >= min && key <= max {
+				sum += w
+			}
+		}
+		return sum
+	}
+	check := func(step int) {
+		t.Helper()
+		if err := list.Validate(); err != nil {
+			t.Fatal("after step", step, err)
+		}
+		for _, bounds := range [][2]float64{{0, 1000}, {100, 250}, {37, 37}, {-5, 3}, {900, 2000}} {
+			if got := list.SumRange(bounds[0], bounds[1]); got != float64(sumRange(bounds[0], bounds[1])) {
+				t.Fatalf("after step %v SumRange(%v, %v) is %v, expected %v", step, bounds[0], bounds[1], got, sumRange(bounds[0], bounds[1]))
+			}
+		}
+		if got := list.WeightedRank(500); got != float64(sumRange(math.Inf(-1), 499)) {
+			t.Fatalf("after step %v WeightedRank(500) is %v, expected %v", step, got, sumRange(math.Inf(-1), 499))
+		}
+	}
+
+	r := rand.New(rand.NewSource(1))
+	next := 1000.0 //keys past the end for Append
+	for i := 0; i < 4000; i++ {
+		key, w := float64(r.Intn(1000)), r.Intn(100)-20
+		switch r.Intn(8) {
+		case 0:
+			list.Del(key)
+			delete(model, key)
+		case 1:
+			list.Upsert(key, func(old interface{}, exists bool) interface{} {
+				if exists {
+					return old.(int) + w
+				}
+				return w
+			})
+			model[key] += w
+		case 2:
+			list.ReplaceOrInsert(key, w)
+			model[key] = w
+		case 3:
+			if column := list.Get(key); column != nil {
+				list.StoreValue(column, w)
+				model[key] = w
+			}
+		case 4:
+			list.Append(next, w)
+			model[next] = w
+			next++
+		case 5:
+			list.SetBatch([]KV{{key, w}, {key + 1, w}})
+			model[key], model[key+1] = w, w
+		default:
+			list.Set(key, w)
+			model[key] = w
+		}
+		if i%500 == 0 {
+			check(i)
+		}
+	}
+	check(-1)
+
+	list.RemoveRange(100, 300)
+	for key := range model {
+		if key >= 100 && key <= 300 {
+			delete(model, key)
+		}
+	}
+	check(-2)
+	for list.Front().Key() < 50 {
+		delete(model, list.PopMin().Key())
+	}
+	check(-3)
+}
+
+func TestWeightsBuilt(t *testing.T) {
+	keys, values := make([]float64, 3000), make([]interface{}, 3000)
+	total := 0
+	for i := range keys {
+		keys[i], values[i] = float64(i), i%7
+		total += i % 7
+	}
+	weight := func(value interface{}) float64 { return float64(value.(int)) }
+
+	list := NewFromSorted(keys, values, WithWeights(weight))
+	if err := list.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if got := list.SumRange(0, 3000); got != float64(total) {
+		t.Fatal("NewFromSorted must sum every weight, got", got, "expected", total)
+	}
+
+	list.RemoveRange(0, 2899) //heavy removals leave the levels tall
+	list.Compact(true)
+	if err := list.Validate(); err != nil {
+		t.Fatal("after Compact:", err)
+	}
+
+	items := make([]KV, len(keys))
+	for i := range keys {
+		items[i] = KV{keys[i], values[i]}
+	}
+	list.LoadParallel(items, 4)
+	if err := list.Validate(); err != nil {
+		t.Fatal("after LoadParallel:", err)
+	}
+	if got := list.WeightedRank(7); got != 21 {
+		t.Fatal("WeightedRank(7) is", got, "expected 21")
+	}
+
+	dup := New(AllowDuplicates(), WithWeights(weight))
+	expected := 0.0
+	for i := 0; i < 500; i++ {
+		dup.Set(float64(i%10), i%3)
+		if i%10 == 0 {
+			expected += float64(i % 3)
+		}
+	}
+	second := dup.Front().Next()
+	dup.StoreValue(second, 100) //among equal keys, changing the weight of this very column
+	expected += 100 - 1
+	if err := dup.Validate(); err != nil {
+		t.Fatal("multiset:", err)
+	}
+	if got := dup.SumRange(0, 0); got != expected {
+		t.Fatal("SumRange(0, 0) of the multiset is", got, "expected", expected)
+	}
+}
@@ -0,0 +1,142 @@
+// Package zset provides a Redis style sorted set: string members ordered by a float64 score,
+// kept in a jumplist.SkipList holding every score with its member, plus a map from member to its column.
+// Members with equal scores are ordered lexicographically, as in Redis.
+package zset
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/abbychau/jumplist"
+)
+
+// Member is a member of the set together with its score.
+type Member struct {
+	Name  string
+	Score float64
+}
+
+// SortedSet is safe for concurrent use.
+type SortedSet struct {
+	mutex   sync.RWMutex //keeps the list and the map in step
+	list    *jumplist.SkipList
+	members map[string]*jumplist.Column
+}
+
+// errNaNScore is the panic of ZAdd and ZIncrBy for a NaN score, which has no place in the order.
+var errNaNScore = errors.New("zset: score is NaN")
+
+// New returns an empty sorted set.
+func New() *SortedSet {
+	byMember := func(a, b interface{}) bool { return a.(string) < b.(string) }
+	return &SortedSet{list: jumplist.New(jumplist.WithTieBreaker(byMember)), members: map[string]*jumplist.Column{}}
+}
+
+// ZAdd sets the score of member and reports whether it was added rather than updated.
+// It panics on a NaN score, leaving the set unchanged.
+func (set *SortedSet) ZAdd(member string, score float64) bool {
+	if score != score {
+		panic(errNaNScore)
+	}
+	set.mutex.Lock()
+	defer set.mutex.Unlock()
+
+	column, ok := set.members[member]
+	if ok && column.Key() == score {
+		return false
+	}
+	set.move(member, column, score)
+	return !ok
+}
+
+// ZIncrBy adds delta to the score of member, starting from 0 if it is absent, and returns the new score.
+// It panics if the new score is NaN, such as adding -Inf to +Inf, leaving the set unchanged.
+func (set *SortedSet) ZIncrBy(member string, delta float64) float64 {
+	set.mutex.Lock()
+	defer set.mutex.Unlock()
+
+	column, ok := set.members[member]
+	score := delta
+	if ok {
+		score += column.Key()
+	}
+	if score != score {
+		panic(errNaNScore)
+	}
+	set.move(member, column, score)
+	return score
+}
+
+// move gives member the score, column being the one it has, nil if it is new. Only that column is
+// unlinked, the other members with its score keep their places.
+func (set *SortedSet) move(member string, column *jumplist.Column, score float64) {
+	if column != nil {
+		set.list.RemoveElement(column)
+	}
+	set.members[member] = set.list.Set(score, member)
+}
+
+// ZScore returns the score of member and whether it is in the set.
+func (set *SortedSet) ZScore(member string) (float64, bool) {
+	set.mutex.RLock()
+	defer set.mutex.RUnlock()
+
+	if column, ok := set.members[member]; ok {
+		return column.Key(), true
+	}
+	return 0, false
+}
+
+// ZRank returns the 0-based position of member in ascending score order and whether it is in the set.
+func (set *SortedSet) ZRank(member string) (int, bool) {
+	set.mutex.RLock()
+	defer set.mutex.RUnlock()
+
+	column, ok := set.members[member]
+	if !ok {
+		return 0, false
+	}
+	rank := set.list.Rank(column.Key())
+	for _, tied := range set.list.GetAll(column.Key()) {
+		if tied == column {
+			break
+		}
+		rank++
+	}
+	return rank, true
+}
+
+// ZRangeByScore returns the members with a score within [min, max] in ascending order.
+func (set *SortedSet) ZRangeByScore(min, max float64) []Member {
+	set.mutex.RLock()
+	defer set.mutex.RUnlock()
+
+	columns := set.list.Range(min, max)
+	members := make([]Member, len(columns))
+	for i, column := range columns {
+		members[i] = Member{column.Value.(string), column.Key()}
+	}
+	return members
+}
+
+// ZRem removes member and reports whether it was in the set.
+func (set *SortedSet) ZRem(member string) bool {
+	set.mutex.Lock()
+	defer set.mutex.Unlock()
+
+	column, ok := set.members[member]
+	if !ok {
+		return false
+	}
+	set.list.RemoveElement(column)
+	delete(set.members, member)
+	return true
+}
+
+// ZCard returns the number of members.
+func (set *SortedSet) ZCard() int {
+	set.mutex.RLock()
+	defer set.mutex.RUnlock()
+
+	return len(set.members)
+}
@@ -0,0 +1,102 @@
+package zset
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestSortedSet(t *testing.T) {
+	set := New()
+	if !set.ZAdd("alice", 30) || !set.ZAdd("bob", 10) || !set.ZAdd("carol", 20) || !set.ZAdd("dave", 20) {
+		t.Fatal("new members must be reported as added")
+	}
+	if set.ZAdd("alice", 40) || set.ZAdd("alice", 40) {
+		t.Fatal("existing members must be reported as updated")
+	}
+	if set.ZCard() != 4 {
+		t.Fatalf("set holds %v members, expected 4", set.ZCard())
+	}
+
+	if score, ok := set.ZScore("alice"); !ok || score != 40 {
+		t.Fatal("wrong score for alice", score, ok)
+	}
+	if _, ok := set.ZScore("nobody"); ok {
+		t.Fatal("missing member must have no score")
+	}
+
+	expected := []string{"bob", "carol", "dave", "alice"}
+	for i, name := range expected {
+		if rank, ok := set.ZRank(name); !ok || rank != i {
+			t.Fatalf("%v ranks %v, expected %v", name, rank, i)
+		}
+	}
+
+	members := set.ZRangeByScore(15, 35)
+	if fmt.Sprint(members) != "[{carol 20} {dave 20}]" {
+		t.Fatal("wrong range", members)
+	}
+
+	//removing a tied member keeps the others in their order
+	set.ZAdd("erin", 20)
+	if !set.ZRem("dave") || set.ZRem("dave") {
+		t.Fatal("ZRem must report whether the member was there")
+	}
+	if members := set.ZRangeByScore(20, 20); fmt.Sprint(members) != "[{carol 20} {erin 20}]" {
+		t.Fatal("wrong ties after ZRem", members)
+	}
+	if rank, _ := set.ZRank("erin"); rank != 2 {
+		t.Fatal("wrong rank after ZRem", rank)
+	}
+
+	if score := set.ZIncrBy("bob", 25); score != 35 {
+		t.Fatal("wrong score after ZIncrBy", score)
+	}
+	if score := set.ZIncrBy("frank", 1.5); score != 1.5 {
+		t.Fatal("ZIncrBy must start a missing member from 0", score)
+	}
+	if members := set.ZRangeByScore(0, 100); fmt.Sprint(members) != "[{frank 1.5} {carol 20} {erin 20} {bob 35} {alice 40}]" {
+		t.Fatal("wrong order after ZIncrBy", members)
+	}
+}
+
+func TestSortedSetTies(t *testing.T) {
+	set := New()
+	for _, name := range []string{"dave", "bob", "erin", "alice", "carol"} {
+		set.ZAdd(name, 1)
+	}
+	if members := set.ZRangeByScore(1, 1); fmt.Sprint(members) != "[{alice 1} {bob 1} {carol 1} {dave 1} {erin 1}]" {
+		t.Fatal("ties must be ordered by member", members)
+	}
+	if rank, _ := set.ZRank("dave"); rank != 3 {
+		t.Fatal("wrong rank among ties", rank)
+	}
+
+	set.ZAdd("carol", 2)
+	set.ZIncrBy("carol", -1) //back among the ties, in its place by name
+	set.ZRem("bob")
+	if members := set.ZRangeByScore(1, 1); fmt.Sprint(members) != "[{alice 1} {carol 1} {dave 1} {erin 1}]" {
+		t.Fatal("moving a member must leave the other ties in place", members)
+	}
+}
+
+func TestSortedSetNaN(t *testing.T) {
+	set := New()
+	set.ZAdd("alice", math.Inf(1))
+	for name, fn := range map[string]func(){
+		"ZAdd":    func() { set.ZAdd("alice", math.NaN()) },
+		"ZIncrBy": func() { set.ZIncrBy("alice", math.Inf(-1)) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error(name, "must panic on a NaN score")
+				}
+			}()
+			fn()
+		}()
+	}
+	if score, ok := set.ZScore("alice"); !ok || score != math.Inf(1) || set.ZCard() != 1 || len(set.ZRangeByScore(0, math.Inf(1))) != 1 {
+		t.Fatal("a rejected score must leave the set unchanged", score, ok)
+	}
+}